@@ -0,0 +1,41 @@
+// Command createtenant registers a legal entity whose books are kept apart
+// from every other tenant and prints its ID.
+package main
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/db"
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/requests/tenant"
+	"accountingsystem/internal/services"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	code := flag.String("code", "", "unique code of the tenant")
+	title := flag.String("title", "", "title of the tenant")
+	flag.Parse()
+
+	if err := configs.InitConfig(".env"); err != nil {
+		log.Fatalf("Error initing config: %v\n", err)
+		return
+	}
+	theDB, err := db.Init()
+	if err != nil {
+		log.Fatalf("Failed to connect to the database: %v", err)
+		return
+	}
+
+	tenantService := &services.TenantService{}
+	tenantService.InitService(theDB)
+
+	tenantDto, err := tenantService.CreateTenant(auth.System(auth.DefaultTenantID), &tenant.InsertRequest{Code: *code, Title: *title})
+	if err != nil {
+		log.Fatalf("Failed to create tenant: %v", err)
+		return
+	}
+
+	fmt.Println(tenantDto.ID)
+}
@@ -0,0 +1,52 @@
+// Command createuser registers a user in a tenant and prints its bearer
+// token. It runs as the system principal, so it is how the first admin of a
+// tenant is created.
+package main
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/db"
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/requests/tenant"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/services"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	username := flag.String("username", "", "name of the user")
+	tenantCode := flag.String("tenant", "default", "code of the tenant the user belongs to")
+	role := flag.String("role", string(auth.Admin), "one of viewer, accountant, approver or admin")
+	flag.Parse()
+
+	if err := configs.InitConfig(".env"); err != nil {
+		log.Fatalf("Error initing config: %v\n", err)
+		return
+	}
+	theDB, err := db.Init()
+	if err != nil {
+		log.Fatalf("Failed to connect to the database: %v", err)
+		return
+	}
+
+	tenantService := &services.TenantService{}
+	tenantService.InitService(theDB)
+	userService := &services.UserService{}
+	userService.InitService(theDB)
+
+	tenantDto, err := tenantService.GetTenantByCode(auth.System(auth.DefaultTenantID), &tenant.GetByCodeRequest{Code: *tenantCode})
+	if err != nil {
+		log.Fatalf("Failed to find tenant: %v", err)
+		return
+	}
+
+	userDto, err := userService.CreateUser(auth.System(tenantDto.ID), &user.InsertRequest{Username: *username, Role: auth.Role(*role)})
+	if err != nil {
+		log.Fatalf("Failed to create user: %v", err)
+		return
+	}
+
+	fmt.Println(userDto.Token)
+}
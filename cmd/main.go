@@ -3,8 +3,25 @@ package main
 import (
 	"accountingsystem/configs"
 	"accountingsystem/db"
+	"accountingsystem/internal/api"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/codetemplate"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/grpcapi"
+	"accountingsystem/internal/idempotency"
+	"accountingsystem/internal/money"
 	"accountingsystem/internal/services"
+	"accountingsystem/internal/textrule"
+	"accountingsystem/internal/txretry"
+	"context"
+	"errors"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
@@ -12,6 +29,30 @@ func main() {
 		log.Fatalf("Error initing config: %v\n", err)
 		return
 	}
+	if err := money.Init(); err != nil {
+		log.Fatalf("Invalid MONEY_MINOR_UNITS: %v", err)
+		return
+	}
+	if err := calendar.Init(); err != nil {
+		log.Fatalf("Invalid CALENDAR: %v", err)
+		return
+	}
+	if err := textrule.Init(); err != nil {
+		log.Fatalf("Invalid text rule configuration: %v", err)
+		return
+	}
+	if err := codetemplate.Init(); err != nil {
+		log.Fatalf("Invalid code template configuration: %v", err)
+		return
+	}
+	if err := txretry.Init(); err != nil {
+		log.Fatalf("Invalid transaction retry configuration: %v", err)
+		return
+	}
+	if err := idempotency.Init(); err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_RETENTION: %v", err)
+		return
+	}
 	theDB, err := db.Init()
 	if err != nil {
 		log.Fatalf("Failed to connect to the database: %v", err)
@@ -21,12 +62,119 @@ func main() {
 	dlService := &services.DLService{}
 	slService := &services.SLService{}
 	voucherService := &services.VoucherService{}
+	reportService := &services.ReportService{}
+	numberSequenceService := &services.NumberSequenceService{}
+	voucherTemplateService := &services.VoucherTemplateService{}
+	currencyService := &services.CurrencyService{}
+	revaluationService := &services.RevaluationService{}
+	outboxService := &services.OutboxService{}
+	webhookService := &services.WebhookService{}
+	idempotencyService := &services.IdempotencyService{}
 
 	dlService.InitService(theDB)
 	slService.InitService(theDB)
 	voucherService.InitService(theDB)
+	reportService.InitService(theDB)
+	numberSequenceService.InitService(theDB)
+	voucherTemplateService.InitService(theDB)
+	currencyService.InitService(theDB)
+	revaluationService.InitService(theDB)
+	outboxService.InitService(theDB)
+	webhookService.InitService(theDB)
+	idempotencyService.InitService(theDB)
 
 	log.Println("Successfully brought up the services")
 
-	// Here we can start interacting with services as needed
+	schedulerInterval := time.Minute
+	if value, err := configs.GetEnv("SCHEDULER_INTERVAL"); err == nil {
+		if schedulerInterval, err = time.ParseDuration(value); err != nil {
+			log.Fatalf("Invalid SCHEDULER_INTERVAL: %v", err)
+			return
+		}
+	}
+
+	outboxInterval := 5 * time.Second
+	if value, err := configs.GetEnv("OUTBOX_INTERVAL"); err == nil {
+		if outboxInterval, err = time.ParseDuration(value); err != nil {
+			log.Fatalf("Invalid OUTBOX_INTERVAL: %v", err)
+			return
+		}
+	}
+	outboxRetention := 7 * 24 * time.Hour
+	if value, err := configs.GetEnv("OUTBOX_RETENTION"); err == nil {
+		if outboxRetention, err = time.ParseDuration(value); err != nil {
+			log.Fatalf("Invalid OUTBOX_RETENTION: %v", err)
+			return
+		}
+	}
+	if value, err := configs.GetEnv("OUTBOX_WEBHOOK_URL"); err == nil && value != "" {
+		secret, err := configs.GetEnv("OUTBOX_WEBHOOK_SECRET")
+		if err != nil || len(secret) < 16 {
+			log.Fatal("OUTBOX_WEBHOOK_SECRET of at least 16 characters is required with OUTBOX_WEBHOOK_URL")
+			return
+		}
+		outboxService.AddSink("webhook", events.WebhookSink(value, secret, &http.Client{Timeout: 10 * time.Second}))
+	}
+	if value, err := configs.GetEnv("OUTBOX_FILE"); err == nil && value != "" {
+		outboxService.AddSink("file", events.FileSink(value))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpAddr := ":8080"
+	if value, err := configs.GetEnv("HTTP_ADDR"); err == nil && value != "" {
+		httpAddr = value
+	}
+	requestTimeout := 30 * time.Second
+	if value, err := configs.GetEnv("REQUEST_TIMEOUT"); err == nil {
+		if requestTimeout, err = time.ParseDuration(value); err != nil {
+			log.Fatalf("Invalid REQUEST_TIMEOUT: %v", err)
+			return
+		}
+	}
+	apiServer := &api.Server{}
+	if err := apiServer.InitServer(theDB, requestTimeout); err != nil {
+		log.Fatalf("Invalid GraphQL schema: %v", err)
+	}
+	httpServer := &http.Server{Addr: httpAddr, Handler: apiServer.Handler()}
+	go func() {
+		log.Printf("Serving the HTTP API on %s", httpAddr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to serve the HTTP API: %v", err)
+		}
+	}()
+
+	grpcAddr := ":9090"
+	if value, err := configs.GetEnv("GRPC_ADDR"); err == nil && value != "" {
+		grpcAddr = value
+	}
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("Failed to listen for the gRPC API: %v", err)
+		return
+	}
+	grpcServer := &grpcapi.Server{}
+	grpcServer.InitServer(theDB, requestTimeout)
+	go func() {
+		log.Printf("Serving the gRPC API on %s", grpcAddr)
+		if err := grpcServer.GRPCServer().Serve(grpcListener); err != nil {
+			log.Fatalf("Failed to serve the gRPC API: %v", err)
+		}
+	}()
+
+	log.Printf("Dispatching outbox events and webhooks every %s", outboxInterval)
+	go outboxService.RunDispatcher(ctx, outboxInterval, outboxRetention)
+	go webhookService.RunDeliverer(ctx, outboxInterval)
+	go idempotencyService.RunPurger(ctx, schedulerInterval)
+
+	log.Printf("Running voucher template scheduler every %s", schedulerInterval)
+	voucherTemplateService.RunScheduler(ctx, schedulerInterval)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down the HTTP API: %v", err)
+	}
+	grpcServer.GRPCServer().GracefulStop()
 }
@@ -0,0 +1,16 @@
+// Command openapi writes the OpenAPI document of the HTTP API to stdout. It
+// regenerates openapi.json, which the clients of openapitools.json are
+// generated from.
+package main
+
+import (
+	"accountingsystem/internal/api"
+	"log"
+	"os"
+)
+
+func main() {
+	if err := api.WriteOpenAPI(os.Stdout); err != nil {
+		log.Fatalf("Failed to write the OpenAPI document: %v", err)
+	}
+}
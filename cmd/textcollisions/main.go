@@ -0,0 +1,50 @@
+// Command textcollisions reports codes, titles and voucher numbers that were
+// stored before normalization and collide once normalized. Such rows have to
+// be renamed or merged by hand; the command only reads the database.
+package main
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/db"
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/services"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	if err := configs.InitConfig(".env"); err != nil {
+		log.Fatalf("Error initing config: %v\n", err)
+		return
+	}
+	theDB, err := db.Init()
+	if err != nil {
+		log.Fatalf("Failed to connect to the database: %v", err)
+		return
+	}
+
+	normalizationService := &services.NormalizationService{}
+	normalizationService.InitService(theDB)
+
+	collisions, err := normalizationService.FindTextCollisions(auth.System(auth.DefaultTenantID))
+	if err != nil {
+		log.Fatalf("Failed to find text collisions: %v", err)
+		return
+	}
+
+	for _, collision := range collisions {
+		rows := make([]string, len(collision.IDs))
+		for i, id := range collision.IDs {
+			rows[i] = fmt.Sprintf("id=%d %q", id, collision.Values[i])
+		}
+		fmt.Printf("tenant=%d %s.%s %q: %s\n", collision.TenantID, collision.Table, collision.Column, collision.NormalizedValue, strings.Join(rows, ", "))
+	}
+
+	if len(collisions) > 0 {
+		log.Printf("Found %d colliding groups", len(collisions))
+		os.Exit(1)
+	}
+	log.Println("No collisions found")
+}
@@ -0,0 +1,19 @@
+CREATE TABLE voucher_number_sequence (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    prefix VARCHAR(32) NOT NULL DEFAULT '',
+    padding INT NOT NULL DEFAULT 0 CHECK (padding >= 0 AND padding <= 20),
+    reset_yearly BOOLEAN NOT NULL DEFAULT FALSE,
+    fiscal_year_start_month INT NOT NULL DEFAULT 1 CHECK (fiscal_year_start_month BETWEEN 1 AND 12),
+    gapless BOOLEAN NOT NULL DEFAULT FALSE,
+    row_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE voucher_number_counter (
+    sequence_id BIGINT NOT NULL REFERENCES voucher_number_sequence(id) ON DELETE CASCADE,
+    fiscal_year INT NOT NULL,
+    last_value BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (sequence_id, fiscal_year)
+);
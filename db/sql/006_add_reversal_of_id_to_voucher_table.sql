@@ -0,0 +1,3 @@
+ALTER TABLE voucher ADD COLUMN reversal_of_id BIGINT REFERENCES voucher(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX voucher_reversal_of_id_key ON voucher(reversal_of_id);
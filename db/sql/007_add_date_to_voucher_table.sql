@@ -0,0 +1 @@
+ALTER TABLE voucher ADD COLUMN date DATE NOT NULL DEFAULT CURRENT_DATE;
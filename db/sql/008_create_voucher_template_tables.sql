@@ -0,0 +1,30 @@
+CREATE TABLE voucher_template (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    title VARCHAR(64) NOT NULL,
+    number_prefix VARCHAR(32) NOT NULL DEFAULT '',
+    variables JSONB NOT NULL DEFAULT '{}',
+    frequency VARCHAR(16) NOT NULL CHECK (frequency IN ('monthly', 'quarterly', 'cron')),
+    cron_expression VARCHAR(128) NOT NULL DEFAULT '',
+    starts_at TIMESTAMP NOT NULL,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    row_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX voucher_template_next_run_at_idx ON voucher_template(next_run_at) WHERE active;
+
+CREATE TABLE voucher_template_item (
+    id BIGSERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL REFERENCES voucher_template(id) ON DELETE CASCADE,
+    sl_id BIGINT NOT NULL REFERENCES sl(id) ON DELETE RESTRICT,
+    dl_id BIGINT REFERENCES dl(id) ON DELETE RESTRICT,
+    side VARCHAR(6) NOT NULL CHECK (side IN ('debit', 'credit')),
+    amount INT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    formula VARCHAR(256) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,31 @@
+CREATE TABLE currency (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(3) NOT NULL UNIQUE,
+    title VARCHAR(64) NOT NULL,
+    row_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE currency_rate (
+    id BIGSERIAL PRIMARY KEY,
+    currency_id BIGINT NOT NULL REFERENCES currency(id) ON DELETE CASCADE,
+    rate_date DATE NOT NULL,
+    rate NUMERIC(24, 10) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (currency_id, rate_date)
+);
+
+ALTER TABLE voucher_item
+    ADD COLUMN currency_code VARCHAR(3) REFERENCES currency(code) ON DELETE RESTRICT,
+    ADD COLUMN foreign_debit INT NOT NULL DEFAULT 0 CHECK (foreign_debit >= 0),
+    ADD COLUMN foreign_credit INT NOT NULL DEFAULT 0 CHECK (foreign_credit >= 0),
+    ADD COLUMN exchange_rate NUMERIC(24, 10),
+    ADD CONSTRAINT foreign_amounts_require_currency CHECK (
+        (currency_code IS NULL AND foreign_debit = 0 AND foreign_credit = 0 AND exchange_rate IS NULL)
+        OR (currency_code IS NOT NULL AND exchange_rate > 0
+            AND ((foreign_debit > 0 AND foreign_credit = 0) OR (foreign_credit > 0 AND foreign_debit = 0)))
+    );
+
+CREATE INDEX voucher_item_currency_code_idx ON voucher_item(currency_code);
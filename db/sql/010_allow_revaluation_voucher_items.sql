@@ -0,0 +1,8 @@
+-- Revaluation adjustments carry a currency and the closing rate but change
+-- only the functional amount of a foreign-currency position.
+ALTER TABLE voucher_item DROP CONSTRAINT foreign_amounts_require_currency;
+
+ALTER TABLE voucher_item ADD CONSTRAINT foreign_amounts_require_currency CHECK (
+    (currency_code IS NULL AND foreign_debit = 0 AND foreign_credit = 0 AND exchange_rate IS NULL)
+    OR (currency_code IS NOT NULL AND exchange_rate > 0 AND (foreign_debit = 0 OR foreign_credit = 0))
+);
@@ -0,0 +1,10 @@
+-- Amounts are counted in minor units and routinely exceed the INT range in
+-- rials. Widening INT to BIGINT keeps every existing value unchanged.
+ALTER TABLE voucher_item
+    ALTER COLUMN debit TYPE BIGINT,
+    ALTER COLUMN credit TYPE BIGINT,
+    ALTER COLUMN foreign_debit TYPE BIGINT,
+    ALTER COLUMN foreign_credit TYPE BIGINT;
+
+ALTER TABLE voucher_template_item
+    ALTER COLUMN amount TYPE BIGINT;
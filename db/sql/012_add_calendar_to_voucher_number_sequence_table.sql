@@ -0,0 +1,2 @@
+ALTER TABLE voucher_number_sequence
+    ADD COLUMN calendar VARCHAR(16) NOT NULL DEFAULT 'gregorian' CHECK (calendar IN ('gregorian', 'jalali'));
@@ -0,0 +1,58 @@
+CREATE TABLE tenant (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    title VARCHAR(64) NOT NULL,
+    row_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Existing books are moved into the default tenant, which gets id 1.
+INSERT INTO tenant (code, title) VALUES ('default', 'Default');
+
+ALTER TABLE dl ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+ALTER TABLE sl ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+ALTER TABLE voucher ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+ALTER TABLE voucher_item ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+ALTER TABLE voucher_template ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+ALTER TABLE app_user ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+
+ALTER TABLE dl ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE sl ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE voucher ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE voucher_item ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE voucher_template ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE app_user ALTER COLUMN tenant_id DROP DEFAULT;
+
+-- Codes, titles and numbers are unique per tenant only.
+ALTER TABLE dl
+    DROP CONSTRAINT dl_code_key,
+    DROP CONSTRAINT dl_title_key,
+    ADD CONSTRAINT dl_tenant_id_code_key UNIQUE (tenant_id, code),
+    ADD CONSTRAINT dl_tenant_id_title_key UNIQUE (tenant_id, title),
+    ADD CONSTRAINT dl_tenant_id_id_key UNIQUE (tenant_id, id);
+
+ALTER TABLE sl
+    DROP CONSTRAINT sl_code_key,
+    DROP CONSTRAINT sl_title_key,
+    ADD CONSTRAINT sl_tenant_id_code_key UNIQUE (tenant_id, code),
+    ADD CONSTRAINT sl_tenant_id_title_key UNIQUE (tenant_id, title),
+    ADD CONSTRAINT sl_tenant_id_id_key UNIQUE (tenant_id, id);
+
+ALTER TABLE voucher
+    DROP CONSTRAINT voucher_number_key,
+    ADD CONSTRAINT voucher_tenant_id_number_key UNIQUE (tenant_id, number),
+    ADD CONSTRAINT voucher_tenant_id_id_key UNIQUE (tenant_id, id);
+
+ALTER TABLE voucher_template
+    DROP CONSTRAINT voucher_template_code_key,
+    ADD CONSTRAINT voucher_template_tenant_id_code_key UNIQUE (tenant_id, code);
+
+-- Voucher lines may only reference the voucher, SL and DL of their own tenant.
+ALTER TABLE voucher_item
+    DROP CONSTRAINT voucher_item_voucher_id_fkey,
+    DROP CONSTRAINT voucher_item_sl_id_fkey,
+    DROP CONSTRAINT voucher_item_dl_id_fkey,
+    ADD CONSTRAINT voucher_item_voucher_fkey FOREIGN KEY (tenant_id, voucher_id) REFERENCES voucher(tenant_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT voucher_item_sl_fkey FOREIGN KEY (tenant_id, sl_id) REFERENCES sl(tenant_id, id) ON DELETE RESTRICT,
+    ADD CONSTRAINT voucher_item_dl_fkey FOREIGN KEY (tenant_id, dl_id) REFERENCES dl(tenant_id, id) ON DELETE RESTRICT;
@@ -0,0 +1,39 @@
+-- Vouchers posted before the approval workflow need no approval.
+ALTER TABLE voucher
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'posted' CHECK (status IN ('draft', 'pending', 'rejected', 'posted')),
+    ADD COLUMN required_approvals INT NOT NULL DEFAULT 0 CHECK (required_approvals >= 0),
+    ADD COLUMN approval_round INT NOT NULL DEFAULT 0,
+    ADD COLUMN submitted_by VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX voucher_pending_idx ON voucher(tenant_id, id) WHERE status = 'pending';
+
+-- A voucher needs approval when its total debit reaches min_amount or one of
+-- its lines uses sl_id; it then needs the largest required_approvals of the
+-- rules it matches.
+CREATE TABLE approval_rule (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id) ON DELETE RESTRICT,
+    min_amount BIGINT CHECK (min_amount > 0),
+    sl_id BIGINT,
+    required_approvals INT NOT NULL CHECK (required_approvals BETWEEN 1 AND 10),
+    row_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT approval_rule_sl_fkey FOREIGN KEY (tenant_id, sl_id) REFERENCES sl(tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT approval_rule_has_condition CHECK (min_amount IS NOT NULL OR sl_id IS NOT NULL)
+);
+
+CREATE TABLE voucher_approval (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL,
+    voucher_id BIGINT NOT NULL,
+    round INT NOT NULL,
+    decision VARCHAR(8) NOT NULL CHECK (decision IN ('submit', 'approve', 'reject')),
+    user_id BIGINT REFERENCES app_user(id) ON DELETE SET NULL,
+    username VARCHAR(64) NOT NULL,
+    comment VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT voucher_approval_voucher_fkey FOREIGN KEY (tenant_id, voucher_id) REFERENCES voucher(tenant_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX voucher_approval_voucher_id_idx ON voucher_approval(voucher_id, round);
@@ -0,0 +1,14 @@
+-- Remembers the result of a create request sent with an idempotency key so a
+-- retry of the same request returns it instead of creating a duplicate.
+CREATE TABLE idempotency_key (
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('dl', 'sl', 'voucher')),
+    key VARCHAR(128) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    response JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tenant_id, scope, key)
+);
+
+CREATE INDEX idempotency_key_expires_at_idx ON idempotency_key(expires_at);
@@ -0,0 +1,18 @@
+-- Domain events are written here in the transaction of the change they
+-- describe and delivered to the sinks afterwards by the dispatcher.
+CREATE TABLE outbox_event (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    aggregate_type VARCHAR(16) NOT NULL CHECK (aggregate_type IN ('dl', 'sl', 'voucher')),
+    aggregate_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    dispatched_at TIMESTAMP
+);
+
+CREATE INDEX outbox_event_pending_idx ON outbox_event(next_attempt_at, id) WHERE dispatched_at IS NULL;
+CREATE INDEX outbox_event_aggregate_idx ON outbox_event(tenant_id, aggregate_type, aggregate_id);
@@ -0,0 +1,39 @@
+-- Webhook subscriptions receive the outbox events of their tenant whose type
+-- they subscribed to. A delivery row is queued for each of them in the
+-- transaction that records the event and keeps the log of its attempts.
+CREATE TABLE webhook_subscription (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    row_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_subscription_event (
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscription(id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL,
+    PRIMARY KEY (subscription_id, event_type)
+);
+
+CREATE INDEX webhook_subscription_event_type_idx ON webhook_subscription_event(event_type);
+
+CREATE TABLE webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id) ON DELETE CASCADE,
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscription(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES outbox_event(id) ON DELETE CASCADE,
+    replay_of_id BIGINT REFERENCES webhook_delivery(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    response_status INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX webhook_delivery_pending_idx ON webhook_delivery(next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX webhook_delivery_subscription_idx ON webhook_delivery(subscription_id, id);
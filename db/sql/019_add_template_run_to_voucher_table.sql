@@ -0,0 +1,6 @@
+-- A voucher posted by a template run remembers the template and occurrence
+-- it was posted for, so the same occurrence is never posted twice.
+ALTER TABLE voucher ADD COLUMN template_id BIGINT REFERENCES voucher_template(id) ON DELETE SET NULL;
+ALTER TABLE voucher ADD COLUMN template_run_at TIMESTAMP;
+
+CREATE UNIQUE INDEX voucher_template_run_key ON voucher(template_id, template_run_at);
@@ -0,0 +1,19 @@
+-- Number sequences and their counters belong to a tenant. Existing sequences
+-- move into the default tenant.
+ALTER TABLE voucher_number_sequence ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+ALTER TABLE voucher_number_counter ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 REFERENCES tenant(id) ON DELETE RESTRICT;
+
+ALTER TABLE voucher_number_sequence ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE voucher_number_counter ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE voucher_number_sequence
+    DROP CONSTRAINT voucher_number_sequence_code_key,
+    ADD CONSTRAINT voucher_number_sequence_tenant_id_code_key UNIQUE (tenant_id, code),
+    ADD CONSTRAINT voucher_number_sequence_tenant_id_id_key UNIQUE (tenant_id, id);
+
+-- A counter may only count for a sequence of its own tenant.
+ALTER TABLE voucher_number_counter
+    DROP CONSTRAINT voucher_number_counter_pkey,
+    DROP CONSTRAINT voucher_number_counter_sequence_id_fkey,
+    ADD CONSTRAINT voucher_number_counter_pkey PRIMARY KEY (tenant_id, sequence_id, fiscal_year),
+    ADD CONSTRAINT voucher_number_counter_sequence_fkey FOREIGN KEY (tenant_id, sequence_id) REFERENCES voucher_number_sequence(tenant_id, id) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+-- Who created and last edited a voucher may not approve or reject it.
+-- Vouchers kept before this have no recorded author.
+ALTER TABLE voucher
+    ADD COLUMN created_by VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN updated_by VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- An archived SL keeps its lines and balances but takes no new lines.
+ALTER TABLE sl ADD COLUMN archived_at TIMESTAMP;
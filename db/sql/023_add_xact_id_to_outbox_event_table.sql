@@ -0,0 +1,6 @@
+-- The postings stream reads events in the order of the transactions that
+-- wrote them and only once no transaction that may still write an earlier
+-- one is running, so that no event is skipped when ids commit out of order.
+ALTER TABLE outbox_event ADD COLUMN xact_id xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX outbox_event_stream_idx ON outbox_event(tenant_id, aggregate_type, xact_id, id);
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/requests/approvalrule"
+	"accountingsystem/internal/requests/voucher"
+	"net/http"
+)
+
+func (s *Server) submitVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.SubmitRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	voucherDto, err := s.voucherService.SubmitVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, voucherDto)
+	return nil
+}
+
+func (s *Server) approveVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.ApproveRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	voucherDto, err := s.voucherService.ApproveVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, voucherDto)
+	return nil
+}
+
+func (s *Server) rejectVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.RejectRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	voucherDto, err := s.voucherService.RejectVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, voucherDto)
+	return nil
+}
+
+func (s *Server) getVoucherApprovals(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	approvals, err := s.voucherService.GetVoucherApprovalsContext(r.Context(), actor, &voucher.GetRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, approvals)
+	return nil
+}
+
+func (s *Server) getApprovalQueue(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	queue, err := s.voucherService.GetApprovalQueueContext(r.Context(), actor)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, queue)
+	return nil
+}
+
+func (s *Server) createApprovalRule(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req approvalrule.InsertRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	ruleDto, err := s.approvalRuleService.CreateApprovalRule(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, ruleDto)
+	return nil
+}
+
+func (s *Server) updateApprovalRule(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req approvalrule.UpdateRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	ruleDto, err := s.approvalRuleService.UpdateApprovalRule(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, ruleDto)
+	return nil
+}
+
+func (s *Server) deleteApprovalRule(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	version, err := queryVersion(r)
+	if err != nil {
+		return err
+	}
+	if err := s.approvalRuleService.DeleteApprovalRule(actor, &approvalrule.DeleteRequest{ID: id, Version: version}); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) getApprovalRule(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	ruleDto, err := s.approvalRuleService.GetApprovalRule(actor, &approvalrule.GetRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, ruleDto)
+	return nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/requests/dl"
+	"net/http"
+)
+
+func (s *Server) createDL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req dl.InsertRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		req.IdempotencyKey = key
+	}
+	dlDto, err := s.dlService.CreateDLContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, dlDto)
+	return nil
+}
+
+func (s *Server) updateDL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req dl.UpdateRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	dlDto, err := s.dlService.UpdateDLContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, dlDto)
+	return nil
+}
+
+func (s *Server) deleteDL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	version, err := queryVersion(r)
+	if err != nil {
+		return err
+	}
+	if err := s.dlService.DeleteDLContext(r.Context(), actor, &dl.DeleteRequest{ID: id, Version: version}); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) getDL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	dlDto, err := s.dlService.GetDLContext(r.Context(), actor, &dl.GetRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, dlDto)
+	return nil
+}
+
+func (s *Server) getDLByCode(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	dlDto, err := s.dlService.GetDLByCodeContext(r.Context(), actor, &dl.GetByCodeRequest{Code: r.PathValue("code")})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, dlDto)
+	return nil
+}
+
+func (s *Server) nextDLCode(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	code, err := s.dlService.NextDLCodeContext(r.Context(), actor, &dl.NextCodeRequest{ParentCode: r.URL.Query().Get("parent")})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, nextCodeResponse{Code: code})
+	return nil
+}
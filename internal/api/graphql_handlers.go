@@ -0,0 +1,18 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/graphqlapi"
+	"net/http"
+)
+
+// graphQL answers with 200 OK even when fields failed; the errors are listed
+// in the result next to the data that could be read.
+func (s *Server) graphQL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req graphqlapi.Request
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, s.graphQLServer.Execute(r.Context(), actor, &req))
+	return nil
+}
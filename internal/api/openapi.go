@@ -0,0 +1,422 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/graphqlapi"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/approvalrule"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/requests/webhook"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// operation documents one route of the API. Request and response hold a
+// zero value of the body types; their schemas are derived from the Go types,
+// so the document follows the request and dto packages as they change.
+type operation struct {
+	pattern  string
+	id       string
+	summary  string
+	public   bool
+	request  any
+	response any
+	status   int
+	// contentTypes replaces the JSON response body with a binary one in each
+	// of the given media types.
+	contentTypes []string
+	parameters   []openAPIParameter
+}
+
+var versionParameter = openAPIParameter{Name: "version", In: "query", Required: true, Description: "RowVersion the client last read.", Schema: &openAPISchema{Type: "integer"}}
+
+var parentParameter = openAPIParameter{Name: "parent", In: "query", Description: "Code of the parent the next code is generated under.", Schema: &openAPISchema{Type: "string"}}
+
+var calendarParameter = openAPIParameter{Name: "calendar", In: "query", Description: "Calendar the voucher date is written in, the configured one by default.", Schema: &openAPISchema{Type: "string", Enum: []string{string(calendar.Gregorian), string(calendar.Jalali)}}}
+
+var idempotencyKeyParameter = openAPIParameter{Name: "Idempotency-Key", In: "header", Description: "Retries with the same key and body get the result of the first request. At most 128 characters.", Schema: &openAPISchema{Type: "string", MaxLength: 128}}
+
+var operations = []operation{
+	{pattern: "GET /health", id: "health", summary: "Report that the server is up", public: true, status: http.StatusNoContent},
+	{pattern: "GET /metrics", id: "metrics", summary: "Report transaction retry counters", public: true, response: metricsResponse{}, status: http.StatusOK},
+	{pattern: "GET /openapi.json", id: "openAPI", summary: "Get this document", public: true, response: map[string]any{}, status: http.StatusOK},
+
+	{pattern: "POST /dls", id: "createDL", summary: "Create a DL", request: dl.InsertRequest{}, response: dtos.DLDto{}, status: http.StatusCreated, parameters: []openAPIParameter{idempotencyKeyParameter}},
+	{pattern: "GET /dls/next-code", id: "nextDLCode", summary: "Suggest the next free DL code", response: nextCodeResponse{}, status: http.StatusOK, parameters: []openAPIParameter{parentParameter}},
+	{pattern: "GET /dls/by-code/{code}", id: "getDLByCode", summary: "Get a DL by its code", response: dtos.DLDto{}, status: http.StatusOK},
+	{pattern: "GET /dls/{id}", id: "getDL", summary: "Get a DL", response: dtos.DLDto{}, status: http.StatusOK},
+	{pattern: "PUT /dls/{id}", id: "updateDL", summary: "Update a DL", request: dl.UpdateRequest{}, response: dtos.DLDto{}, status: http.StatusOK},
+	{pattern: "DELETE /dls/{id}", id: "deleteDL", summary: "Delete a DL", status: http.StatusNoContent, parameters: []openAPIParameter{versionParameter}},
+
+	{pattern: "POST /sls", id: "createSL", summary: "Create an SL", request: sl.InsertRequest{}, response: dtos.SLDto{}, status: http.StatusCreated, parameters: []openAPIParameter{idempotencyKeyParameter}},
+	{pattern: "GET /sls/next-code", id: "nextSLCode", summary: "Suggest the next free SL code", response: nextCodeResponse{}, status: http.StatusOK, parameters: []openAPIParameter{parentParameter}},
+	{pattern: "GET /sls/by-code/{code}", id: "getSLByCode", summary: "Get an SL by its code", response: dtos.SLDto{}, status: http.StatusOK},
+	{pattern: "GET /sls/{id}", id: "getSL", summary: "Get an SL", response: dtos.SLDto{}, status: http.StatusOK},
+	{pattern: "PUT /sls/{id}", id: "updateSL", summary: "Update an SL", request: sl.UpdateRequest{}, response: dtos.SLDto{}, status: http.StatusOK},
+	{pattern: "DELETE /sls/{id}", id: "deleteSL", summary: "Delete an SL", status: http.StatusNoContent, parameters: []openAPIParameter{versionParameter}},
+	{pattern: "POST /sls/{id}/archive", id: "archiveSL", summary: "Archive an SL so that it takes no new voucher lines", request: sl.ArchiveRequest{}, response: dtos.SLDto{}, status: http.StatusOK},
+
+	{pattern: "POST /vouchers", id: "createVoucher", summary: "Create a voucher", request: voucher.InsertRequest{}, response: dtos.VoucherWithItemsDto{}, status: http.StatusCreated, parameters: []openAPIParameter{idempotencyKeyParameter}},
+	{pattern: "GET /voucher-numbers/{number}", id: "getVoucherByNumber", summary: "Get a voucher by its number", response: dtos.VoucherWithItemsDto{}, status: http.StatusOK, parameters: []openAPIParameter{calendarParameter}},
+	{pattern: "GET /vouchers/{id}", id: "getVoucher", summary: "Get a voucher with its lines", response: dtos.VoucherWithItemsDto{}, status: http.StatusOK, parameters: []openAPIParameter{calendarParameter}},
+	{pattern: "PUT /vouchers/{id}", id: "updateVoucher", summary: "Update a voucher and insert, update or delete its lines", request: voucher.UpdateRequest{}, response: dtos.VoucherDto{}, status: http.StatusOK},
+	{pattern: "DELETE /vouchers/{id}", id: "deleteVoucher", summary: "Delete a voucher", status: http.StatusNoContent, parameters: []openAPIParameter{versionParameter}},
+	{pattern: "POST /vouchers/{id}/reverse", id: "reverseVoucher", summary: "Create a voucher that reverses a voucher", request: voucher.ReverseRequest{}, response: dtos.VoucherWithItemsDto{}, status: http.StatusCreated},
+	{pattern: "POST /vouchers/{id}/copy", id: "copyVoucher", summary: "Create a draft voucher with the lines of a voucher", request: voucher.CopyRequest{}, response: dtos.VoucherWithItemsDto{}, status: http.StatusCreated},
+	{pattern: "GET /vouchers/{id}/export", id: "exportVoucher", summary: "Export a voucher", status: http.StatusOK,
+		contentTypes: []string{export.FormatCSV.ContentType(), export.FormatXLSX.ContentType(), export.FormatPDF.ContentType()},
+		parameters:   []openAPIParameter{{Name: "format", In: "query", Description: "Format of the document, csv by default.", Schema: &openAPISchema{Type: "string", Enum: []string{string(export.FormatCSV), string(export.FormatXLSX), string(export.FormatPDF)}}}, calendarParameter}},
+	{pattern: "POST /vouchers/{id}/submit", id: "submitVoucher", summary: "Submit a draft voucher for approval", request: voucher.SubmitRequest{}, response: dtos.VoucherDto{}, status: http.StatusOK},
+	{pattern: "POST /vouchers/{id}/approve", id: "approveVoucher", summary: "Approve a pending voucher", request: voucher.ApproveRequest{}, response: dtos.VoucherDto{}, status: http.StatusOK},
+	{pattern: "POST /vouchers/{id}/reject", id: "rejectVoucher", summary: "Reject a pending voucher", request: voucher.RejectRequest{}, response: dtos.VoucherDto{}, status: http.StatusOK},
+	{pattern: "GET /vouchers/{id}/approvals", id: "getVoucherApprovals", summary: "List the approval decisions on a voucher", response: []dtos.VoucherApprovalDto{}, status: http.StatusOK},
+	{pattern: "GET /vouchers/approval-queue", id: "getApprovalQueue", summary: "List the vouchers waiting for the actor's approval", response: []dtos.ApprovalQueueItemDto{}, status: http.StatusOK},
+
+	{pattern: "POST /approval-rules", id: "createApprovalRule", summary: "Create an approval rule", request: approvalrule.InsertRequest{}, response: dtos.ApprovalRuleDto{}, status: http.StatusCreated},
+	{pattern: "GET /approval-rules/{id}", id: "getApprovalRule", summary: "Get an approval rule", response: dtos.ApprovalRuleDto{}, status: http.StatusOK},
+	{pattern: "PUT /approval-rules/{id}", id: "updateApprovalRule", summary: "Update an approval rule", request: approvalrule.UpdateRequest{}, response: dtos.ApprovalRuleDto{}, status: http.StatusOK},
+	{pattern: "DELETE /approval-rules/{id}", id: "deleteApprovalRule", summary: "Delete an approval rule", status: http.StatusNoContent, parameters: []openAPIParameter{versionParameter}},
+
+	{pattern: "POST /webhooks", id: "createWebhook", summary: "Subscribe a URL to events", request: webhook.InsertRequest{}, response: dtos.WebhookSubscriptionDto{}, status: http.StatusCreated},
+	{pattern: "GET /webhooks/{id}", id: "getWebhook", summary: "Get a webhook subscription", response: dtos.WebhookSubscriptionDto{}, status: http.StatusOK},
+	{pattern: "PUT /webhooks/{id}", id: "updateWebhook", summary: "Update a webhook subscription", request: webhook.UpdateRequest{}, response: dtos.WebhookSubscriptionDto{}, status: http.StatusOK},
+	{pattern: "DELETE /webhooks/{id}", id: "deleteWebhook", summary: "Delete a webhook subscription", status: http.StatusNoContent, parameters: []openAPIParameter{versionParameter}},
+	{pattern: "GET /webhooks/{id}/deliveries", id: "getWebhookDeliveries", summary: "List the latest deliveries of a webhook", response: []dtos.WebhookDeliveryDto{}, status: http.StatusOK,
+		parameters: []openAPIParameter{{Name: "status", In: "query", Schema: &openAPISchema{Type: "string", Enum: []string{webhook.DeliveryPending, webhook.DeliverySucceeded, webhook.DeliveryFailed}}}}},
+	{pattern: "POST /webhook-deliveries/{id}/replay", id: "replayWebhookDelivery", summary: "Queue a delivery again", response: dtos.WebhookDeliveryDto{}, status: http.StatusAccepted},
+
+	{pattern: "GET /stream/postings", id: "streamPostings", summary: "Stream voucher events and SL balances as server-sent events", response: dtos.PostingMessageDto{}, status: http.StatusOK,
+		contentTypes: []string{"text/event-stream"},
+		parameters: []openAPIParameter{
+			{Name: "Last-Event-ID", In: "header", Description: "ID of the last message received; takes precedence over cursor.", Schema: &openAPISchema{Type: "integer", Format: "int64"}},
+			{Name: "cursor", In: "query", Description: "ID of the last message received. Without it the stream starts with the next event.", Schema: &openAPISchema{Type: "integer", Format: "int64"}},
+			{Name: "sl", In: "query", Description: "Comma separated SL IDs the vouchers must have a line on.", Schema: &openAPISchema{Type: "string"}},
+			{Name: "dl", In: "query", Description: "Comma separated DL IDs the vouchers must have a line on.", Schema: &openAPISchema{Type: "string"}},
+		}},
+
+	{pattern: "POST /graphql", id: "graphQL", summary: "Run a read-only GraphQL query on DLs, SLs, vouchers and reports", request: graphqlapi.Request{}, response: graphql.Result{}, status: http.StatusOK},
+
+	{pattern: "POST /users", id: "createUser", summary: "Create a user and issue its token", request: user.InsertRequest{}, response: dtos.UserDto{}, status: http.StatusCreated},
+	{pattern: "GET /users/{id}", id: "getUser", summary: "Get a user", response: dtos.UserDto{}, status: http.StatusOK},
+	{pattern: "PUT /users/{id}", id: "updateUser", summary: "Update the role of a user or deactivate it", request: user.UpdateRequest{}, response: dtos.UserDto{}, status: http.StatusOK},
+	{pattern: "POST /users/{id}/token", id: "rotateUserToken", summary: "Issue a new token for a user", request: user.RotateTokenRequest{}, response: dtos.UserDto{}, status: http.StatusOK},
+}
+
+// enums lists the values of the named string types the requests and DTOs
+// use.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(auth.Role("")):         {string(auth.Viewer), string(auth.Accountant), string(auth.Approver), string(auth.Admin)},
+	reflect.TypeOf(calendar.Calendar("")): {string(calendar.Gregorian), string(calendar.Jalali)},
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Security   []map[string][]string                   `json:"security"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas"`
+	Responses       map[string]*openAPIResponse       `json:"responses"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags"`
+	Security    *[]map[string][]string      `json:"security,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Required    bool           `json:"required,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Schema      *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Ref         string                      `json:"$ref,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	AllOf                []*openAPISchema          `json:"allOf,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	MaxLength            int                       `json:"maxLength,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// WriteOpenAPI writes the document served at /openapi.json, indented, so
+// clients can be generated from it without running the server.
+func WriteOpenAPI(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(buildOpenAPI())
+}
+
+// buildOpenAPI describes the operations as an OpenAPI 3 document. Error
+// responses list the messages of internal/constants each status is used for.
+func buildOpenAPI() *openAPIDocument {
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       "Accounting System API",
+			Version:     "1.0.0",
+			Description: "Every operation but the public ones needs an \"Authorization: Bearer <token>\" header. Errors are answered as {\"Error\": \"<message>\"}.",
+		},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+		Paths:    map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas:         map[string]*openAPISchema{},
+			Responses:       errorResponses(),
+			SecuritySchemes: map[string]*openAPISecurityScheme{"bearerAuth": {Type: "http", Scheme: "bearer"}},
+		},
+	}
+	doc.Components.Schemas["api.errorResponse"] = &openAPISchema{
+		Type:       "object",
+		Properties: map[string]*openAPISchema{"Error": {Type: "string"}},
+		Required:   []string{"Error"},
+	}
+
+	for _, op := range operations {
+		method, route, _ := strings.Cut(op.pattern, " ")
+		if doc.Paths[route] == nil {
+			doc.Paths[route] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[route][strings.ToLower(method)] = doc.operation(method, route, op)
+	}
+	return doc
+}
+
+func (doc *openAPIDocument) operation(method, route string, op operation) *openAPIOperation {
+	result := &openAPIOperation{
+		OperationID: op.id,
+		Summary:     op.summary,
+		Tags:        []string{strings.Split(strings.TrimPrefix(route, "/"), "/")[0]},
+		Responses:   map[string]*openAPIResponse{},
+	}
+	for _, name := range pathParameters(route) {
+		parameter := openAPIParameter{Name: name, In: "path", Required: true, Schema: &openAPISchema{Type: "string"}}
+		if name == "id" {
+			parameter.Schema = &openAPISchema{Type: "integer"}
+		}
+		result.Parameters = append(result.Parameters, parameter)
+	}
+	result.Parameters = append(result.Parameters, op.parameters...)
+
+	if op.request != nil {
+		result.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{"application/json": {Schema: doc.schemaOf(reflect.TypeOf(op.request))}},
+		}
+	}
+
+	success := &openAPIResponse{Description: http.StatusText(op.status)}
+	switch {
+	case len(op.contentTypes) > 0:
+		success.Content = map[string]openAPIMediaType{}
+		for _, contentType := range op.contentTypes {
+			schema := &openAPISchema{Type: "string", Format: "binary"}
+			if contentType == "text/event-stream" {
+				schema = doc.schemaOf(reflect.TypeOf(op.response))
+				success.Description = "Frames with the message's Cursor as id, its Type as event and the message as JSON data."
+			}
+			success.Content[contentType] = openAPIMediaType{Schema: schema}
+		}
+	case op.response != nil:
+		success.Content = map[string]openAPIMediaType{"application/json": {Schema: doc.schemaOf(reflect.TypeOf(op.response))}}
+	}
+	result.Responses[strconv.Itoa(op.status)] = success
+
+	if op.public {
+		result.Security = &[]map[string][]string{}
+		return result
+	}
+	statuses := []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError}
+	if len(pathParameters(route)) > 0 || method != http.MethodGet {
+		statuses = append(statuses, http.StatusNotFound)
+	}
+	if method != http.MethodGet {
+		statuses = append(statuses, http.StatusConflict)
+	}
+	if op.pattern != "GET /stream/postings" {
+		statuses = append(statuses, http.StatusGatewayTimeout)
+	}
+	for _, status := range statuses {
+		result.Responses[strconv.Itoa(status)] = &openAPIResponse{Ref: "#/components/responses/" + errorResponseName(status)}
+	}
+	return result
+}
+
+func errorResponses() map[string]*openAPIResponse {
+	responses := map[string]*openAPIResponse{}
+	add := func(status int, description string, errs ...error) {
+		schema := &openAPISchema{Ref: "#/components/schemas/api.errorResponse"}
+		if len(errs) > 0 {
+			messages := make([]string, 0, len(errs))
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			schema = &openAPISchema{
+				Type:       "object",
+				Properties: map[string]*openAPISchema{"Error": {Type: "string", Enum: messages}},
+				Required:   []string{"Error"},
+			}
+		}
+		responses[errorResponseName(status)] = &openAPIResponse{
+			Description: description,
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: schema}},
+		}
+	}
+	add(http.StatusBadRequest, "The request is malformed or fails validation.", validationErrors...)
+	add(http.StatusUnauthorized, "The bearer token is missing, unknown or belongs to an inactive user.", constants.ErrUnauthenticated)
+	add(http.StatusForbidden, "The role of the user does not allow the operation.", constants.ErrForbidden)
+	add(http.StatusNotFound, "A record the request refers to does not exist in the tenant.", notFoundErrors...)
+	add(http.StatusConflict, "The request conflicts with the stored records; reload them and try again.", conflictErrors...)
+	add(http.StatusInternalServerError, "The server failed unexpectedly.", constants.ErrUnexpectedError)
+	add(http.StatusGatewayTimeout, "The request took longer than the server's request timeout.", constants.ErrDeadlineExceeded)
+	return responses
+}
+
+func errorResponseName(status int) string {
+	return strings.ReplaceAll(http.StatusText(status), " ", "")
+}
+
+func pathParameters(route string) []string {
+	var names []string
+	for _, segment := range strings.Split(route, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			names = append(names, strings.TrimSuffix(name, "}"))
+		}
+	}
+	return names
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	amountType     = reflect.TypeOf(money.Amount(0))
+)
+
+// schemaOf describes how encoding/json writes values of t. Named structs are
+// added to the components once and referenced by "<package>.<type>".
+func (doc *openAPIDocument) schemaOf(t reflect.Type) *openAPISchema {
+	switch {
+	case t == timeType:
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &openAPISchema{Description: "Any JSON value."}
+	case t == amountType:
+		return &openAPISchema{Type: "integer", Format: "int64", Description: "Amount in minor units of the currency."}
+	case enums[t] != nil:
+		return &openAPISchema{Type: "string", Enum: enums[t]}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := doc.schemaOf(t.Elem())
+		if schema.Ref != "" {
+			return &openAPISchema{AllOf: []*openAPISchema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: doc.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: doc.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return doc.structSchema(t)
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			doc.Components.Schemas[name] = nil
+			doc.Components.Schemas[name] = doc.structSchema(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + name}
+	}
+	return &openAPISchema{}
+}
+
+// structSchema lists the exported fields of t under their Go names, or the
+// names of their json tags, with the fields of embedded structs inlined as
+// encoding/json does.
+func (doc *openAPIDocument) structSchema(t reflect.Type) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	for _, field := range reflect.VisibleFields(t) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || field.Anonymous || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = doc.schemaOf(field.Type)
+	}
+	return schema
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"accountingsystem/internal/constants"
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getOpenAPI(t *testing.T, server *Server) map[string]any {
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var doc map[string]any
+	require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+	return doc
+}
+
+func Test_GetOpenAPI_ServesDocument_WithoutAuthentication(t *testing.T) {
+	doc := getOpenAPI(t, newTestServer(t))
+
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.NotEmpty(t, doc["paths"])
+}
+
+func Test_GetOpenAPI_DocumentsExactlyTheRegisteredRoutes(t *testing.T) {
+	server := newTestServer(t)
+	doc := getOpenAPI(t, server)
+
+	var documented []string
+	for route, methods := range doc["paths"].(map[string]any) {
+		for method := range methods.(map[string]any) {
+			documented = append(documented, strings.ToUpper(method)+" "+route)
+		}
+	}
+	registered := append([]string{}, server.routes...)
+	sort.Strings(documented)
+	sort.Strings(registered)
+
+	assert.Equal(t, registered, documented)
+}
+
+func Test_GetOpenAPI_DocumentsPathParameters_OfEveryRoute(t *testing.T) {
+	doc := getOpenAPI(t, newTestServer(t))
+
+	for route, methods := range doc["paths"].(map[string]any) {
+		for method, op := range methods.(map[string]any) {
+			var inPath []string
+			parameters, _ := op.(map[string]any)["parameters"].([]any)
+			for _, parameter := range parameters {
+				if parameter.(map[string]any)["in"] == "path" {
+					inPath = append(inPath, parameter.(map[string]any)["name"].(string))
+				}
+			}
+			assert.Equal(t, pathParameters(route), inPath, "%s %s", method, route)
+		}
+	}
+}
+
+func Test_GetOpenAPI_ResolvesEverySchemaReference(t *testing.T) {
+	doc := getOpenAPI(t, newTestServer(t))
+	body, err := json.Marshal(doc)
+	require.Nil(t, err)
+	components := doc["components"].(map[string]any)
+
+	for _, ref := range strings.Split(string(body), `"$ref":"#/components/`)[1:] {
+		kind, rest, _ := strings.Cut(ref, "/")
+		name, _, _ := strings.Cut(rest, `"`)
+		assert.Contains(t, components[kind], name)
+	}
+}
+
+func Test_GetOpenAPI_DerivesSchemas_FromRequestsAndDtos(t *testing.T) {
+	doc := getOpenAPI(t, newTestServer(t))
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+
+	insert := schemas["voucher.InsertRequest"].(map[string]any)["properties"].(map[string]any)
+	assert.Contains(t, insert, "VoucherItems")
+	assert.Contains(t, insert, "DateText")
+	assert.NotContains(t, insert, "DateInput")
+	item := schemas["voucher.VoucherItemInsertDetail"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "integer", item["Debit"].(map[string]any)["type"])
+	assert.Equal(t, true, item["DLID"].(map[string]any)["nullable"])
+	assert.Contains(t, schemas, "dtos.VoucherWithItemsDto")
+}
+
+func Test_GetOpenAPI_ListsErrorMessages_OfBadRequestNotFoundAndConflict(t *testing.T) {
+	doc := getOpenAPI(t, newTestServer(t))
+	responses := doc["components"].(map[string]any)["responses"].(map[string]any)
+
+	for name, errs := range map[string][]error{"BadRequest": validationErrors, "NotFound": notFoundErrors, "Conflict": conflictErrors} {
+		schema := responses[name].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+		messages := schema["properties"].(map[string]any)["Error"].(map[string]any)["enum"].([]any)
+		require.Len(t, messages, len(errs))
+		for i, err := range errs {
+			assert.Equal(t, err.Error(), messages[i])
+		}
+	}
+}
+
+// unlistedErrors are not documented as responses: ErrCanceled is only written
+// to clients that went away, and the others are only returned while the
+// server reads its configuration.
+var unlistedErrors = []error{
+	constants.ErrCanceled,
+	constants.ErrEnvNotFound,
+	constants.ErrInvalidMinorUnits,
+	constants.ErrInvalidTextRule,
+	constants.ErrInvalidCodeTemplate,
+	constants.ErrInvalidTxIsolation,
+	constants.ErrInvalidTxRetry,
+	constants.ErrInvalidIdempotencyRetention,
+}
+
+func Test_GetOpenAPI_ListsEveryErrorConstant_InExactlyOneResponse(t *testing.T) {
+	doc := getOpenAPI(t, newTestServer(t))
+	listed := map[string]int{}
+	for _, response := range doc["components"].(map[string]any)["responses"].(map[string]any) {
+		schema := response.(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+		properties, _ := schema["properties"].(map[string]any)
+		if properties == nil {
+			continue
+		}
+		for _, message := range properties["Error"].(map[string]any)["enum"].([]any) {
+			listed[message.(string)]++
+		}
+	}
+	for _, err := range unlistedErrors {
+		listed[err.Error()]++
+	}
+
+	for message, count := range listed {
+		assert.Equal(t, 1, count, message)
+	}
+
+	// Every listed error is a constant and no two constants share a message,
+	// so listing as many messages as there are constants lists all of them.
+	file, err := parser.ParseFile(token.NewFileSet(), "../constants/errors.go", nil, 0)
+	require.Nil(t, err)
+	var constantCount int
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.VAR {
+			for _, spec := range genDecl.Specs {
+				constantCount += len(spec.(*ast.ValueSpec).Names)
+			}
+		}
+	}
+	assert.Len(t, listed, constantCount)
+}
+
+func Test_WriteOpenAPI_MatchesCommittedDocument(t *testing.T) {
+	var written bytes.Buffer
+	require.Nil(t, WriteOpenAPI(&written))
+
+	committed, err := os.ReadFile("../../openapi.json")
+
+	require.Nil(t, err)
+	assert.Equal(t, string(committed), written.String(), "openapi.json is outdated, regenerate it with: go run ./cmd/openapi > openapi.json")
+}
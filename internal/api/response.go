@@ -0,0 +1,183 @@
+package api
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/txretry"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// statusClientClosedRequest is the non-standard status used when the client
+// went away before its request was served.
+const statusClientClosedRequest = 499
+
+type errorResponse struct {
+	Error string
+}
+
+type nextCodeResponse struct {
+	Code string
+}
+
+type metricsResponse struct {
+	TransactionRetries txretry.Stats
+}
+
+var notFoundErrors = []error{
+	constants.ErrDLNotFound,
+	constants.ErrSLNotFound,
+	constants.ErrVoucherNotFound,
+	constants.ErrVoucherItemNotFound,
+	constants.ErrUserNotFound,
+	constants.ErrApprovalRuleNotFound,
+	constants.ErrWebhookNotFound,
+	constants.ErrWebhookDeliveryNotFound,
+	constants.ErrCurrencyNotFound,
+	constants.ErrExchangeRateNotFound,
+	constants.ErrNumberSequenceNotFound,
+	constants.ErrParentCodeNotFound,
+	constants.ErrVoucherTemplateNotFound,
+	constants.ErrTenantNotFound,
+}
+
+var conflictErrors = []error{
+	constants.ErrVersionOutdated,
+	constants.ErrCodeAlreadyExists,
+	constants.ErrTitleAlreadyExists,
+	constants.ErrVoucherNumberExists,
+	constants.ErrUsernameAlreadyExists,
+	constants.ErrThereIsRefrenceToDL,
+	constants.ErrThereIsRefrenceToSL,
+	constants.ErrThereIsRefrenceToCurrency,
+	constants.ErrSLArchived,
+	constants.ErrVoucherAlreadyReversed,
+	constants.ErrCodeHasChildren,
+	constants.ErrInvalidVoucherStatus,
+	constants.ErrAlreadyApproved,
+	constants.ErrTransactionConflict,
+	constants.ErrIdempotencyKeyReused,
+	constants.ErrCodeTemplateNotConfigured,
+	constants.ErrNoFreeCode,
+	constants.ErrNothingToRevalue,
+}
+
+// validationErrors are the errors a request can fail validation with. statusOf
+// does not need them, as every error it does not know is one, but the
+// OpenAPI document lists them so that clients can tell them apart.
+var validationErrors = []error{
+	constants.ErrMalformedRequest,
+	constants.ErrCodeEmptyOrTooLong,
+	constants.ErrTitleEmptyOrTooLong,
+	constants.ErrNumberEmptyOrTooLong,
+	constants.ErrCodeFormatInvalid,
+	constants.ErrTitleFormatInvalid,
+	constants.ErrNumberFormatInvalid,
+	constants.ErrCodeTemplateMismatch,
+	constants.ErrVoucherNumberReserved,
+	constants.ErrVoucherItemsCountOutOfRange,
+	constants.ErrDebitOrCreditInvalid,
+	constants.ErrDLIDRequired,
+	constants.ErrDLNotAllowed,
+	constants.ErrDebitCreditMismatch,
+	constants.ErrSLReferenceMismatch,
+	constants.ErrDLReferenceMismatch,
+	constants.ErrAmountOverflow,
+	constants.ErrInvalidAmount,
+	constants.ErrInvalidCurrencyCode,
+	constants.ErrInvalidExchangeRate,
+	constants.ErrCurrencyDetailsInvalid,
+	constants.ErrForeignAmountRequired,
+	constants.ErrFunctionalAmountMismatch,
+	constants.ErrInvalidCalendar,
+	constants.ErrInvalidDate,
+	constants.ErrPrefixTooLong,
+	constants.ErrPaddingOutOfRange,
+	constants.ErrFiscalStartMonthOutOfRange,
+	constants.ErrInvalidCronExpression,
+	constants.ErrInvalidFormula,
+	constants.ErrInvalidExportFormat,
+	constants.ErrInvalidFrequency,
+	constants.ErrScheduleStartRequired,
+	constants.ErrInvalidTemplateItemSide,
+	constants.ErrTemplateItemAmountInvalid,
+	constants.ErrUsernameEmptyOrTooLong,
+	constants.ErrInvalidRole,
+	constants.ErrApprovalConditionRequired,
+	constants.ErrMinAmountNotPositive,
+	constants.ErrRequiredApprovalsInvalid,
+	constants.ErrSelfApproval,
+	constants.ErrCommentTooLong,
+	constants.ErrIdempotencyKeyTooLong,
+	constants.ErrInvalidWebhookURL,
+	constants.ErrWebhookEventTypesEmpty,
+	constants.ErrUnknownEventType,
+	constants.ErrInvalidWebhookSecret,
+	constants.ErrInvalidDeliveryStatus,
+	constants.ErrTooManyIDs,
+}
+
+// statusOf maps a service error to an HTTP status. Errors that are neither
+// unexpected nor listed here are validation errors of the request.
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, constants.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, constants.ErrUnexpectedError):
+		return http.StatusInternalServerError
+	case errors.Is(err, constants.ErrDeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, constants.ErrCanceled):
+		return statusClientClosedRequest
+	}
+	for _, notFound := range notFoundErrors {
+		if errors.Is(err, notFound) {
+			return http.StatusNotFound
+		}
+	}
+	for _, conflict := range conflictErrors {
+		if errors.Is(err, conflict) {
+			return http.StatusConflict
+		}
+	}
+	return http.StatusBadRequest
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusOf(err), errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+func readJSON(r *http.Request, body any) error {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		return constants.ErrMalformedRequest
+	}
+	return nil
+}
+
+func pathID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return 0, constants.ErrMalformedRequest
+	}
+	return id, nil
+}
+
+func queryVersion(r *http.Request) (int, error) {
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil {
+		return 0, constants.ErrMalformedRequest
+	}
+	return version, nil
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/graphqlapi"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/services"
+	"accountingsystem/internal/txretry"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Server exposes the services over HTTP. Every route but the health check,
+// the metrics and the OpenAPI document requires an "Authorization: Bearer
+// <token>" header, and the user the token belongs to is the actor of the
+// service call.
+type Server struct {
+	dlService            *services.DLService
+	slService            *services.SLService
+	voucherService       *services.VoucherService
+	approvalRuleService  *services.ApprovalRuleService
+	userService          *services.UserService
+	webhookService       *services.WebhookService
+	postingStreamService *services.PostingStreamService
+	graphQLServer        *graphqlapi.Server
+	requestTimeout       time.Duration
+	mux                  *http.ServeMux
+	// routes lists the registered patterns, so the OpenAPI document can be
+	// checked against them.
+	routes  []string
+	openAPI *openAPIDocument
+}
+
+// handlerFunc handles an authenticated request. A returned error is written
+// as the response with the status it maps to.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, actor auth.Principal) error
+
+// InitServer prepares the routes. A positive requestTimeout bounds how long
+// the services may work on a single request.
+func (s *Server) InitServer(db *gorm.DB, requestTimeout time.Duration) error {
+	s.requestTimeout = requestTimeout
+	s.dlService = &services.DLService{}
+	s.slService = &services.SLService{}
+	s.voucherService = &services.VoucherService{}
+	s.approvalRuleService = &services.ApprovalRuleService{}
+	s.userService = &services.UserService{}
+	s.webhookService = &services.WebhookService{}
+	s.postingStreamService = &services.PostingStreamService{}
+
+	s.dlService.InitService(db)
+	s.slService.InitService(db)
+	s.voucherService.InitService(db)
+	s.approvalRuleService.InitService(db)
+	s.userService.InitService(db)
+	s.webhookService.InitService(db)
+	s.postingStreamService.InitService(db)
+
+	s.graphQLServer = &graphqlapi.Server{}
+	if err := s.graphQLServer.InitServer(db); err != nil {
+		return err
+	}
+
+	s.openAPI = buildOpenAPI()
+	s.mux = http.NewServeMux()
+	s.registerRoutes()
+	return nil
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+func (s *Server) registerRoutes() {
+	s.handlePublic("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handlePublic("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, metricsResponse{TransactionRetries: txretry.Snapshot()})
+	})
+	s.handlePublic("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.openAPI)
+	})
+
+	s.handle("POST /dls", s.createDL)
+	s.handle("GET /dls/next-code", s.nextDLCode)
+	s.handle("GET /dls/by-code/{code}", s.getDLByCode)
+	s.handle("GET /dls/{id}", s.getDL)
+	s.handle("PUT /dls/{id}", s.updateDL)
+	s.handle("DELETE /dls/{id}", s.deleteDL)
+
+	s.handle("POST /sls", s.createSL)
+	s.handle("GET /sls/next-code", s.nextSLCode)
+	s.handle("GET /sls/by-code/{code}", s.getSLByCode)
+	s.handle("GET /sls/{id}", s.getSL)
+	s.handle("PUT /sls/{id}", s.updateSL)
+	s.handle("DELETE /sls/{id}", s.deleteSL)
+	s.handle("POST /sls/{id}/archive", s.archiveSL)
+
+	s.handle("POST /vouchers", s.createVoucher)
+	// Numbers have a path of their own, as /vouchers/by-number/{number}
+	// would overlap /vouchers/{id}/export and make the mux panic.
+	s.handle("GET /voucher-numbers/{number}", s.getVoucherByNumber)
+	s.handle("GET /vouchers/{id}", s.getVoucher)
+	s.handle("PUT /vouchers/{id}", s.updateVoucher)
+	s.handle("DELETE /vouchers/{id}", s.deleteVoucher)
+	s.handle("POST /vouchers/{id}/reverse", s.reverseVoucher)
+	s.handle("POST /vouchers/{id}/copy", s.copyVoucher)
+	s.handle("GET /vouchers/{id}/export", s.exportVoucher)
+	s.handle("POST /vouchers/{id}/submit", s.submitVoucher)
+	s.handle("POST /vouchers/{id}/approve", s.approveVoucher)
+	s.handle("POST /vouchers/{id}/reject", s.rejectVoucher)
+	s.handle("GET /vouchers/{id}/approvals", s.getVoucherApprovals)
+	s.handle("GET /vouchers/approval-queue", s.getApprovalQueue)
+
+	s.handle("POST /approval-rules", s.createApprovalRule)
+	s.handle("GET /approval-rules/{id}", s.getApprovalRule)
+	s.handle("PUT /approval-rules/{id}", s.updateApprovalRule)
+	s.handle("DELETE /approval-rules/{id}", s.deleteApprovalRule)
+
+	s.handle("POST /webhooks", s.createWebhook)
+	s.handle("GET /webhooks/{id}", s.getWebhook)
+	s.handle("PUT /webhooks/{id}", s.updateWebhook)
+	s.handle("DELETE /webhooks/{id}", s.deleteWebhook)
+	s.handle("GET /webhooks/{id}/deliveries", s.getWebhookDeliveries)
+	s.handle("POST /webhook-deliveries/{id}/replay", s.replayWebhookDelivery)
+
+	s.handleStream("GET /stream/postings", s.streamPostings)
+
+	s.handle("POST /graphql", s.graphQL)
+
+	s.handle("POST /users", s.createUser)
+	s.handle("GET /users/{id}", s.getUser)
+	s.handle("PUT /users/{id}", s.updateUser)
+	s.handle("POST /users/{id}/token", s.rotateUserToken)
+}
+
+// handlePublic registers a handler that needs no authentication.
+func (s *Server) handlePublic(pattern string, handler http.HandlerFunc) {
+	s.routes = append(s.routes, pattern)
+	s.mux.HandleFunc(pattern, handler)
+}
+
+func (s *Server) handle(pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, pattern)
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if s.requestTimeout > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), s.requestTimeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+		s.serveAuthenticated(w, r, handler)
+	})
+}
+
+// handleStream registers a long-lived handler, which is not bound by the
+// request timeout and runs until the client goes away.
+func (s *Server) handleStream(pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, pattern)
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.serveAuthenticated(w, r, handler)
+	})
+}
+
+func (s *Server) serveAuthenticated(w http.ResponseWriter, r *http.Request, handler handlerFunc) {
+	actor, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := handler(w, r, actor); err != nil {
+		writeError(w, err)
+	}
+}
+
+func (s *Server) authenticate(r *http.Request) (auth.Principal, error) {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return s.userService.Authenticate(&user.AuthenticateRequest{Token: strings.TrimSpace(token)})
+}
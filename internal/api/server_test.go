@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *Server {
+	server := &Server{}
+	require.Nil(t, server.InitServer(nil, 0))
+	return server
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+func Test_InitServer_RegistersRoutes_WithoutConflictingPatterns(t *testing.T) {
+	assert.NotPanics(t, func() {
+		server := &Server{}
+		require.Nil(t, server.InitServer(nil, 0))
+	})
+}
+
+func Test_Handler_RequiresAuthentication_OnEveryRegisteredRoute(t *testing.T) {
+	server := newTestServer(t)
+	public := map[string]bool{"GET /health": true, "GET /metrics": true, "GET /openapi.json": true}
+
+	for _, route := range server.routes {
+		if public[route] {
+			continue
+		}
+		method, path, _ := strings.Cut(route, " ")
+		recorder := httptest.NewRecorder()
+
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(method, pathParameterPattern.ReplaceAllString(path, "1"), nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, route)
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/requests/sl"
+	"net/http"
+)
+
+func (s *Server) createSL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req sl.InsertRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		req.IdempotencyKey = key
+	}
+	slDto, err := s.slService.CreateSLContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, slDto)
+	return nil
+}
+
+func (s *Server) updateSL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req sl.UpdateRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	slDto, err := s.slService.UpdateSLContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, slDto)
+	return nil
+}
+
+func (s *Server) deleteSL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	version, err := queryVersion(r)
+	if err != nil {
+		return err
+	}
+	if err := s.slService.DeleteSLContext(r.Context(), actor, &sl.DeleteRequest{ID: id, Version: version}); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) archiveSL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req sl.ArchiveRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	slDto, err := s.slService.ArchiveSLContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, slDto)
+	return nil
+}
+
+func (s *Server) getSL(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	slDto, err := s.slService.GetSLContext(r.Context(), actor, &sl.GetRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, slDto)
+	return nil
+}
+
+func (s *Server) getSLByCode(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	slDto, err := s.slService.GetSLByCodeContext(r.Context(), actor, &sl.GetByCodeRequest{Code: r.PathValue("code")})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, slDto)
+	return nil
+}
+
+func (s *Server) nextSLCode(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	code, err := s.slService.NextSLCodeContext(r.Context(), actor, &sl.NextCodeRequest{ParentCode: r.URL.Query().Get("parent")})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, nextCodeResponse{Code: code})
+	return nil
+}
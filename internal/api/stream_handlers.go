@@ -0,0 +1,124 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/stream"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// streamHeartbeatInterval keeps idle streams from being closed by proxies.
+const streamHeartbeatInterval = 15 * time.Second
+
+// streamPostings serves the postings stream as server-sent events. Each
+// message has the cursor of its event as id, so a reconnecting EventSource
+// resumes through the Last-Event-ID header; other clients can pass the
+// cursor query parameter. The sl and dl parameters, repeated or comma
+// separated, filter the vouchers.
+func (s *Server) streamPostings(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return err
+	}
+	req, err := postingsRequest(r)
+	if err != nil {
+		return err
+	}
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	controller.Flush()
+
+	var mu sync.Mutex
+	write := func(frame string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, err := fmt.Fprint(w, frame); err != nil {
+			return err
+		}
+		return controller.Flush()
+	}
+
+	// The heartbeat has to stop writing before the handler returns, as the
+	// ResponseWriter may not be used after that.
+	done := make(chan struct{})
+	var heartbeat sync.WaitGroup
+	heartbeat.Add(1)
+	defer func() {
+		close(done)
+		heartbeat.Wait()
+	}()
+	go func() {
+		defer heartbeat.Done()
+		ticker := time.NewTicker(streamHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				write(": heartbeat\n\n")
+			}
+		}
+	}()
+
+	err = s.postingStreamService.StreamPostings(r.Context(), actor, req, func(message *dtos.PostingMessageDto) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		return write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", message.Cursor, message.Type, data))
+	})
+	if err != nil && r.Context().Err() == nil {
+		data, _ := json.Marshal(errorResponse{Error: err.Error()})
+		write(fmt.Sprintf("event: error\ndata: %s\n\n", data))
+	}
+	return nil
+}
+
+func postingsRequest(r *http.Request) (*stream.PostingsRequest, error) {
+	req := &stream.PostingsRequest{}
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = r.URL.Query().Get("cursor")
+	}
+	if cursor != "" {
+		value, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || value < 0 {
+			return nil, constants.ErrMalformedRequest
+		}
+		req.Cursor = value
+	}
+
+	var err error
+	if req.SLIDs, err = queryIDs(r, "sl"); err != nil {
+		return nil, err
+	}
+	if req.DLIDs, err = queryIDs(r, "dl"); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func queryIDs(r *http.Request, name string) ([]int, error) {
+	var ids []int
+	for _, value := range r.URL.Query()[name] {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, constants.ErrMalformedRequest
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/requests/user"
+	"net/http"
+)
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req user.InsertRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	userDto, err := s.userService.CreateUser(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, userDto)
+	return nil
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req user.UpdateRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	userDto, err := s.userService.UpdateUser(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, userDto)
+	return nil
+}
+
+func (s *Server) rotateUserToken(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req user.RotateTokenRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	userDto, err := s.userService.RotateUserToken(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, userDto)
+	return nil
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	userDto, err := s.userService.GetUser(actor, &user.GetRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, userDto)
+	return nil
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/requests/voucher"
+	"net/http"
+)
+
+func (s *Server) createVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.InsertRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		req.IdempotencyKey = key
+	}
+	voucherDto, err := s.voucherService.CreateVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, voucherDto)
+	return nil
+}
+
+func (s *Server) updateVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.UpdateRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	voucherDto, err := s.voucherService.UpdateVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, voucherDto)
+	return nil
+}
+
+func (s *Server) deleteVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	version, err := queryVersion(r)
+	if err != nil {
+		return err
+	}
+	if err := s.voucherService.DeleteVoucherContext(r.Context(), actor, &voucher.DeleteRequest{ID: id, Version: version}); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) getVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	voucherDto, err := s.voucherService.GetVoucherContext(r.Context(), actor, &voucher.GetRequest{ID: id, Calendar: queryCalendar(r)})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, voucherDto)
+	return nil
+}
+
+func (s *Server) getVoucherByNumber(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	voucherDto, err := s.voucherService.GetVoucherByNumberContext(r.Context(), actor, &voucher.GetByNumberRequest{Number: r.PathValue("number"), Calendar: queryCalendar(r)})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, voucherDto)
+	return nil
+}
+
+func (s *Server) reverseVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.ReverseRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	voucherDto, err := s.voucherService.ReverseVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, voucherDto)
+	return nil
+}
+
+func (s *Server) copyVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req voucher.CopyRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	voucherDto, err := s.voucherService.CopyVoucherContext(r.Context(), actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, voucherDto)
+	return nil
+}
+
+func (s *Server) exportVoucher(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	format := export.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		format = export.FormatCSV
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	return s.voucherService.ExportVoucherContext(r.Context(), actor, &voucher.GetRequest{ID: id, Calendar: queryCalendar(r)}, format, w)
+}
+
+// queryCalendar is the calendar the voucher date is written in, the
+// configured one when the query leaves it out.
+func queryCalendar(r *http.Request) calendar.Calendar {
+	return calendar.Calendar(r.URL.Query().Get("calendar"))
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/requests/webhook"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req webhook.InsertRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	subscriptionDto, err := s.webhookService.CreateWebhook(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, subscriptionDto)
+	return nil
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	var req webhook.UpdateRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	subscriptionDto, err := s.webhookService.UpdateWebhook(actor, &req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, subscriptionDto)
+	return nil
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	version, err := queryVersion(r)
+	if err != nil {
+		return err
+	}
+	if err := s.webhookService.DeleteWebhook(actor, &webhook.DeleteRequest{ID: id, Version: version}); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	subscriptionDto, err := s.webhookService.GetWebhook(actor, &webhook.GetRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, subscriptionDto)
+	return nil
+}
+
+func (s *Server) getWebhookDeliveries(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := pathID(r)
+	if err != nil {
+		return err
+	}
+	req := &webhook.GetDeliveriesRequest{SubscriptionID: id, Status: r.URL.Query().Get("status")}
+	deliveryDtos, err := s.webhookService.GetWebhookDeliveries(actor, req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, deliveryDtos)
+	return nil
+}
+
+func (s *Server) replayWebhookDelivery(w http.ResponseWriter, r *http.Request, actor auth.Principal) error {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return constants.ErrMalformedRequest
+	}
+	deliveryDto, err := s.webhookService.ReplayWebhookDelivery(actor, &webhook.ReplayRequest{DeliveryID: id})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusAccepted, deliveryDto)
+	return nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+import "accountingsystem/internal/constants"
+
+type Role string
+
+const (
+	Viewer     Role = "viewer"
+	Accountant Role = "accountant"
+	Approver   Role = "approver"
+	Admin      Role = "admin"
+)
+
+type Entity string
+
+const (
+	EntityDL              Entity = "dl"
+	EntitySL              Entity = "sl"
+	EntityVoucher         Entity = "voucher"
+	EntityVoucherTemplate Entity = "voucher_template"
+	EntityApprovalRule    Entity = "approval_rule"
+	EntityUser            Entity = "user"
+	EntityWebhook         Entity = "webhook"
+	EntityCurrency        Entity = "currency"
+	EntityNumberSequence  Entity = "number_sequence"
+	EntityTenant          Entity = "tenant"
+)
+
+type Action string
+
+const (
+	ActionRead    Action = "read"
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionReverse Action = "reverse"
+	ActionSubmit  Action = "submit"
+	ActionApprove Action = "approve"
+	// ActionDeletePosted is deleting a voucher that is already posted, which
+	// would undo what approvers signed off. No role but admin may do it;
+	// others reverse the voucher instead.
+	ActionDeletePosted Action = "delete_posted"
+)
+
+type Permission struct {
+	Entity Entity
+	Action Action
+}
+
+// DefaultTenantID is the tenant the books kept before tenants were introduced
+// were moved into.
+const DefaultTenantID = 1
+
+// Principal is the actor a service operation is performed for. Operations
+// only see and touch the records of the principal's tenant. The zero value
+// has no role and is allowed nothing.
+type Principal struct {
+	UserID   int
+	Username string
+	Role     Role
+	TenantID int
+}
+
+// System returns the principal of background jobs and operator commands,
+// such as the voucher template scheduler, acting within a tenant.
+func System(tenantID int) Principal {
+	return Principal{Username: "system", Role: Admin, TenantID: tenantID}
+}
+
+// permissions lists what each role may do besides reading, which every role
+// may do on every entity except users, webhooks and tenants. Admins may do
+// everything.
+var permissions = map[Role][]Permission{
+	Accountant: {
+		{EntityDL, ActionCreate},
+		{EntityDL, ActionUpdate},
+		{EntityVoucher, ActionCreate},
+		{EntityVoucher, ActionUpdate},
+		{EntityVoucher, ActionDelete},
+		{EntityVoucher, ActionSubmit},
+		{EntityVoucherTemplate, ActionCreate},
+		{EntityVoucherTemplate, ActionUpdate},
+		{EntityVoucherTemplate, ActionDelete},
+	},
+	Approver: {
+		{EntityVoucher, ActionReverse},
+		{EntityVoucher, ActionApprove},
+	},
+}
+
+func (r Role) IsValid() bool {
+	switch r {
+	case Viewer, Accountant, Approver, Admin:
+		return true
+	}
+	return false
+}
+
+func (p Principal) Can(entity Entity, action Action) bool {
+	if !p.Role.IsValid() {
+		return false
+	}
+	if p.Role == Admin {
+		return true
+	}
+	if action == ActionRead && entity != EntityUser && entity != EntityWebhook && entity != EntityTenant {
+		return true
+	}
+	for _, permission := range permissions[p.Role] {
+		if permission.Entity == entity && permission.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns constants.ErrForbidden unless the principal may perform
+// action on entity.
+func Authorize(p Principal, entity Entity, action Action) error {
+	if !p.Can(entity, action) {
+		return constants.ErrForbidden
+	}
+	return nil
+}
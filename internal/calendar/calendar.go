@@ -0,0 +1,195 @@
+package calendar
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/internal/constants"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar selects how dates are read from requests, labelled in DTOs and
+// split into months and fiscal years. Dates are always stored as Gregorian
+// UTC midnights; a calendar only changes how they are presented and grouped.
+type Calendar string
+
+const (
+	Gregorian Calendar = "gregorian"
+	Jalali    Calendar = "jalali"
+)
+
+var defaultCalendar = Gregorian
+
+var gregorianMonthNames = [12]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var jalaliMonthNames = [12]string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+// Init reads CALENDAR, the calendar used when a request does not choose one.
+// It defaults to gregorian.
+func Init() error {
+	value, err := configs.GetEnv("CALENDAR")
+	if err != nil || value == "" {
+		defaultCalendar = Gregorian
+		return nil
+	}
+	c := Calendar(value)
+	if !c.IsValid() {
+		return constants.ErrInvalidCalendar
+	}
+	defaultCalendar = c
+	return nil
+}
+
+func Default() Calendar {
+	return defaultCalendar
+}
+
+// OrDefault returns c, or the configured calendar when c is empty.
+func OrDefault(c Calendar) Calendar {
+	if c == "" {
+		return defaultCalendar
+	}
+	return c
+}
+
+func (c Calendar) IsValid() bool {
+	return c == Gregorian || c == Jalali
+}
+
+// Date returns the UTC midnight of the given day in the calendar.
+func (c Calendar) Date(year int, month int, day int) (time.Time, error) {
+	if month < 1 || month > 12 || day < 1 || day > c.monthLength(year, month) {
+		return time.Time{}, constants.ErrInvalidDate
+	}
+	if c == Jalali {
+		if !isSupportedJalaliYear(year) {
+			return time.Time{}, constants.ErrInvalidDate
+		}
+		return jalaliToGregorian(year, month, day), nil
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// YearMonthDay returns the calendar date of the day t falls on in UTC.
+func (c Calendar) YearMonthDay(t time.Time) (int, int, int) {
+	t = t.UTC()
+	if c == Jalali {
+		return gregorianToJalali(t)
+	}
+	return t.Year(), int(t.Month()), t.Day()
+}
+
+// Parse reads a date written as year, month and day separated by '-' or '/'.
+func (c Calendar) Parse(text string) (time.Time, error) {
+	parts := strings.FieldsFunc(strings.TrimSpace(text), func(r rune) bool {
+		return r == '-' || r == '/'
+	})
+	if len(parts) != 3 {
+		return time.Time{}, constants.ErrInvalidDate
+	}
+	fields := [3]int{}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, constants.ErrInvalidDate
+		}
+		fields[i] = value
+	}
+	return c.Date(fields[0], fields[1], fields[2])
+}
+
+// Format writes t as YYYY/MM/DD in the Jalali calendar and as YYYY-MM-DD in
+// the Gregorian one.
+func (c Calendar) Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	year, month, day := c.YearMonthDay(t)
+	if c == Jalali {
+		return fmt.Sprintf("%04d/%02d/%02d", year, month, day)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+func (c Calendar) MonthName(month int) string {
+	if month < 1 || month > 12 {
+		return ""
+	}
+	if c == Jalali {
+		return jalaliMonthNames[month-1]
+	}
+	return gregorianMonthNames[month-1]
+}
+
+// PeriodLabel names a month of the calendar, e.g. "Farvardin 1403".
+func (c Calendar) PeriodLabel(year int, month int) string {
+	return c.MonthName(month) + " " + strconv.Itoa(year)
+}
+
+// NextMonthStart returns the first day of the month after the one t falls in.
+func (c Calendar) NextMonthStart(t time.Time) time.Time {
+	year, month, _ := c.YearMonthDay(t)
+	year, month = c.AddMonths(year, month, 1)
+	if c == Jalali {
+		return jalaliToGregorian(year, month, 1)
+	}
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// FiscalYearOf returns the fiscal year t falls in when fiscal years start on
+// startMonth of the calendar. A fiscal year is named after the calendar year
+// it starts in.
+func (c Calendar) FiscalYearOf(t time.Time, startMonth int) int {
+	year, month, _ := c.YearMonthDay(t)
+	if month < startMonth {
+		return year - 1
+	}
+	return year
+}
+
+// Periods returns the start of each month of a fiscal year followed by the
+// start of the next fiscal year, so period i spans [starts[i], starts[i+1]).
+func (c Calendar) Periods(fiscalYear int, startMonth int) ([]time.Time, error) {
+	if startMonth < 1 || startMonth > 12 {
+		return nil, constants.ErrFiscalStartMonthOutOfRange
+	}
+	starts := make([]time.Time, 13)
+	for i := range starts {
+		year, month := c.AddMonths(fiscalYear, startMonth, i)
+		start, err := c.Date(year, month, 1)
+		if err != nil {
+			return nil, err
+		}
+		starts[i] = start
+	}
+	return starts, nil
+}
+
+// AddMonths moves a calendar month forward by n months.
+func (c Calendar) AddMonths(year int, month int, n int) (int, int) {
+	index := year*12 + month - 1 + n
+	return index / 12, index%12 + 1
+}
+
+func (c Calendar) monthLength(year int, month int) int {
+	if c == Jalali {
+		switch {
+		case month <= 6:
+			return 31
+		case month <= 11:
+			return 30
+		case isJalaliLeapYear(year):
+			return 30
+		default:
+			return 29
+		}
+	}
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,88 @@
+package calendar
+
+import "time"
+
+// jalaliBreaks are the years in which the 33-year leap cycle of the Jalali
+// calendar is realigned with the astronomical vernal equinox. The conversion
+// follows the arithmetic of Borkowski's algorithm and is exact for the years
+// between the first and the last break.
+var jalaliBreaks = []int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+func isSupportedJalaliYear(year int) bool {
+	return year >= jalaliBreaks[0] && year < jalaliBreaks[len(jalaliBreaks)-1]
+}
+
+// jalaliYearInfo returns whether the Jalali year is leap and the day of March
+// of the Gregorian year year+621 on which its first day (1 Farvardin) falls.
+func jalaliYearInfo(year int) (bool, int) {
+	gregorianYear := year + 621
+	leapJ := -14
+	previousBreak := jalaliBreaks[0]
+	jump := 0
+	for _, currentBreak := range jalaliBreaks[1:] {
+		jump = currentBreak - previousBreak
+		if year < currentBreak {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		previousBreak = currentBreak
+	}
+
+	n := year - previousBreak
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gregorianYear/4 - (gregorianYear/100+1)*3/4 - 150
+	march := 20 + leapJ - leapG
+
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap := ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return leap == 0, march
+}
+
+func isJalaliLeapYear(year int) bool {
+	if !isSupportedJalaliYear(year) {
+		return false
+	}
+	leap, _ := jalaliYearInfo(year)
+	return leap
+}
+
+func jalaliNewYear(year int) time.Time {
+	_, march := jalaliYearInfo(year)
+	return time.Date(year+621, time.March, march, 0, 0, 0, 0, time.UTC)
+}
+
+func jalaliToGregorian(year int, month int, day int) time.Time {
+	dayOfYear := (month-1)*31 + day - 1
+	if month > 7 {
+		dayOfYear = 186 + (month-7)*30 + day - 1
+	}
+	return jalaliNewYear(year).AddDate(0, 0, dayOfYear)
+}
+
+func gregorianToJalali(t time.Time) (int, int, int) {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	year := date.Year() - 621
+	newYear := jalaliNewYear(year)
+	if date.Before(newYear) {
+		year--
+		newYear = jalaliNewYear(year)
+	}
+
+	dayOfYear := int(date.Sub(newYear).Hours() / 24)
+	if dayOfYear < 186 {
+		return year, dayOfYear/31 + 1, dayOfYear%31 + 1
+	}
+	dayOfYear -= 186
+	return year, dayOfYear/30 + 7, dayOfYear%30 + 1
+}
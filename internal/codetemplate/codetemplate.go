@@ -0,0 +1,198 @@
+package codetemplate
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/internal/constants"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCodeLength is the VARCHAR length of the code columns.
+const maxCodeLength = 64
+
+// Template describes how codes of an entity encode their place in a
+// hierarchy. Segments holds the length of each level, so with {2, 2, 4} the
+// code 1101 is the child of 11 and the parent of 11010001. A template
+// without segments accepts any code.
+type Template struct {
+	Segments      []int
+	NumericOnly   bool
+	RequireParent bool
+}
+
+// Entity is an entity whose codes follow a template. Its template is read
+// from <Name>_CODE_SEGMENTS (comma-separated lengths),
+// <Name>_CODE_NUMERIC_ONLY and <Name>_CODE_REQUIRE_PARENT.
+type Entity struct {
+	Name     string
+	template Template
+}
+
+var (
+	DL = &Entity{Name: "DL"}
+	SL = &Entity{Name: "SL"}
+)
+
+func Entities() []*Entity {
+	return []*Entity{DL, SL}
+}
+
+func Init() error {
+	for _, entity := range Entities() {
+		template, err := readTemplate(entity.Name)
+		if err != nil {
+			return err
+		}
+		entity.template = template
+	}
+	return nil
+}
+
+func readTemplate(name string) (Template, error) {
+	var template Template
+	if value, err := configs.GetEnv(name + "_CODE_SEGMENTS"); err == nil && value != "" {
+		for _, segment := range strings.Split(value, ",") {
+			length, err := strconv.Atoi(strings.TrimSpace(segment))
+			if err != nil {
+				return Template{}, constants.ErrInvalidCodeTemplate
+			}
+			template.Segments = append(template.Segments, length)
+		}
+	}
+	if value, err := configs.GetEnv(name + "_CODE_NUMERIC_ONLY"); err == nil && value != "" {
+		if template.NumericOnly, err = strconv.ParseBool(value); err != nil {
+			return Template{}, constants.ErrInvalidCodeTemplate
+		}
+	}
+	if value, err := configs.GetEnv(name + "_CODE_REQUIRE_PARENT"); err == nil && value != "" {
+		if template.RequireParent, err = strconv.ParseBool(value); err != nil {
+			return Template{}, constants.ErrInvalidCodeTemplate
+		}
+	}
+	if err := template.validate(); err != nil {
+		return Template{}, err
+	}
+	return template, nil
+}
+
+func (t Template) validate() error {
+	total := 0
+	for _, length := range t.Segments {
+		if length < 1 {
+			return constants.ErrInvalidCodeTemplate
+		}
+		total += length
+	}
+	if total > maxCodeLength {
+		return constants.ErrInvalidCodeTemplate
+	}
+	return nil
+}
+
+func (e *Entity) Template() Template {
+	return e.template
+}
+
+// SetTemplate replaces the template of the entity.
+func (e *Entity) SetTemplate(template Template) error {
+	if err := template.validate(); err != nil {
+		return err
+	}
+	e.template = template
+	return nil
+}
+
+func (t Template) IsHierarchical() bool {
+	return len(t.Segments) > 0
+}
+
+// Level returns the 1-based level of code in the hierarchy, or 0 when its
+// length does not end a segment.
+func (t Template) Level(code string) int {
+	length := utf8.RuneCountInString(code)
+	total := 0
+	for i, segment := range t.Segments {
+		total += segment
+		if length == total {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LengthAt returns the length of codes on the given 1-based level.
+func (t Template) LengthAt(level int) int {
+	total := 0
+	for _, segment := range t.Segments[:level] {
+		total += segment
+	}
+	return total
+}
+
+// Validate checks that code is numeric when required and, in a hierarchical
+// template, that its length ends a segment.
+func (t Template) Validate(code string) error {
+	if t.NumericOnly && !isNumeric(code) {
+		return constants.ErrCodeTemplateMismatch
+	}
+	if t.IsHierarchical() && t.Level(code) == 0 {
+		return constants.ErrCodeTemplateMismatch
+	}
+	return nil
+}
+
+// ParentOf returns the code of the parent of code, or "" for a top-level
+// code.
+func (t Template) ParentOf(code string) string {
+	level := t.Level(code)
+	if level <= 1 {
+		return ""
+	}
+	return string([]rune(code)[:t.LengthAt(level-1)])
+}
+
+// NextCode returns the code following the largest numeric sibling under
+// parent, or the first code under it when there are none. An empty parent
+// stands for the top level.
+func (t Template) NextCode(parent string, siblings []string) (string, error) {
+	level := 1
+	if parent != "" {
+		level = t.Level(parent) + 1
+	}
+	if level > len(t.Segments) {
+		return "", constants.ErrNoFreeCode
+	}
+
+	width := t.Segments[level-1]
+	last := uint64(0)
+	for _, sibling := range siblings {
+		suffix := string([]rune(sibling)[utf8.RuneCountInString(parent):])
+		if !isNumeric(suffix) {
+			continue
+		}
+		value, err := strconv.ParseUint(suffix, 10, 64)
+		if err == nil && value > last {
+			last = value
+		}
+	}
+
+	next := fmt.Sprintf("%0*d", width, last+1)
+	if len(next) > width {
+		return "", constants.ErrNoFreeCode
+	}
+	return parent + next, nil
+}
+
+func isNumeric(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, character := range text {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+	return true
+}
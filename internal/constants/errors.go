@@ -1,18 +1,21 @@
 package constants
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrUnexpectedError             = errors.New("something went wrong")
 	ErrEnvNotFound                 = errors.New("environment variable not found")
-	ErrCodeEmptyOrTooLong          = errors.New("code cannot be empty or more than 64 characters")
-	ErrTitleEmptyOrTooLong         = errors.New("title cannot be empty or more than 64 characters")
+	ErrCodeEmptyOrTooLong          = errors.New("code cannot be empty or longer than allowed")
+	ErrTitleEmptyOrTooLong         = errors.New("title cannot be empty or longer than allowed")
 	ErrCodeAlreadyExists           = errors.New("code should be unique")
 	ErrTitleAlreadyExists          = errors.New("title should be unique")
 	ErrDLNotFound                  = errors.New("DL not found")
 	ErrVersionOutdated             = errors.New("version is outdated")
 	ErrSLNotFound                  = errors.New("SL not found")
-	ErrNumberEmptyOrTooLong        = errors.New("number cannot be empty or more than 64 characters")
+	ErrNumberEmptyOrTooLong        = errors.New("number cannot be empty or longer than allowed")
 	ErrVoucherNumberExists         = errors.New("voucher number already exists")
 	ErrVoucherItemsCountOutOfRange = errors.New("voucher items count should be between 2 and 500")
 	ErrDebitOrCreditInvalid        = errors.New("one and only one of debit or credit should be greater than 0")
@@ -23,4 +26,77 @@ var (
 	ErrThereIsRefrenceToSL         = errors.New("there is refrence to this SL")
 	ErrVoucherItemNotFound         = errors.New("voucher item not found")
 	ErrVoucherNotFound             = errors.New("voucher not found")
+	ErrSLReferenceMismatch         = errors.New("provided SL code does not match provided SL ID")
+	ErrDLReferenceMismatch         = errors.New("provided DL code does not match provided DL ID")
+	ErrNumberSequenceNotFound      = errors.New("number sequence not found")
+	ErrPrefixTooLong               = errors.New("prefix cannot be more than 32 characters")
+	ErrPaddingOutOfRange           = errors.New("padding should be between 0 and 20")
+	ErrFiscalStartMonthOutOfRange  = errors.New("fiscal year start month should be between 1 and 12")
+	ErrVoucherAlreadyReversed      = errors.New("voucher is already reversed")
+	ErrInvalidCronExpression       = errors.New("cron expression should have five valid fields")
+	ErrInvalidFormula              = errors.New("formula is not a valid arithmetic expression over template variables")
+	ErrInvalidExportFormat         = errors.New("export format should be one of csv, xlsx or pdf")
+	ErrVoucherTemplateNotFound     = errors.New("voucher template not found")
+	ErrInvalidFrequency            = errors.New("frequency should be one of monthly, quarterly or cron")
+	ErrScheduleStartRequired       = errors.New("schedule start time is required")
+	ErrInvalidTemplateItemSide     = errors.New("template item side should be debit or credit")
+	ErrTemplateItemAmountInvalid   = errors.New("one and only one of amount or formula should be provided")
+	ErrCurrencyNotFound            = errors.New("currency not found")
+	ErrInvalidCurrencyCode         = errors.New("currency code should be three uppercase letters")
+	ErrInvalidExchangeRate         = errors.New("exchange rate should be a positive decimal number")
+	ErrExchangeRateNotFound        = errors.New("no exchange rate is defined for the currency on this date")
+	ErrCurrencyDetailsInvalid      = errors.New("foreign amounts and exchange rate require a currency code")
+	ErrFunctionalAmountMismatch    = errors.New("debit or credit does not match foreign amount times exchange rate")
+	ErrThereIsRefrenceToCurrency   = errors.New("there is refrence to this currency")
+	ErrNothingToRevalue            = errors.New("there is no foreign currency difference to revalue")
+	ErrAmountOverflow              = errors.New("amount is too large")
+	ErrInvalidAmount               = errors.New("amount is not a valid decimal number")
+	ErrInvalidMinorUnits           = errors.New("money minor units should be between 0 and 6")
+	ErrInvalidCalendar             = errors.New("calendar should be gregorian or jalali")
+	ErrInvalidDate                 = errors.New("date is not a valid day of the calendar")
+	ErrCodeFormatInvalid           = errors.New("code does not match the allowed format")
+	ErrTitleFormatInvalid          = errors.New("title does not match the allowed format")
+	ErrNumberFormatInvalid         = errors.New("number does not match the allowed format")
+	ErrInvalidTextRule             = errors.New("text rule configuration is invalid")
+	ErrInvalidCodeTemplate         = errors.New("code template configuration is invalid")
+	ErrCodeTemplateMismatch        = errors.New("code does not match the configured code template")
+	ErrCodeTemplateNotConfigured   = errors.New("no hierarchical code template is configured")
+	ErrParentCodeNotFound          = errors.New("parent code not found")
+	ErrCodeHasChildren             = errors.New("code has child codes")
+	ErrNoFreeCode                  = errors.New("no free code is left under the parent")
+	ErrForbidden                   = errors.New("operation is not permitted for this user")
+	ErrUnauthenticated             = errors.New("authentication token is missing or invalid")
+	ErrUserNotFound                = errors.New("user not found")
+	ErrUsernameEmptyOrTooLong      = errors.New("username cannot be empty or longer than 64 characters")
+	ErrUsernameAlreadyExists       = errors.New("username should be unique")
+	ErrInvalidRole                 = errors.New("role should be one of viewer, accountant, approver or admin")
+	ErrMalformedRequest            = errors.New("request is malformed")
+	ErrTenantNotFound              = errors.New("tenant not found")
+	ErrApprovalRuleNotFound        = errors.New("approval rule not found")
+	ErrApprovalConditionRequired   = errors.New("approval rule needs a minimum amount or an SL")
+	ErrMinAmountNotPositive        = errors.New("minimum amount should be positive")
+	ErrRequiredApprovalsInvalid    = errors.New("required approvals should be between 1 and 10")
+	ErrInvalidVoucherStatus        = errors.New("operation is not allowed in the voucher's status")
+	ErrSelfApproval                = errors.New("vouchers cannot be approved or rejected by the user who created, edited or submitted them")
+	ErrAlreadyApproved             = errors.New("user has already approved this voucher")
+	ErrCommentTooLong              = errors.New("comment cannot be longer than 512 characters")
+	ErrDeadlineExceeded            = errors.New("operation did not finish before its deadline")
+	ErrCanceled                    = errors.New("operation was canceled")
+	ErrInvalidTxIsolation          = errors.New("transaction isolation should be read-committed, repeatable-read or serializable")
+	ErrInvalidTxRetry              = errors.New("transaction retry count should be 0 to 10 and its delay at most 1s")
+	ErrTransactionConflict         = errors.New("operation conflicted with concurrent changes, try again")
+	ErrIdempotencyKeyTooLong       = errors.New("idempotency key cannot be longer than 128 characters")
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used for a different request")
+	ErrInvalidIdempotencyRetention = errors.New("idempotency retention should be a positive duration")
+	ErrWebhookNotFound             = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL           = errors.New("webhook URL should be an absolute http or https URL of at most 2048 characters")
+	ErrWebhookEventTypesEmpty      = errors.New("webhook should subscribe to at least one event type")
+	ErrUnknownEventType            = errors.New("unknown event type")
+	ErrInvalidWebhookSecret        = errors.New("webhook secret should be 16 to 128 characters")
+	ErrInvalidDeliveryStatus       = errors.New("delivery status should be pending, succeeded or failed")
+	ErrTooManyIDs                  = fmt.Errorf("cannot look up more than %d records at once", MaxLookupIDs)
+	ErrVoucherNumberReserved       = errors.New("voucher number is in the format of a gapless numbering sequence")
+	ErrForeignAmountRequired       = errors.New("a line in a foreign currency needs a foreign debit or credit")
+	ErrSLArchived                  = errors.New("SL is archived")
 )
@@ -0,0 +1,5 @@
+package constants
+
+// MaxLookupIDs is the most records a GetMany request, or a request for the
+// balances of several SLs, may look up at once.
+const MaxLookupIDs = 500
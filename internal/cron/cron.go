@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"accountingsystem/internal/constants"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a standard five field cron expression
+// (minute hour day-of-month month day-of-week) with support for
+// "*", lists, ranges and steps.
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	anyDay      bool
+	anyWeekday  bool
+}
+
+type field struct {
+	min int
+	max int
+}
+
+var fields = []field{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+func Parse(expression string) (*Schedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(fields) {
+		return nil, constants.ErrInvalidCronExpression
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday may be written as 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &Schedule{
+		minutes:     sets[0],
+		hours:       sets[1],
+		daysOfMonth: sets[2],
+		months:      sets[3],
+		daysOfWeek:  sets[4],
+		anyDay:      parts[2] == "*",
+		anyWeekday:  parts[4] == "*",
+	}, nil
+}
+
+func parseField(text string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(text, ",") {
+		rangeText, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			parsedStep, err := strconv.Atoi(item[i+1:])
+			if err != nil || parsedStep <= 0 {
+				return 0, constants.ErrInvalidCronExpression
+			}
+			rangeText, step = item[:i], parsedStep
+		}
+
+		low, high := f.min, f.max
+		if rangeText != "*" {
+			bounds := strings.SplitN(rangeText, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, constants.ErrInvalidCronExpression
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, constants.ErrInvalidCronExpression
+				}
+			} else if step > 1 {
+				high = f.max
+			}
+		}
+
+		maxAllowed := f.max
+		if f.max == 6 {
+			maxAllowed = 7
+		}
+		if low < f.min || high > maxAllowed || low > high {
+			return 0, constants.ErrInvalidCronExpression
+		}
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first activation strictly after the given time, or the
+// zero time when the expression never fires within the next five years.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayMatches := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	weekdayMatches := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekdayMatches
+	case s.anyWeekday:
+		return dayMatches
+	}
+	return dayMatches || weekdayMatches
+}
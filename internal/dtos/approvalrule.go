@@ -0,0 +1,11 @@
+package dtos
+
+import "accountingsystem/internal/money"
+
+type ApprovalRuleDto struct {
+	ID                int
+	MinAmount         money.Amount
+	SLID              int
+	RequiredApprovals int
+	RowVersion        int
+}
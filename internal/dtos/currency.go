@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+type CurrencyDto struct {
+	ID         int
+	Code       string
+	Title      string
+	RowVersion int
+}
+
+type ExchangeRateDto struct {
+	CurrencyCode string
+	Date         time.Time
+	DateText     string
+	Rate         string
+}
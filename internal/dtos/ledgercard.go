@@ -0,0 +1,39 @@
+package dtos
+
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type LedgerCardRowDto struct {
+	VoucherID       int
+	VoucherNumber   string
+	VoucherDate     time.Time
+	VoucherDateText string
+	VoucherItemID   int
+	Debit           money.Amount
+	Credit          money.Amount
+	Balance         money.Amount
+	CurrencyCode    string
+	ForeignDebit    money.Amount
+	ForeignCredit   money.Amount
+	ExchangeRate    string
+	ForeignBalance  money.Amount
+}
+
+type LedgerCardDto struct {
+	SLID               int
+	SLCode             string
+	SLTitle            string
+	DLID               int
+	DLCode             string
+	DLTitle            string
+	Rows               []LedgerCardRowDto
+	TotalDebit         money.Amount
+	TotalCredit        money.Amount
+	Balance            money.Amount
+	CurrencyCode       string
+	TotalForeignDebit  money.Amount
+	TotalForeignCredit money.Amount
+	ForeignBalance     money.Amount
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "accountingsystem/internal/calendar"
+
+type NumberSequenceDto struct {
+	ID                   int
+	Code                 string
+	Prefix               string
+	Padding              int
+	ResetYearly          bool
+	FiscalYearStartMonth int
+	Calendar             calendar.Calendar
+	Gapless              bool
+	RowVersion           int
+}
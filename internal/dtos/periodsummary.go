@@ -0,0 +1,35 @@
+package dtos
+
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type PeriodSummaryRowDto struct {
+	Year          int
+	Month         int
+	Label         string
+	StartDate     time.Time
+	EndDate       time.Time
+	StartDateText string
+	EndDateText   string
+	Debit         money.Amount
+	Credit        money.Amount
+	Balance       money.Amount
+}
+
+type PeriodSummaryDto struct {
+	SLID        int
+	SLCode      string
+	SLTitle     string
+	DLID        int
+	DLCode      string
+	DLTitle     string
+	Calendar    calendar.Calendar
+	FiscalYear  int
+	Rows        []PeriodSummaryRowDto
+	TotalDebit  money.Amount
+	TotalCredit money.Amount
+	Balance     money.Amount
+}
@@ -0,0 +1,26 @@
+package dtos
+
+import (
+	"accountingsystem/internal/money"
+	"encoding/json"
+)
+
+// PostingMessageDto is a message of the postings stream. Cursor is the ID of
+// the event the message belongs to. Payload is set for voucher events and
+// Balance for SLBalanceChanged messages. VoucherID is 0 for the balances sent
+// after the events a resumed stream replayed.
+type PostingMessageDto struct {
+	Cursor    int64
+	Type      string
+	VoucherID int
+	Payload   json.RawMessage
+	Balance   *SLBalanceDto
+}
+
+// SLBalanceDto is the running balance of an SL over its posted vouchers.
+type SLBalanceDto struct {
+	SLID        int
+	TotalDebit  money.Amount
+	TotalCredit money.Amount
+	Balance     money.Amount
+}
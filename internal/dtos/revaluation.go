@@ -0,0 +1,29 @@
+package dtos
+
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type RevaluationLineDto struct {
+	SLID            int
+	DLID            int
+	CurrencyCode    string
+	ForeignBalance  money.Amount
+	BookedBalance   money.Amount
+	ClosingRate     string
+	RevaluedBalance money.Amount
+	Difference      money.Amount
+}
+
+type RevaluationDto struct {
+	Date              time.Time
+	DateText          string
+	ReversalDate      time.Time
+	ReversalDateText  string
+	Lines             []RevaluationLineDto
+	TotalGain         money.Amount
+	TotalLoss         money.Amount
+	VoucherID         int
+	ReversalVoucherID int
+}
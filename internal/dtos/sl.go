@@ -5,5 +5,6 @@ type SLDto struct {
 	Code       string
 	Title      string
 	HasDL      bool
+	Archived   bool
 	RowVersion int
 }
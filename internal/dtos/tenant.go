@@ -0,0 +1,8 @@
+package dtos
+
+type TenantDto struct {
+	ID         int
+	Code       string
+	Title      string
+	RowVersion int
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type TextCollisionDto struct {
+	Table           string
+	Column          string
+	TenantID        int
+	NormalizedValue string
+	IDs             []int
+	Values          []string
+}
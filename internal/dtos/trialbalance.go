@@ -0,0 +1,26 @@
+package dtos
+
+import "accountingsystem/internal/money"
+
+type TrialBalanceRowDto struct {
+	SLID                 int
+	SLCode               string
+	SLTitle              string
+	TotalDebit           money.Amount
+	TotalCredit          money.Amount
+	DebitBalance         money.Amount
+	CreditBalance        money.Amount
+	CurrencyCode         string
+	TotalForeignDebit    money.Amount
+	TotalForeignCredit   money.Amount
+	ForeignDebitBalance  money.Amount
+	ForeignCreditBalance money.Amount
+}
+
+type TrialBalanceDto struct {
+	Rows               []TrialBalanceRowDto
+	TotalDebit         money.Amount
+	TotalCredit        money.Amount
+	TotalDebitBalance  money.Amount
+	TotalCreditBalance money.Amount
+}
@@ -0,0 +1,14 @@
+package dtos
+
+import "accountingsystem/internal/auth"
+
+type UserDto struct {
+	ID         int
+	Username   string
+	Role       auth.Role
+	Active     bool
+	RowVersion int
+	// Token is the bearer token of the user. It is only set when a token is
+	// issued, as only its hash is stored.
+	Token string
+}
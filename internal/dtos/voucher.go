@@ -1,7 +1,14 @@
 package dtos
 
+import "time"
+
 type VoucherDto struct {
-	ID         int
-	Number     string
-	RowVersion int
+	ID                int
+	Number            string
+	Date              time.Time
+	DateText          string
+	ReversalOfID      int
+	Status            string
+	RequiredApprovals int
+	RowVersion        int
 }
@@ -0,0 +1,21 @@
+package dtos
+
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type VoucherApprovalDto struct {
+	Round     int
+	Decision  string
+	Username  string
+	Comment   string
+	CreatedAt time.Time
+}
+
+type ApprovalQueueItemDto struct {
+	Voucher     VoucherDto
+	TotalDebit  money.Amount
+	SubmittedBy string
+	Approvals   int
+}
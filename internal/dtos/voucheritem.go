@@ -1,9 +1,15 @@
 package dtos
 
+import "accountingsystem/internal/money"
+
 type VoucherItemDto struct {
-	ID     int
-	SLID   int
-	DLID   int
-	Debit  int
-	Credit int
+	ID            int
+	SLID          int
+	DLID          int
+	Debit         money.Amount
+	Credit        money.Amount
+	CurrencyCode  string
+	ForeignDebit  money.Amount
+	ForeignCredit money.Amount
+	ExchangeRate  string
 }
@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type VoucherTemplateItemDto struct {
+	ID      int
+	SLID    int
+	DLID    int
+	Side    string
+	Amount  money.Amount
+	Formula string
+}
+
+type VoucherTemplateDto struct {
+	ID             int
+	Code           string
+	Title          string
+	NumberPrefix   string
+	Variables      map[string]money.Amount
+	Frequency      string
+	CronExpression string
+	StartsAt       time.Time
+	NextRunAt      time.Time
+	LastRunAt      *time.Time
+	Active         bool
+	RowVersion     int
+	Items          []VoucherTemplateItemDto
+}
@@ -1,8 +1,15 @@
 package dtos
 
+import "time"
+
 type VoucherWithItemsDto struct {
-	ID           int
-	Number       string
-	RowVersion   int
-	VoucherItems []VoucherItemDto
+	ID                int
+	Number            string
+	Date              time.Time
+	DateText          string
+	ReversalOfID      int
+	Status            string
+	RequiredApprovals int
+	RowVersion        int
+	VoucherItems      []VoucherItemDto
 }
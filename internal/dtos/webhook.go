@@ -0,0 +1,29 @@
+package dtos
+
+import "time"
+
+type WebhookSubscriptionDto struct {
+	ID         int
+	URL        string
+	EventTypes []string
+	Active     bool
+	RowVersion int
+	// Secret signs the payloads. It is only set when the subscription is
+	// created.
+	Secret string
+}
+
+type WebhookDeliveryDto struct {
+	ID             int64
+	SubscriptionID int
+	EventID        int64
+	EventType      string
+	ReplayOfID     int64
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    time.Time
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"accountingsystem/internal/dtos"
+	"encoding/json"
+	"time"
+)
+
+// Types of the domain events written to the outbox.
+const (
+	DLCreated        = "DLCreated"
+	DLUpdated        = "DLUpdated"
+	DLDeleted        = "DLDeleted"
+	SLCreated        = "SLCreated"
+	SLUpdated        = "SLUpdated"
+	SLDeleted        = "SLDeleted"
+	SLArchived       = "SLArchived"
+	VoucherCreated   = "VoucherCreated"
+	VoucherUpdated   = "VoucherUpdated"
+	VoucherDeleted   = "VoucherDeleted"
+	VoucherSubmitted = "VoucherSubmitted"
+	VoucherApproved  = "VoucherApproved"
+	VoucherRejected  = "VoucherRejected"
+	VoucherPosted    = "VoucherPosted"
+)
+
+// Types lists every event type.
+var Types = []string{
+	DLCreated, DLUpdated, DLDeleted,
+	SLCreated, SLUpdated, SLDeleted, SLArchived,
+	VoucherCreated, VoucherUpdated, VoucherDeleted,
+	VoucherSubmitted, VoucherApproved, VoucherRejected, VoucherPosted,
+}
+
+func IsKnownType(eventType string) bool {
+	for _, known := range Types {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Aggregates the events are about.
+const (
+	AggregateDL      = "dl"
+	AggregateSL      = "sl"
+	AggregateVoucher = "voucher"
+)
+
+// Event is a change to the ledger as delivered to sinks. Payload holds the
+// JSON of the payload type of the event: a DLDto or SLDto for DL and SL
+// events, a VoucherWithItemsDto for VoucherCreated and VoucherDeleted,
+// VoucherUpdatedPayload for VoucherUpdated, a VoucherDto for the other
+// voucher events. VoucherPosted is
+// raised whenever a voucher starts counting in the books.
+type Event struct {
+	ID            int64
+	TenantID      int
+	Type          string
+	AggregateType string
+	AggregateID   int
+	Payload       json.RawMessage
+	CreatedAt     time.Time
+}
+
+// VoucherUpdatedPayload describes a voucher after an update together with
+// what happened to its lines.
+type VoucherUpdatedPayload struct {
+	Voucher       dtos.VoucherDto
+	InsertedItems []dtos.VoucherItemDto
+	UpdatedItems  []VoucherItemChange
+	DeletedItems  []dtos.VoucherItemDto
+}
+
+type VoucherItemChange struct {
+	Before dtos.VoucherItemDto
+	After  dtos.VoucherItemDto
+}
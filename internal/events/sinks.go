@@ -0,0 +1,111 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sink delivers an event to a downstream system. It may be called again with
+// an event it already received, since delivery is at least once, and should
+// return an error when the event has to be delivered again later.
+type Sink func(ctx context.Context, event Event) error
+
+// WebhookSink posts every event as JSON to url, signed with secret like the
+// requests to webhook subscriptions, and treats any status other than 2xx as
+// a failed delivery.
+func WebhookSink(url string, secret string, client *http.Client) Sink {
+	return func(ctx context.Context, event Event) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		timestamp := time.Now().Unix()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", fmt.Sprintf("event-%d", event.ID))
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(EventTypeHeader, event.Type)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook %s answered %s", url, resp.Status)
+		}
+		return nil
+	}
+}
+
+// FileSink appends every event to the file at path as a line of JSON.
+func FileSink(path string) Sink {
+	var mu sync.Mutex
+	return func(ctx context.Context, event Event) error {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+}
+
+// Subscribers hands events to functions of this process, registered for the
+// event types they care about.
+type Subscribers struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(ctx context.Context, event Event) error
+}
+
+// Subscribe registers handle for the given event types, or for every event
+// when no type is given.
+func (s *Subscribers) Subscribe(handle func(ctx context.Context, event Event) error, eventTypes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.handlers == nil {
+		s.handlers = make(map[string][]func(ctx context.Context, event Event) error)
+	}
+	if len(eventTypes) == 0 {
+		eventTypes = []string{""}
+	}
+	for _, eventType := range eventTypes {
+		s.handlers[eventType] = append(s.handlers[eventType], handle)
+	}
+}
+
+// Sink returns the sink that calls the subscribers of each event. The event
+// is delivered again when any of them fails.
+func (s *Subscribers) Sink() Sink {
+	return func(ctx context.Context, event Event) error {
+		s.mu.RLock()
+		handlers := append(append([]func(ctx context.Context, event Event) error(nil), s.handlers[""]...), s.handlers[event.Type]...)
+		s.mu.RUnlock()
+		for _, handle := range handlers {
+			if err := handle(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, doc Document) (*csvWriter, error) {
+	c := &csvWriter{writer: csv.NewWriter(w)}
+	for _, line := range preamble(doc) {
+		if err := c.writer.Write([]string{line}); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.writer.Write(doc.Columns); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *csvWriter) writeRow(cells []Cell) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = cell.Text
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
@@ -0,0 +1,356 @@
+package export
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"sync"
+)
+
+//go:embed fonts/DejaVuSans.ttf
+var dejaVuSans []byte
+
+var errInvalidFont = errors.New("invalid TrueType font")
+
+// loadFont parses the embedded font once; PDF writers only read it.
+var loadFont = sync.OnceValues(func() (*trueTypeFont, error) {
+	return parseTrueType(dejaVuSans)
+})
+
+// subsetTables are the tables a PDF viewer needs to draw the glyphs of an
+// embedded CIDFontType2 font. Glyphs are selected by ID, so cmap is dropped.
+var subsetTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+type trueTypeFont struct {
+	tables     map[string][]byte
+	unitsPerEm int
+	numGlyphs  int
+	widths     []int
+	glyphs     map[rune]uint16
+	bbox       [4]int
+	ascent     int
+	descent    int
+	capHeight  int
+}
+
+func parseTrueType(data []byte) (*trueTypeFont, error) {
+	tables, err := readTables(data)
+	if err != nil {
+		return nil, err
+	}
+	f := &trueTypeFont{tables: tables, glyphs: map[rune]uint16{}}
+
+	head, hhea, maxp := f.tables["head"], f.tables["hhea"], f.tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 || f.tables["glyf"] == nil || f.tables["loca"] == nil {
+		return nil, errInvalidFont
+	}
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	for i := range f.bbox {
+		f.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+	f.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	f.capHeight = f.ascent
+	if os2 := f.tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		f.capHeight = int(int16(binary.BigEndian.Uint16(os2[88:])))
+	}
+	f.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+	if f.unitsPerEm == 0 || f.numGlyphs == 0 {
+		return nil, errInvalidFont
+	}
+
+	if err := f.parseWidths(int(binary.BigEndian.Uint16(hhea[34:]))); err != nil {
+		return nil, err
+	}
+	if err := f.parseCmap(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func readTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, errInvalidFont
+	}
+	tables := map[string][]byte{}
+	for i := 0; i < int(binary.BigEndian.Uint16(data[4:])); i++ {
+		record := 12 + 16*i
+		if record+16 > len(data) {
+			return nil, errInvalidFont
+		}
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset+length > len(data) {
+			return nil, errInvalidFont
+		}
+		tables[string(data[record:record+4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+func (f *trueTypeFont) parseWidths(numberOfHMetrics int) error {
+	hmtx := f.tables["hmtx"]
+	if numberOfHMetrics == 0 || len(hmtx) < 4*numberOfHMetrics {
+		return errInvalidFont
+	}
+	f.widths = make([]int, f.numGlyphs)
+	for gid := range f.widths {
+		metric := gid
+		if metric >= numberOfHMetrics {
+			metric = numberOfHMetrics - 1
+		}
+		f.widths[gid] = int(binary.BigEndian.Uint16(hmtx[4*metric:]))
+	}
+	return nil
+}
+
+// parseCmap reads the Windows Unicode subtable, preferring the full
+// repertoire (format 12) over the BMP one (format 4).
+func (f *trueTypeFont) parseCmap() error {
+	cmap := f.tables["cmap"]
+	if len(cmap) < 4 {
+		return errInvalidFont
+	}
+	var bmp, full []byte
+	for i := 0; i < int(binary.BigEndian.Uint16(cmap[2:])); i++ {
+		record := 4 + 8*i
+		if record+8 > len(cmap) {
+			return errInvalidFont
+		}
+		platform, encoding := binary.BigEndian.Uint16(cmap[record:]), binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if platform != 3 || offset+4 > len(cmap) {
+			continue
+		}
+		switch {
+		case encoding == 1 && binary.BigEndian.Uint16(cmap[offset:]) == 4:
+			bmp = cmap[offset:]
+		case encoding == 10 && binary.BigEndian.Uint16(cmap[offset:]) == 12:
+			full = cmap[offset:]
+		}
+	}
+	switch {
+	case full != nil:
+		return f.parseCmapFormat12(full)
+	case bmp != nil:
+		return f.parseCmapFormat4(bmp)
+	}
+	return errInvalidFont
+}
+
+func (f *trueTypeFont) parseCmapFormat4(table []byte) error {
+	if len(table) < 14 {
+		return errInvalidFont
+	}
+	segCount := int(binary.BigEndian.Uint16(table[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + 2*segCount + 2
+	idDeltas := startCodes + 2*segCount
+	idRangeOffsets := idDeltas + 2*segCount
+	if idRangeOffsets+2*segCount > len(table) {
+		return errInvalidFont
+	}
+	for i := 0; i < segCount; i++ {
+		end := int(binary.BigEndian.Uint16(table[endCodes+2*i:]))
+		start := int(binary.BigEndian.Uint16(table[startCodes+2*i:]))
+		delta := int(binary.BigEndian.Uint16(table[idDeltas+2*i:]))
+		rangeOffset := int(binary.BigEndian.Uint16(table[idRangeOffsets+2*i:]))
+		for c := start; c <= end && c != 0xffff; c++ {
+			gid := (c + delta) & 0xffff
+			if rangeOffset != 0 {
+				address := idRangeOffsets + 2*i + rangeOffset + 2*(c-start)
+				if address+2 > len(table) {
+					return errInvalidFont
+				}
+				if gid = int(binary.BigEndian.Uint16(table[address:])); gid != 0 {
+					gid = (gid + delta) & 0xffff
+				}
+			}
+			if gid != 0 && gid < f.numGlyphs {
+				f.glyphs[rune(c)] = uint16(gid)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *trueTypeFont) parseCmapFormat12(table []byte) error {
+	if len(table) < 16 {
+		return errInvalidFont
+	}
+	groups := int(binary.BigEndian.Uint32(table[12:]))
+	if 16+12*groups > len(table) {
+		return errInvalidFont
+	}
+	for i := 0; i < groups; i++ {
+		group := table[16+12*i:]
+		start, end, gid := binary.BigEndian.Uint32(group), binary.BigEndian.Uint32(group[4:]), binary.BigEndian.Uint32(group[8:])
+		for c := start; c <= end && c <= 0x10ffff; c++ {
+			if int(gid) < f.numGlyphs {
+				f.glyphs[rune(c)] = uint16(gid)
+			}
+			gid++
+		}
+	}
+	return nil
+}
+
+func (f *trueTypeFont) glyphID(r rune) (uint16, bool) {
+	gid, ok := f.glyphs[r]
+	return gid, ok
+}
+
+// advance returns the advance width of a glyph in 1/1000 em.
+func (f *trueTypeFont) advance(gid uint16) int {
+	return f.widths[gid] * 1000 / f.unitsPerEm
+}
+
+func (f *trueTypeFont) scale(value int) int {
+	return value * 1000 / f.unitsPerEm
+}
+
+// glyph returns the outline of a glyph, which is empty for blank glyphs.
+func (f *trueTypeFont) glyph(gid int) []byte {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	var start, end int
+	if binary.BigEndian.Uint16(f.tables["head"][50:]) == 0 {
+		if 2*gid+4 > len(loca) {
+			return nil
+		}
+		start, end = 2*int(binary.BigEndian.Uint16(loca[2*gid:])), 2*int(binary.BigEndian.Uint16(loca[2*gid+2:]))
+	} else {
+		if 4*gid+8 > len(loca) {
+			return nil
+		}
+		start, end = int(binary.BigEndian.Uint32(loca[4*gid:])), int(binary.BigEndian.Uint32(loca[4*gid+4:]))
+	}
+	if start >= end || end > len(glyf) {
+		return nil
+	}
+	return glyf[start:end]
+}
+
+// components returns the glyphs a composite glyph is built from.
+func (f *trueTypeFont) components(gid int) []int {
+	outline := f.glyph(gid)
+	if len(outline) < 10 || int16(binary.BigEndian.Uint16(outline)) >= 0 {
+		return nil
+	}
+	var components []int
+	for offset := 10; offset+4 <= len(outline); {
+		flags := binary.BigEndian.Uint16(outline[offset:])
+		components = append(components, int(binary.BigEndian.Uint16(outline[offset+2:])))
+		offset += 4
+		if flags&0x0001 != 0 {
+			offset += 4
+		} else {
+			offset += 2
+		}
+		switch {
+		case flags&0x0008 != 0:
+			offset += 2
+		case flags&0x0040 != 0:
+			offset += 4
+		case flags&0x0080 != 0:
+			offset += 8
+		}
+		if flags&0x0020 == 0 {
+			break
+		}
+	}
+	return components
+}
+
+// subset returns a font program with the outlines of the given glyphs only.
+// Glyph IDs are kept, so the content streams can refer to them unchanged.
+func (f *trueTypeFont) subset(used []uint16) []byte {
+	keep := map[int]bool{}
+	pending := []int{0}
+	for _, gid := range used {
+		pending = append(pending, int(gid))
+	}
+	for len(pending) > 0 {
+		gid := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if gid >= f.numGlyphs || keep[gid] {
+			continue
+		}
+		keep[gid] = true
+		pending = append(pending, f.components(gid)...)
+	}
+
+	var glyf bytes.Buffer
+	loca := make([]byte, 4*(f.numGlyphs+1))
+	for gid := 0; gid < f.numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(loca[4*gid:], uint32(glyf.Len()))
+		if keep[gid] {
+			glyf.Write(f.glyph(gid))
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[4*f.numGlyphs:], uint32(glyf.Len()))
+
+	head := append([]byte{}, f.tables["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0)
+	binary.BigEndian.PutUint16(head[50:], 1)
+
+	tables := map[string][]byte{"glyf": glyf.Bytes(), "loca": loca, "head": head}
+	for _, tag := range subsetTables {
+		if _, ok := tables[tag]; !ok && f.tables[tag] != nil {
+			tables[tag] = f.tables[tag]
+		}
+	}
+	return assembleTrueType(tables)
+}
+
+func assembleTrueType(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= len(tags) {
+		searchRange *= 2
+		entrySelector++
+	}
+	var out bytes.Buffer
+	binary.Write(&out, binary.BigEndian, []uint32{0x00010000})
+	binary.Write(&out, binary.BigEndian, []uint16{uint16(len(tags)), uint16(16 * searchRange), uint16(entrySelector), uint16(16 * (len(tags) - searchRange))})
+
+	offset := 12 + 16*len(tags)
+	for _, tag := range tags {
+		out.WriteString(tag)
+		binary.Write(&out, binary.BigEndian, []uint32{tableChecksum(tables[tag]), uint32(offset), uint32(len(tables[tag]))})
+		offset += (len(tables[tag]) + 3) &^ 3
+	}
+	headOffset := 0
+	for _, tag := range tags {
+		if tag == "head" {
+			headOffset = out.Len()
+		}
+		out.Write(tables[tag])
+		for out.Len()%4 != 0 {
+			out.WriteByte(0)
+		}
+	}
+
+	font := out.Bytes()
+	binary.BigEndian.PutUint32(font[headOffset+8:], 0xb1b0afba-tableChecksum(font))
+	return font
+}
+
+func tableChecksum(table []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(table); i += 4 {
+		var word [4]byte
+		copy(word[:], table[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
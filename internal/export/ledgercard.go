@@ -0,0 +1,70 @@
+package export
+
+import (
+	"accountingsystem/internal/dtos"
+	"io"
+)
+
+type LedgerCardWriter struct {
+	writer       *Writer
+	withCurrency bool
+}
+
+func NewLedgerCardWriter(w io.Writer, format Format, card *dtos.LedgerCardDto) (*LedgerCardWriter, error) {
+	headings := []string{"SL: " + card.SLCode + " - " + card.SLTitle}
+	if card.DLID != 0 {
+		headings = append(headings, "DL: "+card.DLCode+" - "+card.DLTitle)
+	}
+
+	columns := []string{"Voucher ID", "Voucher Number", "Date", "Item ID", "Debit", "Credit", "Balance"}
+	withCurrency := card.CurrencyCode != ""
+	if withCurrency {
+		headings = append(headings, "Currency: "+card.CurrencyCode)
+		columns = append(columns, "Rate", "Foreign Debit", "Foreign Credit", "Foreign Balance")
+	}
+
+	writer, err := NewWriter(w, format, Document{
+		Title:    "Ledger Card",
+		Headings: headings,
+		Columns:  columns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &LedgerCardWriter{writer: writer, withCurrency: withCurrency}, nil
+}
+
+func (l *LedgerCardWriter) WriteRow(row dtos.LedgerCardRowDto) error {
+	cells := []Cell{
+		Number(row.VoucherID),
+		Text(row.VoucherNumber),
+		Text(row.VoucherDateText),
+		Number(row.VoucherItemID),
+		Amount(row.Debit),
+		Amount(row.Credit),
+		Amount(row.Balance),
+	}
+	if l.withCurrency {
+		cells = append(cells, Text(row.ExchangeRate), Amount(row.ForeignDebit), Amount(row.ForeignCredit), Amount(row.ForeignBalance))
+	}
+	return l.writer.WriteRow(cells...)
+}
+
+func (l *LedgerCardWriter) Close(totals *dtos.LedgerCardDto) error {
+	cells := []Cell{
+		Text("Total"),
+		Text(""),
+		Text(""),
+		Text(""),
+		Amount(totals.TotalDebit),
+		Amount(totals.TotalCredit),
+		Amount(totals.Balance),
+	}
+	if l.withCurrency {
+		cells = append(cells, Text(""), Amount(totals.TotalForeignDebit), Amount(totals.TotalForeignCredit), Amount(totals.ForeignBalance))
+	}
+	if err := l.writer.WriteTotals(cells...); err != nil {
+		return err
+	}
+	return l.writer.Close()
+}
@@ -0,0 +1,384 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	pdfPageWidth    = 595.0
+	pdfPageHeight   = 842.0
+	pdfMargin       = 40.0
+	pdfFooterHeight = 20.0
+	pdfFontSize     = 9.0
+	pdfRowHeight    = 14.0
+	pdfCellPadding  = 2.0
+	pdfSignatureGap = 70.0
+	pdfCatalogID    = 1
+	pdfPagesID      = 2
+	pdfFontID       = 3
+)
+
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+	err     error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.writer.Write(p)
+	c.written += int64(n)
+	c.err = err
+	return n, err
+}
+
+// pdfWriter renders an A4 table layout with an embedded Unicode font, so
+// Persian text prints as it reads. Only the page being drawn is kept in
+// memory; finished pages are flushed as soon as they are full. The font is
+// written last, with the outlines of the glyphs the pages used.
+type pdfWriter struct {
+	out        *countingWriter
+	doc        Document
+	font       *trueTypeFont
+	used       map[uint16]rune
+	offsets    map[int]int64
+	nextID     int
+	pageIDs    []int
+	widths     []float64
+	page       bytes.Buffer
+	pageNumber int
+	y          float64
+}
+
+func newPDFWriter(w io.Writer, doc Document) (*pdfWriter, error) {
+	font, err := loadFont()
+	if err != nil {
+		return nil, err
+	}
+	p := &pdfWriter{
+		out:     &countingWriter{writer: w},
+		doc:     doc,
+		font:    font,
+		used:    map[uint16]rune{},
+		offsets: map[int]int64{},
+		nextID:  pdfFontID + 1,
+		widths:  columnWidths(len(doc.Columns)),
+	}
+
+	io.WriteString(p.out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	if p.out.err != nil {
+		return nil, p.out.err
+	}
+
+	p.startPage(true)
+	return p, nil
+}
+
+func columnWidths(count int) []float64 {
+	widths := make([]float64, count)
+	for i := range widths {
+		widths[i] = (pdfPageWidth - 2*pdfMargin) / float64(count)
+	}
+	return widths
+}
+
+func (p *pdfWriter) writeObject(id int, body string) {
+	p.offsets[id] = p.out.written
+	fmt.Fprintf(p.out, "%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (p *pdfWriter) allocateID() int {
+	id := p.nextID
+	p.nextID++
+	return id
+}
+
+func (p *pdfWriter) startPage(withTableHeader bool) {
+	p.pageNumber++
+	p.page.Reset()
+	p.y = pdfPageHeight - pdfMargin
+
+	if p.pageNumber == 1 {
+		p.drawText(pdfMargin, p.y-14, p.doc.Title, 14, true)
+		p.y -= 24
+		for _, heading := range p.doc.Headings {
+			p.drawText(pdfMargin, p.y-10, heading, 10, false)
+			p.y -= 14
+		}
+		p.y -= 8
+	}
+
+	if withTableHeader {
+		header := make([]Cell, len(p.doc.Columns))
+		for i, column := range p.doc.Columns {
+			header[i] = Text(column)
+		}
+		p.drawRow(header, true)
+		p.drawLine(pdfMargin, p.y+3, pdfPageWidth-pdfMargin, p.y+3)
+	}
+}
+
+func (p *pdfWriter) finishPage() {
+	footer := "Page " + strconv.Itoa(p.pageNumber)
+	p.drawText((pdfPageWidth-p.textWidth(footer, 8))/2, pdfMargin/2, footer, 8, false)
+
+	contentID := p.allocateID()
+	pageID := p.allocateID()
+	p.writeStream(contentID, "", p.page.Bytes())
+	p.writeObject(pageID, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesID, pdfPageWidth, pdfPageHeight, pdfFontID, contentID))
+	p.pageIDs = append(p.pageIDs, pageID)
+}
+
+func (p *pdfWriter) writeRow(cells []Cell, isTotal bool) error {
+	if p.y-pdfRowHeight < pdfMargin+pdfFooterHeight {
+		p.finishPage()
+		p.startPage(true)
+	}
+	if isTotal {
+		p.drawLine(pdfMargin, p.y+3, pdfPageWidth-pdfMargin, p.y+3)
+	}
+	p.drawRow(cells, isTotal)
+	return p.out.err
+}
+
+func (p *pdfWriter) drawRow(cells []Cell, bold bool) {
+	x := pdfMargin
+	baseline := p.y - pdfRowHeight + 4
+	for i, cell := range cells {
+		if i >= len(p.widths) {
+			break
+		}
+		text := cell.Text
+		if cell.IsNumber {
+			text = groupDigits(cell.Text)
+		}
+		available := p.widths[i] - 2*pdfCellPadding
+		text = p.fitText(text, available, pdfFontSize)
+		if cell.IsNumber {
+			p.drawText(x+p.widths[i]-pdfCellPadding-p.textWidth(text, pdfFontSize), baseline, text, pdfFontSize, bold)
+		} else {
+			p.drawText(x+pdfCellPadding, baseline, text, pdfFontSize, bold)
+		}
+		x += p.widths[i]
+	}
+	p.y -= pdfRowHeight
+}
+
+func (p *pdfWriter) drawSignatures() {
+	if len(p.doc.Signatures) == 0 {
+		return
+	}
+	if p.y-pdfSignatureGap < pdfMargin+pdfFooterHeight {
+		p.finishPage()
+		p.startPage(false)
+	}
+	boxWidth := (pdfPageWidth - 2*pdfMargin) / float64(len(p.doc.Signatures))
+	lineY := p.y - pdfSignatureGap + 20
+	for i, label := range p.doc.Signatures {
+		x := pdfMargin + float64(i)*boxWidth
+		p.drawLine(x+10, lineY, x+boxWidth-10, lineY)
+		p.drawText(x+10, lineY-12, p.fitText(label, boxWidth-20, pdfFontSize), pdfFontSize, false)
+	}
+	p.y -= pdfSignatureGap
+}
+
+// drawText shows text with the glyphs of the embedded font. Bold text is
+// stroked as well as filled, since only the regular face is embedded.
+func (p *pdfWriter) drawText(x float64, y float64, text string, size float64, bold bool) {
+	if bold {
+		fmt.Fprintf(&p.page, "BT /F1 %g Tf 2 Tr %.2f w %.2f %.2f Td <%s> Tj ET\n", size, size/30, x, y, p.glyphString(text))
+	} else {
+		fmt.Fprintf(&p.page, "BT /F1 %g Tf 0 Tr %.2f %.2f Td <%s> Tj ET\n", size, x, y, p.glyphString(text))
+	}
+}
+
+func (p *pdfWriter) drawLine(x1 float64, y1 float64, x2 float64, y2 float64) {
+	fmt.Fprintf(&p.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+func (p *pdfWriter) close() error {
+	p.drawSignatures()
+	p.finishPage()
+	if err := p.writeFont(); err != nil {
+		return err
+	}
+
+	kids := make([]string, len(p.pageIDs))
+	for i, id := range p.pageIDs {
+		kids[i] = strconv.Itoa(id) + " 0 R"
+	}
+	p.writeObject(pdfPagesID, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pageIDs)))
+	p.writeObject(pdfCatalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesID))
+
+	xrefOffset := p.out.written
+	fmt.Fprintf(p.out, "xref\n0 %d\n0000000000 65535 f \n", p.nextID)
+	for id := 1; id < p.nextID; id++ {
+		fmt.Fprintf(p.out, "%010d 00000 n \n", p.offsets[id])
+	}
+	fmt.Fprintf(p.out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.nextID, pdfCatalogID, xrefOffset)
+	return p.out.err
+}
+
+// writeFont embeds the subset of the font with the glyphs the pages used, as
+// a CIDFontType2 font whose character codes are the glyph IDs.
+func (p *pdfWriter) writeFont() error {
+	gids := make([]uint16, 0, len(p.used))
+	for gid := range p.used {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+
+	program := p.font.subset(gids)
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(program)
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	name := subsetTag(gids) + "+DejaVuSans"
+	cidFontID, descriptorID, fileID, toUnicodeID := p.allocateID(), p.allocateID(), p.allocateID(), p.allocateID()
+
+	p.writeObject(pdfFontID, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		name, cidFontID, toUnicodeID))
+
+	var widths strings.Builder
+	for _, gid := range gids {
+		fmt.Fprintf(&widths, "%d [%d] ", gid, p.font.advance(gid))
+	}
+	p.writeObject(cidFontID, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor %d 0 R /DW %d /W [%s] /CIDToGIDMap /Identity >>",
+		name, descriptorID, p.font.advance(0), strings.TrimSpace(widths.String())))
+
+	bbox := p.font.bbox
+	p.writeObject(descriptorID, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, p.font.scale(bbox[0]), p.font.scale(bbox[1]), p.font.scale(bbox[2]), p.font.scale(bbox[3]),
+		p.font.scale(p.font.ascent), p.font.scale(p.font.descent), p.font.scale(p.font.capHeight), fileID))
+
+	p.writeStream(fileID, fmt.Sprintf("/Length1 %d /Filter /FlateDecode", len(program)), compressed.Bytes())
+	p.writeStream(toUnicodeID, "", p.toUnicode(gids))
+	return p.out.err
+}
+
+func (p *pdfWriter) writeStream(id int, dict string, data []byte) {
+	if dict != "" {
+		dict = " " + dict
+	}
+	p.offsets[id] = p.out.written
+	fmt.Fprintf(p.out, "%d 0 obj\n<< /Length %d%s >>\nstream\n", id, len(data), dict)
+	p.out.Write(data)
+	io.WriteString(p.out, "\nendstream\nendobj\n")
+}
+
+// toUnicode maps the glyphs back to text for copying and searching.
+// Presentation forms map to the letters they were shaped from.
+func (p *pdfWriter) toUnicode(gids []uint16) []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(gids); start += 100 {
+		batch := gids[start:min(start+100, len(gids))]
+		fmt.Fprintf(&b, "%d beginbfchar\n", len(batch))
+		for _, gid := range batch {
+			fmt.Fprintf(&b, "<%04X> <", gid)
+			for _, unit := range utf16.Encode([]rune(norm.NFKC.String(string(p.used[gid])))) {
+				fmt.Fprintf(&b, "%04X", unit)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend")
+	return b.Bytes()
+}
+
+// subsetTag names a font subset after the glyphs in it, as six capital
+// letters.
+func subsetTag(gids []uint16) string {
+	hash := crc32.NewIEEE()
+	for _, gid := range gids {
+		hash.Write([]byte{byte(gid >> 8), byte(gid)})
+	}
+	sum := hash.Sum32()
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = byte('A' + sum%26)
+		sum /= 26
+	}
+	return string(tag)
+}
+
+// glyphString encodes text as the hex glyph IDs of the embedded font, in the
+// order they are drawn. Characters the font lacks are drawn as its missing
+// glyph box.
+func (p *pdfWriter) glyphString(text string) string {
+	var b strings.Builder
+	for _, r := range shapeText(text) {
+		gid, ok := p.font.glyphID(r)
+		if ok {
+			if _, seen := p.used[gid]; !seen {
+				p.used[gid] = r
+			}
+		}
+		fmt.Fprintf(&b, "%04X", gid)
+	}
+	return b.String()
+}
+
+func (p *pdfWriter) textWidth(text string, size float64) float64 {
+	total := 0
+	for _, r := range shapeText(text) {
+		gid, _ := p.font.glyphID(r)
+		total += p.font.advance(gid)
+	}
+	return float64(total) * size / 1000
+}
+
+func (p *pdfWriter) fitText(text string, maxWidth float64, size float64) string {
+	if p.textWidth(text, size) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && p.textWidth(string(runes)+"...", size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func groupDigits(number string) string {
+	digits, fraction, hasFraction := strings.Cut(number, ".")
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if hasFraction {
+		fraction = "." + fraction
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String() + fraction
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	pdfObjectPattern    = regexp.MustCompile(`(?s)(\d+) 0 obj\n(<<.*?>>)\n(?:stream\n(.*?)\nendstream\n)?endobj\n`)
+	pdfShowPattern      = regexp.MustCompile(`<([0-9A-F]*)> Tj`)
+	pdfBfCharPattern    = regexp.MustCompile(`<([0-9A-F]{4})> <([0-9A-F]+)>`)
+	pdfStartXrefPattern = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+)
+
+type pdfObject struct {
+	dict   string
+	stream []byte
+}
+
+// readPDF checks the cross-reference table and returns the objects by ID.
+func readPDF(t *testing.T, content []byte) map[int]pdfObject {
+	objects := map[int]pdfObject{}
+	for _, match := range pdfObjectPattern.FindAllSubmatchIndex(content, -1) {
+		id, err := strconv.Atoi(string(content[match[2]:match[3]]))
+		require.Nil(t, err)
+		object := pdfObject{dict: string(content[match[4]:match[5]])}
+		if match[6] >= 0 {
+			object.stream = content[match[6]:match[7]]
+		}
+		objects[id] = object
+	}
+
+	startXref := pdfStartXrefPattern.FindSubmatch(content)
+	require.NotNil(t, startXref)
+	xrefOffset, err := strconv.Atoi(string(startXref[1]))
+	require.Nil(t, err)
+	xref := strings.Split(string(content[xrefOffset:]), "\n")
+	require.Equal(t, "xref", xref[0])
+	for id := 1; id < len(objects)+1; id++ {
+		offset, err := strconv.Atoi(xref[2+id][:10])
+		require.Nil(t, err)
+		assert.True(t, bytes.HasPrefix(content[offset:], []byte(strconv.Itoa(id)+" 0 obj\n")), "object %d", id)
+	}
+	return objects
+}
+
+func findPDFObject(objects map[int]pdfObject, marker string) pdfObject {
+	for _, object := range objects {
+		if strings.Contains(object.dict, marker) {
+			return object
+		}
+	}
+	return pdfObject{}
+}
+
+// extractPDFText decodes the shown strings of every page with the
+// ToUnicode map of the font, in the order they are drawn.
+func extractPDFText(t *testing.T, objects map[int]pdfObject) []string {
+	toUnicode := map[string]string{}
+	var cmap []byte
+	for _, object := range objects {
+		if bytes.Contains(object.stream, []byte("beginbfchar")) {
+			cmap = object.stream
+		}
+	}
+	for _, match := range pdfBfCharPattern.FindAllSubmatch(cmap, -1) {
+		units, err := hex.DecodeString(string(match[2]))
+		require.Nil(t, err)
+		var text []rune
+		for i := 0; i+1 < len(units); i += 2 {
+			text = append(text, rune(units[i])<<8|rune(units[i+1]))
+		}
+		toUnicode[string(match[1])] = string(text)
+	}
+
+	var texts []string
+	for id := 1; id <= len(objects); id++ {
+		if !strings.Contains(objects[id].dict, "/Type /Page ") {
+			continue
+		}
+		contentID, err := strconv.Atoi(regexp.MustCompile(`/Contents (\d+) 0 R`).FindStringSubmatch(objects[id].dict)[1])
+		require.Nil(t, err)
+		for _, match := range pdfShowPattern.FindAllSubmatch(objects[contentID].stream, -1) {
+			var text strings.Builder
+			for i := 0; i+4 <= len(match[1]); i += 4 {
+				text.WriteString(toUnicode[string(match[1][i:i+4])])
+			}
+			texts = append(texts, text.String())
+		}
+	}
+	return texts
+}
+
+func reversed(text string) string {
+	runes := []rune(text)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func Test_PDFWriter_WritesValidCrossReferences(t *testing.T) {
+	content := writeTestTable(t, FormatPDF)
+
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	objects := readPDF(t, content)
+	assert.Contains(t, findPDFObject(objects, "/Type /Catalog").dict, "/Pages 2 0 R")
+	assert.Contains(t, findPDFObject(objects, "/Type /Pages").dict, "/Count 1")
+}
+
+func Test_PDFWriter_PrintsPersianText_WithEmbeddedFont(t *testing.T) {
+	objects := readPDF(t, writeTestTable(t, FormatPDF))
+
+	assert.Contains(t, findPDFObject(objects, "/Subtype /Type0").dict, "/Encoding /Identity-H")
+	assert.Contains(t, findPDFObject(objects, "/Subtype /CIDFontType2").dict, "/CIDToGIDMap /Identity")
+	assert.Contains(t, findPDFObject(objects, "/Type /FontDescriptor").dict, "/FontFile2")
+	texts := extractPDFText(t, objects)
+	assert.Equal(t, reversed("سند حسابداری"), texts[0])
+	assert.Equal(t, "Number: 1001", texts[1])
+	assert.Contains(t, texts, reversed("صندوق"))
+	assert.Contains(t, texts, "125,075")
+	assert.Contains(t, texts, "Page 1")
+}
+
+func Test_PDFWriter_DrawsNoMissingGlyphs_WithPersianText(t *testing.T) {
+	objects := readPDF(t, writeTestTable(t, FormatPDF))
+	contents := findPDFObject(objects, "/Type /Page ").dict
+	contentID, err := strconv.Atoi(regexp.MustCompile(`/Contents (\d+) 0 R`).FindStringSubmatch(contents)[1])
+	require.Nil(t, err)
+
+	for _, match := range pdfShowPattern.FindAllSubmatch(objects[contentID].stream, -1) {
+		for i := 0; i+4 <= len(match[1]); i += 4 {
+			assert.NotEqual(t, "0000", string(match[1][i:i+4]))
+		}
+	}
+}
+
+func Test_PDFWriter_EmbedsOnlyUsedGlyphs(t *testing.T) {
+	font, err := loadFont()
+	require.Nil(t, err)
+	objects := readPDF(t, writeTestTable(t, FormatPDF))
+
+	reader, err := zlib.NewReader(bytes.NewReader(findPDFObject(objects, "/Length1").stream))
+	require.Nil(t, err)
+	program, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	subset := &trueTypeFont{}
+	subset.tables, err = readTables(program)
+	require.Nil(t, err)
+
+	used, _ := font.glyphID('N')
+	unused, _ := font.glyphID('Z')
+	assert.Equal(t, font.glyph(int(used)), subset.glyph(int(used)))
+	assert.NotEmpty(t, subset.glyph(int(used)))
+	assert.Empty(t, subset.glyph(int(unused)))
+	assert.Less(t, len(program), len(dejaVuSans)/10)
+}
+
+func Test_PDFWriter_StartsNewPages_WithManyRows(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := NewWriter(&output, FormatPDF, testDocument)
+	require.Nil(t, err)
+	for i := 0; i < 120; i++ {
+		require.Nil(t, writer.WriteRow(Number(i), Text("ردیف"), Amount(100)))
+	}
+	require.Nil(t, writer.Close())
+
+	objects := readPDF(t, output.Bytes())
+
+	assert.Contains(t, findPDFObject(objects, "/Type /Pages").dict, "/Count 3")
+	assert.Contains(t, extractPDFText(t, objects), "Page 3")
+}
+
+func Test_JoinArabic_PicksContextualForms(t *testing.T) {
+	tests := map[string][]rune{
+		"کتاب":        {0xfb90, 0xfe98, 0xfe8e, 0xfe8f},
+		"سلام":        {0xfeb3, 0xfefc, 0xfee1},
+		"لا":          {0xfefb},
+		"ژ":           {0xfb8a},
+		"پی":          {0xfb58, 0xfbfd},
+		"می\u200cشود": {0xfee3, 0xfbfd, 0xfeb7, 0xfeee, 0xfea9},
+		"a-b":         {'a', '-', 'b'},
+	}
+	for text, forms := range tests {
+		assert.Equal(t, forms, joinArabic([]rune(text)), text)
+	}
+}
+
+func Test_JoinArabic_JoinsAcrossZWJ(t *testing.T) {
+	assert.Equal(t, []rune{0xfeb3, 0xfeb2}, joinArabic([]rune("س\u200dس")))
+	assert.Equal(t, []rune{0xfeb1, 0xfeb1}, joinArabic([]rune("س\u200cس")))
+}
+
+func Test_ReorderText_ReversesRightToLeftRuns(t *testing.T) {
+	tests := map[string]string{
+		"Voucher 1001":     "Voucher 1001",
+		"-1250":            "-1250",
+		"سند":              "دنس",
+		"سند 1001":         "1001 دنس",
+		"Bank ملت 123":     "Bank 123 تلم",
+		"حساب Bank Melli":  "Bank Melli باسح",
+		"(الف)":            "(فلا)",
+		"سند ۱۲/۳ تاریخ":   "خیرات ۱۲/۳ دنس",
+		"SL: 1101 - صندوق": "SL: 1101 - قودنص",
+	}
+	for text, visual := range tests {
+		assert.Equal(t, visual, string(reorderText([]rune(text))), text)
+	}
+}
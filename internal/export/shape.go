@@ -0,0 +1,298 @@
+package export
+
+import (
+	"golang.org/x/text/unicode/bidi"
+)
+
+const (
+	zeroWidthNonJoiner = '\u200c'
+	zeroWidthJoiner    = '\u200d'
+	tatweel            = '\u0640'
+	lam                = '\u0644'
+)
+
+// arabicForms lists the isolated, final, initial and medial presentation
+// forms of the Arabic and Persian letters. Letters without initial and
+// medial forms only join the letter before them.
+var arabicForms = map[rune][4]rune{
+	'ء': {0xfe80, 0, 0, 0},
+	'آ': {0xfe81, 0xfe82, 0, 0},
+	'أ': {0xfe83, 0xfe84, 0, 0},
+	'ؤ': {0xfe85, 0xfe86, 0, 0},
+	'إ': {0xfe87, 0xfe88, 0, 0},
+	'ئ': {0xfe89, 0xfe8a, 0xfe8b, 0xfe8c},
+	'ا': {0xfe8d, 0xfe8e, 0, 0},
+	'ب': {0xfe8f, 0xfe90, 0xfe91, 0xfe92},
+	'ة': {0xfe93, 0xfe94, 0, 0},
+	'ت': {0xfe95, 0xfe96, 0xfe97, 0xfe98},
+	'ث': {0xfe99, 0xfe9a, 0xfe9b, 0xfe9c},
+	'ج': {0xfe9d, 0xfe9e, 0xfe9f, 0xfea0},
+	'ح': {0xfea1, 0xfea2, 0xfea3, 0xfea4},
+	'خ': {0xfea5, 0xfea6, 0xfea7, 0xfea8},
+	'د': {0xfea9, 0xfeaa, 0, 0},
+	'ذ': {0xfeab, 0xfeac, 0, 0},
+	'ر': {0xfead, 0xfeae, 0, 0},
+	'ز': {0xfeaf, 0xfeb0, 0, 0},
+	'س': {0xfeb1, 0xfeb2, 0xfeb3, 0xfeb4},
+	'ش': {0xfeb5, 0xfeb6, 0xfeb7, 0xfeb8},
+	'ص': {0xfeb9, 0xfeba, 0xfebb, 0xfebc},
+	'ض': {0xfebd, 0xfebe, 0xfebf, 0xfec0},
+	'ط': {0xfec1, 0xfec2, 0xfec3, 0xfec4},
+	'ظ': {0xfec5, 0xfec6, 0xfec7, 0xfec8},
+	'ع': {0xfec9, 0xfeca, 0xfecb, 0xfecc},
+	'غ': {0xfecd, 0xfece, 0xfecf, 0xfed0},
+	'ف': {0xfed1, 0xfed2, 0xfed3, 0xfed4},
+	'ق': {0xfed5, 0xfed6, 0xfed7, 0xfed8},
+	'ك': {0xfed9, 0xfeda, 0xfedb, 0xfedc},
+	'ل': {0xfedd, 0xfede, 0xfedf, 0xfee0},
+	'م': {0xfee1, 0xfee2, 0xfee3, 0xfee4},
+	'ن': {0xfee5, 0xfee6, 0xfee7, 0xfee8},
+	'ه': {0xfee9, 0xfeea, 0xfeeb, 0xfeec},
+	'و': {0xfeed, 0xfeee, 0, 0},
+	'ى': {0xfeef, 0xfef0, 0, 0},
+	'ي': {0xfef1, 0xfef2, 0xfef3, 0xfef4},
+	'پ': {0xfb56, 0xfb57, 0xfb58, 0xfb59},
+	'چ': {0xfb7a, 0xfb7b, 0xfb7c, 0xfb7d},
+	'ژ': {0xfb8a, 0xfb8b, 0, 0},
+	'ک': {0xfb8e, 0xfb8f, 0xfb90, 0xfb91},
+	'گ': {0xfb92, 0xfb93, 0xfb94, 0xfb95},
+	'ی': {0xfbfc, 0xfbfd, 0xfbfe, 0xfbff},
+}
+
+// lamAlefForms are the isolated and final forms of lam followed by an alef.
+var lamAlefForms = map[rune][2]rune{
+	'آ': {0xfef5, 0xfef6},
+	'أ': {0xfef7, 0xfef8},
+	'إ': {0xfef9, 0xfefa},
+	'ا': {0xfefb, 0xfefc},
+}
+
+var mirroredRunes = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<', '«': '»', '»': '«',
+}
+
+// shapeText returns the runes of a single line in the order they are drawn
+// from left to right. The embedded font has no layout tables, so Arabic
+// letters are replaced by their contextual presentation forms and
+// right-to-left runs are reordered here.
+func shapeText(text string) []rune {
+	return reorderText(joinArabic([]rune(text)))
+}
+
+func joinsBefore(r rune) bool {
+	if r == tatweel || r == zeroWidthJoiner {
+		return true
+	}
+	_, ok := arabicForms[r]
+	return ok && r != 'ء'
+}
+
+func joinsAfter(r rune) bool {
+	if r == tatweel || r == zeroWidthJoiner {
+		return true
+	}
+	forms, ok := arabicForms[r]
+	return ok && forms[2] != 0
+}
+
+func isTransparent(r rune) bool {
+	props, _ := bidi.LookupRune(r)
+	return props.Class() == bidi.NSM
+}
+
+// joinArabic picks the presentation form of every Arabic letter from its
+// neighbours, skipping marks. ZWNJ keeps letters apart and ZWJ joins them;
+// both are dropped since they have nothing to draw.
+func joinArabic(runes []rune) []rune {
+	neighbour := func(from int, step int) rune {
+		for i := from + step; i >= 0 && i < len(runes); i += step {
+			if !isTransparent(runes[i]) {
+				return runes[i]
+			}
+		}
+		return 0
+	}
+
+	shaped := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == zeroWidthNonJoiner || r == zeroWidthJoiner {
+			continue
+		}
+		forms, ok := arabicForms[r]
+		if !ok {
+			shaped = append(shaped, r)
+			continue
+		}
+		joinedBefore := joinsBefore(r) && joinsAfter(neighbour(i, -1))
+		next := neighbour(i, 1)
+		if ligature, ok := lamAlefForms[next]; r == lam && ok {
+			if joinedBefore {
+				shaped = append(shaped, ligature[1])
+			} else {
+				shaped = append(shaped, ligature[0])
+			}
+			for i++; runes[i] != next; i++ {
+				shaped = append(shaped, runes[i])
+			}
+			continue
+		}
+		joinedAfter := joinsAfter(r) && joinsBefore(next)
+
+		form := forms[0]
+		switch {
+		case joinedBefore && joinedAfter:
+			form = forms[3]
+		case joinedBefore:
+			form = forms[1]
+		case joinedAfter:
+			form = forms[2]
+		}
+		shaped = append(shaped, form)
+	}
+	return shaped
+}
+
+// reorderText applies the parts of the Unicode bidirectional algorithm that
+// matter for a single line without embeddings: the paragraph takes the
+// direction of its first strong character, numbers stay left to right and
+// neutrals take the direction of the text around them.
+func reorderText(runes []rune) []rune {
+	classes := make([]bidi.Class, len(runes))
+	baseLevel := -1
+	for i, r := range runes {
+		props, _ := bidi.LookupRune(r)
+		classes[i] = props.Class()
+		if baseLevel < 0 {
+			switch classes[i] {
+			case bidi.L:
+				baseLevel = 0
+			case bidi.R, bidi.AL:
+				baseLevel = 1
+			}
+		}
+	}
+	if baseLevel < 0 {
+		return runes
+	}
+	baseClass := bidi.L
+	if baseLevel == 1 {
+		baseClass = bidi.R
+	}
+
+	// Marks take the class of the character they follow, and numbers take
+	// the class of the strong text they follow.
+	lastStrong := baseClass
+	for i, class := range classes {
+		if class == bidi.NSM {
+			if i == 0 {
+				class = baseClass
+			} else {
+				class = classes[i-1]
+			}
+		}
+		switch class {
+		case bidi.L, bidi.R:
+			lastStrong = class
+		case bidi.AL:
+			lastStrong, class = bidi.R, bidi.R
+		case bidi.EN:
+			if lastStrong == bidi.L {
+				class = bidi.L
+			}
+		}
+		classes[i] = class
+	}
+	for i := 1; i+1 < len(classes); i++ {
+		if (classes[i] == bidi.ES || classes[i] == bidi.CS) && classes[i-1] == bidi.EN && classes[i+1] == bidi.EN {
+			classes[i] = bidi.EN
+		}
+	}
+
+	strongAt := func(i int) bidi.Class {
+		switch classes[i] {
+		case bidi.L:
+			return bidi.L
+		case bidi.R, bidi.EN, bidi.AN:
+			return bidi.R
+		}
+		return bidi.ON
+	}
+	levels := make([]int, len(runes))
+	for i := 0; i < len(runes); {
+		if strongAt(i) != bidi.ON {
+			levels[i] = resolvedLevel(classes[i], baseLevel)
+			i++
+			continue
+		}
+		end := i
+		for end < len(runes) && strongAt(end) == bidi.ON {
+			end++
+		}
+		before, after := baseClass, baseClass
+		if i > 0 {
+			before = strongAt(i - 1)
+		}
+		if end < len(runes) {
+			after = strongAt(end)
+		}
+		class := baseClass
+		if before == after {
+			class = before
+		}
+		for ; i < end; i++ {
+			levels[i] = resolvedLevel(class, baseLevel)
+		}
+	}
+
+	visual := append([]rune{}, runes...)
+	for level := maxLevel(levels); level > 0; level-- {
+		for start := 0; start < len(visual); {
+			if levels[start] < level {
+				start++
+				continue
+			}
+			end := start
+			for end < len(visual) && levels[end] >= level {
+				end++
+			}
+			reverse(visual[start:end], levels[start:end])
+			start = end
+		}
+	}
+	for i, r := range visual {
+		if levels[i]%2 == 1 {
+			if mirrored, ok := mirroredRunes[r]; ok {
+				visual[i] = mirrored
+			}
+		}
+	}
+	return visual
+}
+
+func resolvedLevel(class bidi.Class, baseLevel int) int {
+	switch {
+	case class == bidi.L:
+		return baseLevel + baseLevel%2
+	case class == bidi.EN || class == bidi.AN:
+		return baseLevel + 2 - baseLevel%2
+	}
+	return baseLevel + 1 - baseLevel%2
+}
+
+func maxLevel(levels []int) int {
+	highest := 0
+	for _, level := range levels {
+		if level > highest {
+			highest = level
+		}
+	}
+	return highest
+}
+
+func reverse(runes []rune, levels []int) {
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+		levels[i], levels[j] = levels[j], levels[i]
+	}
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"accountingsystem/internal/dtos"
+	"io"
+)
+
+type TrialBalanceWriter struct {
+	writer     *Writer
+	byCurrency bool
+}
+
+func NewTrialBalanceWriter(w io.Writer, format Format, byCurrency bool) (*TrialBalanceWriter, error) {
+	columns := []string{"SL Code", "SL Title", "Total Debit", "Total Credit", "Debit Balance", "Credit Balance"}
+	if byCurrency {
+		columns = append(columns, "Currency", "Foreign Debit Balance", "Foreign Credit Balance")
+	}
+
+	writer, err := NewWriter(w, format, Document{
+		Title:   "Trial Balance",
+		Columns: columns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TrialBalanceWriter{writer: writer, byCurrency: byCurrency}, nil
+}
+
+func (t *TrialBalanceWriter) WriteRow(row dtos.TrialBalanceRowDto) error {
+	cells := []Cell{
+		Text(row.SLCode),
+		Text(row.SLTitle),
+		Amount(row.TotalDebit),
+		Amount(row.TotalCredit),
+		Amount(row.DebitBalance),
+		Amount(row.CreditBalance),
+	}
+	if t.byCurrency {
+		cells = append(cells, Text(row.CurrencyCode), Amount(row.ForeignDebitBalance), Amount(row.ForeignCreditBalance))
+	}
+	return t.writer.WriteRow(cells...)
+}
+
+func (t *TrialBalanceWriter) Close(totals *dtos.TrialBalanceDto) error {
+	// Foreign balances are in different currencies and are not totalled.
+	cells := []Cell{
+		Text("Total"),
+		Text(""),
+		Amount(totals.TotalDebit),
+		Amount(totals.TotalCredit),
+		Amount(totals.TotalDebitBalance),
+		Amount(totals.TotalCreditBalance),
+	}
+	if t.byCurrency {
+		cells = append(cells, Text(""), Text(""), Text(""))
+	}
+	if err := t.writer.WriteTotals(cells...); err != nil {
+		return err
+	}
+	return t.writer.Close()
+}
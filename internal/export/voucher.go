@@ -0,0 +1,83 @@
+package export
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/money"
+	"io"
+	"strconv"
+)
+
+func WriteVoucher(w io.Writer, format Format, voucher *dtos.VoucherWithItemsDto, sls map[int]dtos.SLDto, dls map[int]dtos.DLDto) error {
+	columns := []string{"Row", "SL Code", "SL Title", "DL Code", "DL Title", "Debit", "Credit"}
+	withCurrency := hasForeignCurrencyItems(voucher.VoucherItems)
+	if withCurrency {
+		columns = append(columns, "Currency", "Foreign Amount", "Rate")
+	}
+
+	writer, err := NewWriter(w, format, Document{
+		Title: "Voucher " + voucher.Number,
+		Headings: []string{
+			"Number: " + voucher.Number,
+			"Date: " + voucher.DateText,
+			"ID: " + strconv.Itoa(voucher.ID),
+			"Version: " + strconv.Itoa(voucher.RowVersion),
+		},
+		Columns:    columns,
+		Signatures: []string{"Prepared by", "Checked by", "Approved by"},
+	})
+	if err != nil {
+		return err
+	}
+
+	var totalDebit, totalCredit money.Amount
+	for i, item := range voucher.VoucherItems {
+		sl := sls[item.SLID]
+		dl := dls[item.DLID]
+		cells := []Cell{
+			Number(i + 1),
+			Text(sl.Code),
+			Text(sl.Title),
+			Text(dl.Code),
+			Text(dl.Title),
+			Amount(item.Debit),
+			Amount(item.Credit),
+		}
+		if withCurrency {
+			cells = append(cells, Text(item.CurrencyCode), foreignAmountCell(item), Text(item.ExchangeRate))
+		}
+		if err := writer.WriteRow(cells...); err != nil {
+			return err
+		}
+		if totalDebit, err = totalDebit.Add(item.Debit); err != nil {
+			return err
+		}
+		if totalCredit, err = totalCredit.Add(item.Credit); err != nil {
+			return err
+		}
+	}
+
+	totals := []Cell{Text("Total"), Text(""), Text(""), Text(""), Text(""), Amount(totalDebit), Amount(totalCredit)}
+	if withCurrency {
+		totals = append(totals, Text(""), Text(""), Text(""))
+	}
+	if err := writer.WriteTotals(totals...); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func hasForeignCurrencyItems(items []dtos.VoucherItemDto) bool {
+	for _, item := range items {
+		if item.CurrencyCode != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func foreignAmountCell(item dtos.VoucherItemDto) Cell {
+	if item.CurrencyCode == "" {
+		return Text("")
+	}
+	return Amount(item.ForeignDebit + item.ForeignCredit)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/money"
+	"io"
+	"strconv"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+func (f Format) IsValid() bool {
+	return f == FormatCSV || f == FormatXLSX || f == FormatPDF
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+type Document struct {
+	Title      string
+	Headings   []string
+	Columns    []string
+	Signatures []string
+}
+
+// preamble returns the lines spreadsheets show above the table: the title,
+// the headings and a blank line.
+func preamble(doc Document) []string {
+	lines := append([]string{doc.Title}, doc.Headings...)
+	return append(lines, "")
+}
+
+// Cell is a single table value. Numeric cells keep their canonical decimal
+// text, e.g. "-1250.75", which every format renders in its own way.
+type Cell struct {
+	Text     string
+	IsNumber bool
+}
+
+func Text(text string) Cell {
+	return Cell{Text: text}
+}
+
+func Number(number int) Cell {
+	return Cell{Text: strconv.Itoa(number), IsNumber: true}
+}
+
+func Amount(amount money.Amount) Cell {
+	return Cell{Text: amount.String(), IsNumber: true}
+}
+
+// Writer streams a single table to the underlying io.Writer row by row, so
+// callers can feed it straight from a database cursor.
+type Writer struct {
+	format Format
+	csv    *csvWriter
+	xlsx   *xlsxWriter
+	pdf    *pdfWriter
+}
+
+func NewWriter(w io.Writer, format Format, doc Document) (*Writer, error) {
+	writer := &Writer{format: format}
+	var err error
+	switch format {
+	case FormatCSV:
+		writer.csv, err = newCSVWriter(w, doc)
+	case FormatXLSX:
+		writer.xlsx, err = newXLSXWriter(w, doc)
+	case FormatPDF:
+		writer.pdf, err = newPDFWriter(w, doc)
+	default:
+		return nil, constants.ErrInvalidExportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *Writer) WriteRow(cells ...Cell) error {
+	switch w.format {
+	case FormatCSV:
+		return w.csv.writeRow(cells)
+	case FormatXLSX:
+		return w.xlsx.writeRow(cells)
+	default:
+		return w.pdf.writeRow(cells, false)
+	}
+}
+
+func (w *Writer) WriteTotals(cells ...Cell) error {
+	switch w.format {
+	case FormatCSV:
+		return w.csv.writeRow(cells)
+	case FormatXLSX:
+		return w.xlsx.writeRow(cells)
+	default:
+		return w.pdf.writeRow(cells, true)
+	}
+}
+
+func (w *Writer) Close() error {
+	switch w.format {
+	case FormatCSV:
+		return w.csv.close()
+	case FormatXLSX:
+		return w.xlsx.close()
+	default:
+		return w.pdf.close()
+	}
+}
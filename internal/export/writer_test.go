@@ -0,0 +1,129 @@
+package export
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDocument = Document{
+	Title:    "سند حسابداری",
+	Headings: []string{"Number: 1001", "Date: 1403/01/15"},
+	Columns:  []string{"Code", "Title", "Debit"},
+}
+
+func writeTestTable(t *testing.T, format Format) []byte {
+	var output bytes.Buffer
+	writer, err := NewWriter(&output, format, testDocument)
+	require.Nil(t, err)
+	require.Nil(t, writer.WriteRow(Text("1001"), Text("صندوق"), Amount(125075)))
+	require.Nil(t, writer.WriteTotals(Text("Total"), Text(""), Amount(125075)))
+	require.Nil(t, writer.Close())
+	return output.Bytes()
+}
+
+// readCSV reads the records of an export; the title and heading lines have
+// a single field.
+func readCSV(t *testing.T, content []byte) [][]string {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.Nil(t, err)
+	return records
+}
+
+func readXLSXSheet(t *testing.T, content []byte) (string, string) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.Nil(t, err)
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.Nil(t, err)
+		part, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		parts[file.Name] = string(part)
+	}
+	return parts["xl/workbook.xml"], parts["xl/worksheets/sheet1.xml"]
+}
+
+func Test_NewWriter_ReturnsErrInvalidExportFormat_WithUnknownFormat(t *testing.T) {
+	writer, err := NewWriter(io.Discard, Format("docx"), testDocument)
+
+	assert.ErrorIs(t, err, constants.ErrInvalidExportFormat)
+	assert.Nil(t, writer)
+}
+
+func Test_CSVWriter_WritesTitleAndHeadings_BeforeTable(t *testing.T) {
+	records := readCSV(t, writeTestTable(t, FormatCSV))
+
+	assert.Equal(t, [][]string{
+		{"سند حسابداری"},
+		{"Number: 1001"},
+		{"Date: 1403/01/15"},
+		{"Code", "Title", "Debit"},
+		{"1001", "صندوق", "125075"},
+		{"Total", "", "125075"},
+	}, records)
+}
+
+func Test_CSVWriter_SeparatesHeadingsFromTable_WithBlankLine(t *testing.T) {
+	lines := strings.Split(string(writeTestTable(t, FormatCSV)), "\n")
+
+	require.Greater(t, len(lines), 4)
+	assert.Equal(t, "", lines[3])
+	assert.Equal(t, "Code,Title,Debit", lines[4])
+}
+
+func Test_XLSXWriter_WritesTitleHeadingsAndCells(t *testing.T) {
+	workbook, sheet := readXLSXSheet(t, writeTestTable(t, FormatXLSX))
+
+	assert.Contains(t, workbook, `<sheet name="سند حسابداری"`)
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">سند حسابداری</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Number: 1001</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A5" t="inlineStr"><is><t xml:space="preserve">Code</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B6" t="inlineStr"><is><t xml:space="preserve">صندوق</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C6"><v>125075</v></c>`)
+	assert.Contains(t, sheet, `<c r="C7"><v>125075</v></c>`)
+}
+
+func Test_XLSXWriter_EscapesText_WithMarkup(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := NewWriter(&output, FormatXLSX, Document{Title: "A/B", Columns: []string{"Title"}})
+	require.Nil(t, err)
+	require.Nil(t, writer.WriteRow(Text("<R&D>")))
+	require.Nil(t, writer.Close())
+
+	workbook, sheet := readXLSXSheet(t, output.Bytes())
+
+	assert.Contains(t, workbook, `<sheet name="A-B"`)
+	assert.Contains(t, sheet, "&lt;R&amp;D&gt;")
+}
+
+func Test_WriteVoucher_ListsItemsWithAccountTitles(t *testing.T) {
+	voucher := &dtos.VoucherWithItemsDto{
+		ID: 7, Number: "1001", DateText: "1403/01/15", RowVersion: 1,
+		VoucherItems: []dtos.VoucherItemDto{
+			{SLID: 1, DLID: 2, Debit: 500},
+			{SLID: 3, Credit: 500},
+		},
+	}
+	sls := map[int]dtos.SLDto{1: {Code: "1101", Title: "صندوق"}, 3: {Code: "4101", Title: "فروش"}}
+	dls := map[int]dtos.DLDto{2: {Code: "201", Title: "شعبه مرکزی"}}
+
+	var output bytes.Buffer
+	err := WriteVoucher(&output, FormatCSV, voucher, sls, dls)
+
+	require.Nil(t, err)
+	records := readCSV(t, output.Bytes())
+	assert.Equal(t, []string{"Voucher 1001"}, records[0])
+	assert.Equal(t, []string{"1", "1101", "صندوق", "201", "شعبه مرکزی", "500", "0"}, records[len(records)-3])
+	assert.Equal(t, []string{"Total", "", "", "", "", "500", "500"}, records[len(records)-1])
+}
@@ -0,0 +1,150 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetEnd = `</sheetData></worksheet>`
+
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+func newXLSXWriter(w io.Writer, doc Document) (*xlsxWriter, error) {
+	x := &xlsxWriter{zip: zip.NewWriter(w)}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escapeXML(sheetName(doc.Title)))},
+	}
+	for _, part := range parts {
+		if err := x.writePart(part.name, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+	x.sheet = sheet
+
+	for _, line := range preamble(doc) {
+		if err := x.writeRow([]Cell{Text(line)}); err != nil {
+			return nil, err
+		}
+	}
+	header := make([]Cell, len(doc.Columns))
+	for i, column := range doc.Columns {
+		header[i] = Text(column)
+	}
+	if err := x.writeRow(header); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxWriter) writePart(name string, content string) error {
+	part, err := x.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, content)
+	return err
+}
+
+func (x *xlsxWriter) writeRow(cells []Cell) error {
+	x.row++
+	var b strings.Builder
+	b.WriteString(`<row r="` + strconv.Itoa(x.row) + `">`)
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(x.row)
+		if cell.IsNumber {
+			b.WriteString(`<c r="` + ref + `"><v>` + cell.Text + `</v></c>`)
+		} else {
+			b.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + escapeXML(cell.Text) + `</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+func (x *xlsxWriter) close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func sheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '-'
+		}
+		return r
+	}, title)
+	if name == "" {
+		return "Sheet1"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func escapeXML(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package formula
+
+import (
+	"accountingsystem/internal/constants"
+	"math/big"
+)
+
+// Expression is a parsed arithmetic formula over named integer variables,
+// e.g. "salary * 0.23" or "(rent + service) / 12". It supports + - * /,
+// parentheses, unary minus and decimal literals, and evaluates exactly.
+type Expression struct {
+	root *node
+}
+
+type node struct {
+	op       byte
+	value    *big.Rat
+	variable string
+	left     *node
+	right    *node
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func Parse(input string) (*Expression, error) {
+	p := &parser{input: input}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, constants.ErrInvalidFormula
+	}
+	return &Expression{root: root}, nil
+}
+
+func (e *Expression) Variables() []string {
+	var names []string
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n == nil {
+			return
+		}
+		if n.variable != "" {
+			names = append(names, n.variable)
+		}
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(e.root)
+	return names
+}
+
+// Evaluate returns the value rounded half away from zero to an integer.
+func (e *Expression) Evaluate(variables map[string]int64) (int64, error) {
+	value, err := evaluate(e.root, variables)
+	if err != nil {
+		return 0, err
+	}
+	return round(value)
+}
+
+func evaluate(n *node, variables map[string]int64) (*big.Rat, error) {
+	if n.value != nil {
+		return n.value, nil
+	}
+	if n.variable != "" {
+		value, ok := variables[n.variable]
+		if !ok {
+			return nil, constants.ErrInvalidFormula
+		}
+		return new(big.Rat).SetInt64(value), nil
+	}
+
+	left, err := evaluate(n.left, variables)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == 'n' {
+		return new(big.Rat).Neg(left), nil
+	}
+	right, err := evaluate(n.right, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case '+':
+		return new(big.Rat).Add(left, right), nil
+	case '-':
+		return new(big.Rat).Sub(left, right), nil
+	case '*':
+		return new(big.Rat).Mul(left, right), nil
+	default:
+		if right.Sign() == 0 {
+			return nil, constants.ErrInvalidFormula
+		}
+		return new(big.Rat).Quo(left, right), nil
+	}
+}
+
+func round(value *big.Rat) (int64, error) {
+	num := new(big.Int).Abs(value.Num())
+	quotient, remainder := new(big.Int).QuoRem(num, value.Denom(), new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return 0, constants.ErrAmountOverflow
+	}
+	return quotient.Int64(), nil
+}
+
+func (p *parser) parseSum() (*node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '+' && p.input[p.pos] != '-') {
+			return left, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &node{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseProduct() (*node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '*' && p.input[p.pos] != '/') {
+			return left, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &node{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (*node, error) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{op: 'n', left: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (*node, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, constants.ErrInvalidFormula
+	}
+
+	c := p.input[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, constants.ErrInvalidFormula
+		}
+		p.pos++
+		return inner, nil
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		value, ok := new(big.Rat).SetString(p.input[start:p.pos])
+		if !ok {
+			return nil, constants.ErrInvalidFormula
+		}
+		return &node{value: value}, nil
+	case isIdentifierStart(c):
+		start := p.pos
+		for p.pos < len(p.input) && (isIdentifierStart(p.input[p.pos]) || p.input[p.pos] >= '0' && p.input[p.pos] <= '9') {
+			p.pos++
+		}
+		return &node{variable: p.input[start:p.pos]}, nil
+	}
+	return nil, constants.ErrInvalidFormula
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
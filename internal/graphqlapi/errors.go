@@ -0,0 +1,86 @@
+package graphqlapi
+
+import (
+	"accountingsystem/internal/constants"
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// errorCodes maps the service errors that are not validation errors of the
+// request to the code reported in the extensions of a GraphQL error. A
+// response may carry data and errors at once, so the HTTP status cannot tell
+// them apart.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{constants.ErrForbidden, "FORBIDDEN"},
+	{constants.ErrUnexpectedError, "UNEXPECTED_ERROR"},
+	{constants.ErrDeadlineExceeded, "DEADLINE_EXCEEDED"},
+	{constants.ErrCanceled, "CANCELED"},
+	{constants.ErrDLNotFound, "DL_NOT_FOUND"},
+	{constants.ErrSLNotFound, "SL_NOT_FOUND"},
+	{constants.ErrVoucherNotFound, "VOUCHER_NOT_FOUND"},
+	{constants.ErrCurrencyNotFound, "CURRENCY_NOT_FOUND"},
+	{constants.ErrAmountOverflow, "AMOUNT_OVERFLOW"},
+	{constants.ErrTooManyIDs, "TOO_MANY_IDS"},
+}
+
+type codedError struct {
+	err  error
+	code string
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+func (e *codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// serviceError attaches the code of a service error, or "INVALID_REQUEST"
+// for validation errors.
+func serviceError(err error) error {
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			return &codedError{err: err, code: errorCode.code}
+		}
+	}
+	return &codedError{err: err, code: "INVALID_REQUEST"}
+}
+
+// addErrorCodes restores the codes of errors returned by the thunks of the
+// loaders, whose extensions the executor drops when it wraps them.
+func addErrorCodes(result *graphql.Result) {
+	for i := range result.Errors {
+		if result.Errors[i].Extensions != nil {
+			continue
+		}
+		if coded := codedErrorOf(result.Errors[i].OriginalError()); coded != nil {
+			result.Errors[i].Extensions = coded.Extensions()
+		}
+	}
+}
+
+func codedErrorOf(err error) *codedError {
+	for err != nil {
+		switch wrapped := err.(type) {
+		case *codedError:
+			return wrapped
+		case *gqlerrors.Error:
+			err = wrapped.OriginalError
+		case gqlerrors.FormattedError:
+			err = wrapped.OriginalError()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
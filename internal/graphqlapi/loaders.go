@@ -0,0 +1,131 @@
+package graphqlapi
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/report"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/voucher"
+	"context"
+	"sync"
+)
+
+// loaderBatchSize is the most IDs a loader asks a service for at once, the
+// limit of the services' GetMany requests.
+const loaderBatchSize = constants.MaxLookupIDs
+
+// loader batches the lookups of one kind of record within a request. load
+// only queues the ID and returns a thunk; the executor calls the thunks of
+// a level after all fields of the level were resolved, so the first thunk
+// fetches every queued ID in one call and the others read its results.
+type loader struct {
+	mu      sync.Mutex
+	fetch   func(ids []int) (map[int]any, error)
+	queued  []int
+	known   map[int]bool
+	results map[int]any
+	errs    map[int]error
+}
+
+func newLoader(fetch func(ids []int) (map[int]any, error)) *loader {
+	return &loader{fetch: fetch, known: map[int]bool{}, results: map[int]any{}, errs: map[int]error{}}
+}
+
+// load returns a thunk that yields the record with the given ID, or nil when
+// there is none.
+func (l *loader) load(id int) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.known[id] {
+		l.known[id] = true
+		l.queued = append(l.queued, id)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.fetchQueued()
+		if err := l.errs[id]; err != nil {
+			return nil, err
+		}
+		return l.results[id], nil
+	}
+}
+
+func (l *loader) fetchQueued() {
+	for len(l.queued) > 0 {
+		batch := l.queued
+		if len(batch) > loaderBatchSize {
+			batch = batch[:loaderBatchSize]
+		}
+		l.queued = l.queued[len(batch):]
+
+		records, err := l.fetch(batch)
+		for _, id := range batch {
+			if err != nil {
+				l.errs[id] = err
+				continue
+			}
+			l.results[id] = records[id]
+		}
+	}
+}
+
+// loaders holds the loaders of one request, so nothing is cached across
+// requests or actors.
+type loaders struct {
+	dls      *loader
+	sls      *loader
+	vouchers *loader
+	balances *loader
+}
+
+func (s *Server) newLoaders(ctx context.Context) *loaders {
+	actor := actorFrom(ctx)
+	return &loaders{
+		dls: newLoader(func(ids []int) (map[int]any, error) {
+			dlDtos, err := s.dlService.GetDLsContext(ctx, actor, &dl.GetManyRequest{IDs: ids})
+			if err != nil {
+				return nil, serviceError(err)
+			}
+			records := make(map[int]any, len(dlDtos))
+			for _, dlDto := range dlDtos {
+				records[dlDto.ID] = dlDto
+			}
+			return records, nil
+		}),
+		sls: newLoader(func(ids []int) (map[int]any, error) {
+			slDtos, err := s.slService.GetSLsContext(ctx, actor, &sl.GetManyRequest{IDs: ids})
+			if err != nil {
+				return nil, serviceError(err)
+			}
+			records := make(map[int]any, len(slDtos))
+			for _, slDto := range slDtos {
+				records[slDto.ID] = slDto
+			}
+			return records, nil
+		}),
+		vouchers: newLoader(func(ids []int) (map[int]any, error) {
+			voucherDtos, err := s.voucherService.GetVouchersContext(ctx, actor, &voucher.GetManyRequest{IDs: ids})
+			if err != nil {
+				return nil, serviceError(err)
+			}
+			records := make(map[int]any, len(voucherDtos))
+			for _, voucherDto := range voucherDtos {
+				records[voucherDto.ID] = voucherDto
+			}
+			return records, nil
+		}),
+		balances: newLoader(func(ids []int) (map[int]any, error) {
+			balances, err := s.reportService.GetSLBalancesContext(ctx, actor, &report.SLBalancesRequest{SLIDs: ids})
+			if err != nil {
+				return nil, serviceError(err)
+			}
+			records := make(map[int]any, len(balances))
+			for _, balance := range balances {
+				records[balance.SLID] = balance
+			}
+			return records, nil
+		}),
+	}
+}
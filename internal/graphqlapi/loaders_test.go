@@ -0,0 +1,134 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fetchRecorder struct {
+	batches [][]int
+	err     error
+}
+
+func (f *fetchRecorder) fetch(ids []int) (map[int]any, error) {
+	f.batches = append(f.batches, append([]int{}, ids...))
+	if f.err != nil {
+		return nil, f.err
+	}
+	records := map[int]any{}
+	for _, id := range ids {
+		if id > 0 {
+			records[id] = map[string]interface{}{"id": id, "parentId": id * 10}
+		}
+	}
+	return records, nil
+}
+
+// runLoaderQuery resolves items whose owners, and the parents of the owners,
+// come from loaders.
+func runLoaderQuery(t *testing.T, ownerIDs []int, owners *loader, parents *loader) *graphql.Result {
+	parentType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Parent",
+		Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.Int}},
+	})
+	ownerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Owner",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int},
+			"parent": &graphql.Field{
+				Type: parentType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return parents.load(p.Source.(map[string]interface{})["parentId"].(int)), nil
+				},
+			},
+		},
+	})
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"owner": &graphql.Field{
+				Type: ownerType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return owners.load(p.Source.(int)), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewList(itemType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return ownerIDs, nil
+				},
+			},
+		},
+	})})
+	require.Nil(t, err)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: "{ items { owner { id parent { id } } } }",
+		Context:       context.Background(),
+	})
+	addErrorCodes(result)
+	return result
+}
+
+func Test_Loader_FetchesEachLevelOnce_WithRepeatedIDs(t *testing.T) {
+	owners := &fetchRecorder{}
+	parents := &fetchRecorder{}
+
+	result := runLoaderQuery(t, []int{1, 2, 1, 3, 2}, newLoader(owners.fetch), newLoader(parents.fetch))
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, [][]int{{1, 2, 3}}, owners.batches)
+	assert.Equal(t, [][]int{{10, 20, 30}}, parents.batches)
+	items := result.Data.(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 5)
+	owner := items[3].(map[string]interface{})["owner"].(map[string]interface{})
+	assert.Equal(t, 3, owner["id"])
+	assert.Equal(t, 30, owner["parent"].(map[string]interface{})["id"])
+}
+
+func Test_Loader_ResolvesNull_WithMissingRecord(t *testing.T) {
+	owners := &fetchRecorder{}
+
+	result := runLoaderQuery(t, []int{1, -1}, newLoader(owners.fetch), newLoader((&fetchRecorder{}).fetch))
+
+	require.Empty(t, result.Errors)
+	items := result.Data.(map[string]interface{})["items"].([]interface{})
+	assert.Nil(t, items[1].(map[string]interface{})["owner"])
+}
+
+func Test_Loader_SplitsBatches_WithMoreIDsThanBatchSize(t *testing.T) {
+	owners := &fetchRecorder{}
+	ownerIDs := make([]int, loaderBatchSize+1)
+	for i := range ownerIDs {
+		ownerIDs[i] = i + 1
+	}
+
+	result := runLoaderQuery(t, ownerIDs, newLoader(owners.fetch), newLoader((&fetchRecorder{}).fetch))
+
+	require.Empty(t, result.Errors)
+	require.Len(t, owners.batches, 2)
+	assert.Len(t, owners.batches[0], loaderBatchSize)
+	assert.Equal(t, []int{loaderBatchSize + 1}, owners.batches[1])
+}
+
+func Test_Loader_ReportsFetchError_WithCodeExtension(t *testing.T) {
+	owners := &fetchRecorder{err: serviceError(errors.New("request is malformed"))}
+
+	result := runLoaderQuery(t, []int{1, 2}, newLoader(owners.fetch), newLoader((&fetchRecorder{}).fetch))
+
+	require.Len(t, owners.batches, 1)
+	require.NotEmpty(t, result.Errors)
+	assert.Equal(t, "request is malformed", result.Errors[0].Message)
+	assert.Equal(t, "INVALID_REQUEST", result.Errors[0].Extensions["code"])
+}
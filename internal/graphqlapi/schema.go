@@ -0,0 +1,391 @@
+package graphqlapi
+
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/report"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/voucher"
+	"errors"
+
+	"github.com/graphql-go/graphql"
+)
+
+var amountType = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Amount",
+	Description: "An amount in minor units of the currency, as a 64-bit integer.",
+	Serialize: func(value interface{}) interface{} {
+		if amount, ok := value.(money.Amount); ok {
+			return int64(amount)
+		}
+		return nil
+	},
+})
+
+// field declares a non-null field read from the DTO field of the same name.
+func field(fieldType graphql.Output) *graphql.Field {
+	return &graphql.Field{Type: graphql.NewNonNull(fieldType)}
+}
+
+func (s *Server) buildSchema() (graphql.Schema, error) {
+	dlType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "DL",
+		Fields: graphql.Fields{
+			"id":         field(graphql.Int),
+			"code":       field(graphql.String),
+			"title":      field(graphql.String),
+			"rowVersion": field(graphql.Int),
+		},
+	})
+
+	var slType *graphql.Object
+	slBalanceType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "SLBalance",
+		Description: "Totals of the posted lines of an SL.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"sl": &graphql.Field{
+					Type: graphql.NewNonNull(slType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).sls.load(p.Source.(dtos.SLBalanceDto).SLID), nil
+					},
+				},
+				"totalDebit":  field(amountType),
+				"totalCredit": field(amountType),
+				"balance":     field(amountType),
+			}
+		}),
+	})
+	slType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "SL",
+		Fields: graphql.Fields{
+			"id":         field(graphql.Int),
+			"code":       field(graphql.String),
+			"title":      field(graphql.String),
+			"hasDL":      field(graphql.Boolean),
+			"archived":   field(graphql.Boolean),
+			"rowVersion": field(graphql.Int),
+			"balance": &graphql.Field{
+				Type: graphql.NewNonNull(slBalanceType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).balances.load(p.Source.(dtos.SLDto).ID), nil
+				},
+			},
+		},
+	})
+
+	voucherItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "VoucherItem",
+		Fields: graphql.Fields{
+			"id": field(graphql.Int),
+			"sl": &graphql.Field{
+				Type: graphql.NewNonNull(slType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).sls.load(p.Source.(dtos.VoucherItemDto).SLID), nil
+				},
+			},
+			"dl": &graphql.Field{
+				Type: dlType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadDL(p, p.Source.(dtos.VoucherItemDto).DLID), nil
+				},
+			},
+			"debit":         field(amountType),
+			"credit":        field(amountType),
+			"currencyCode":  field(graphql.String),
+			"foreignDebit":  field(amountType),
+			"foreignCredit": field(amountType),
+			"exchangeRate":  field(graphql.String),
+		},
+	})
+
+	var voucherType *graphql.Object
+	voucherType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Voucher",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                field(graphql.Int),
+				"number":            field(graphql.String),
+				"date":              field(graphql.DateTime),
+				"dateText":          field(graphql.String),
+				"status":            field(graphql.String),
+				"requiredApprovals": field(graphql.Int),
+				"rowVersion":        field(graphql.Int),
+				"reversalOf": &graphql.Field{
+					Type: voucherType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						reversalOfID := p.Source.(dtos.VoucherWithItemsDto).ReversalOfID
+						if reversalOfID == 0 {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).vouchers.load(reversalOfID), nil
+					},
+				},
+				"items": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(voucherItemType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(dtos.VoucherWithItemsDto).VoucherItems, nil
+					},
+				},
+			}
+		}),
+	})
+
+	ledgerCardRowType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LedgerCardRow",
+		Fields: graphql.Fields{
+			"voucher": &graphql.Field{
+				Type: graphql.NewNonNull(voucherType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).vouchers.load(p.Source.(dtos.LedgerCardRowDto).VoucherID), nil
+				},
+			},
+			"voucherNumber":   field(graphql.String),
+			"voucherDate":     field(graphql.DateTime),
+			"voucherDateText": field(graphql.String),
+			"voucherItemId":   field(graphql.Int),
+			"debit":           field(amountType),
+			"credit":          field(amountType),
+			"balance":         field(amountType),
+			"currencyCode":    field(graphql.String),
+			"foreignDebit":    field(amountType),
+			"foreignCredit":   field(amountType),
+			"exchangeRate":    field(graphql.String),
+			"foreignBalance":  field(amountType),
+		},
+	})
+
+	ledgerCardType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LedgerCard",
+		Fields: graphql.Fields{
+			"sl": &graphql.Field{
+				Type: graphql.NewNonNull(slType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).sls.load(p.Source.(*dtos.LedgerCardDto).SLID), nil
+				},
+			},
+			"dl": &graphql.Field{
+				Type: dlType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadDL(p, p.Source.(*dtos.LedgerCardDto).DLID), nil
+				},
+			},
+			"rows":               field(graphql.NewList(graphql.NewNonNull(ledgerCardRowType))),
+			"totalDebit":         field(amountType),
+			"totalCredit":        field(amountType),
+			"balance":            field(amountType),
+			"currencyCode":       field(graphql.String),
+			"totalForeignDebit":  field(amountType),
+			"totalForeignCredit": field(amountType),
+			"foreignBalance":     field(amountType),
+		},
+	})
+
+	trialBalanceRowType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TrialBalanceRow",
+		Fields: graphql.Fields{
+			"sl": &graphql.Field{
+				Type: graphql.NewNonNull(slType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).sls.load(p.Source.(dtos.TrialBalanceRowDto).SLID), nil
+				},
+			},
+			"totalDebit":           field(amountType),
+			"totalCredit":          field(amountType),
+			"debitBalance":         field(amountType),
+			"creditBalance":        field(amountType),
+			"currencyCode":         field(graphql.String),
+			"totalForeignDebit":    field(amountType),
+			"totalForeignCredit":   field(amountType),
+			"foreignDebitBalance":  field(amountType),
+			"foreignCreditBalance": field(amountType),
+		},
+	})
+
+	trialBalanceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TrialBalance",
+		Fields: graphql.Fields{
+			"rows":               field(graphql.NewList(graphql.NewNonNull(trialBalanceRowType))),
+			"totalDebit":         field(amountType),
+			"totalCredit":        field(amountType),
+			"totalDebitBalance":  field(amountType),
+			"totalCreditBalance": field(amountType),
+		},
+	})
+
+	idArgs := graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.Int)}}
+	idsArgs := graphql.FieldConfigArgument{"ids": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))}}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"dl": &graphql.Field{
+				Type: dlType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).dls.load(p.Args["id"].(int)), nil
+				},
+			},
+			"dlByCode": &graphql.Field{
+				Type: dlType,
+				Args: graphql.FieldConfigArgument{"code": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dlDto, err := s.dlService.GetDLByCodeContext(p.Context, actorFrom(p.Context), &dl.GetByCodeRequest{Code: p.Args["code"].(string)})
+					if errors.Is(err, constants.ErrDLNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return *dlDto, nil
+				},
+			},
+			"dls": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dlType))),
+				Args: idsArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dlDtos, err := s.dlService.GetDLsContext(p.Context, actorFrom(p.Context), &dl.GetManyRequest{IDs: intsArg(p, "ids")})
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return dlDtos, nil
+				},
+			},
+			"sl": &graphql.Field{
+				Type: slType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).sls.load(p.Args["id"].(int)), nil
+				},
+			},
+			"slByCode": &graphql.Field{
+				Type: slType,
+				Args: graphql.FieldConfigArgument{"code": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					slDto, err := s.slService.GetSLByCodeContext(p.Context, actorFrom(p.Context), &sl.GetByCodeRequest{Code: p.Args["code"].(string)})
+					if errors.Is(err, constants.ErrSLNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return *slDto, nil
+				},
+			},
+			"sls": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(slType))),
+				Args: idsArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					slDtos, err := s.slService.GetSLsContext(p.Context, actorFrom(p.Context), &sl.GetManyRequest{IDs: intsArg(p, "ids")})
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return slDtos, nil
+				},
+			},
+			"voucher": &graphql.Field{
+				Type: voucherType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).vouchers.load(p.Args["id"].(int)), nil
+				},
+			},
+			"voucherByNumber": &graphql.Field{
+				Type: voucherType,
+				Args: graphql.FieldConfigArgument{"number": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					voucherDto, err := s.voucherService.GetVoucherByNumberContext(p.Context, actorFrom(p.Context), &voucher.GetByNumberRequest{Number: p.Args["number"].(string)})
+					if errors.Is(err, constants.ErrVoucherNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return *voucherDto, nil
+				},
+			},
+			"vouchers": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(voucherType))),
+				Args: idsArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					voucherDtos, err := s.voucherService.GetVouchersContext(p.Context, actorFrom(p.Context), &voucher.GetManyRequest{IDs: intsArg(p, "ids")})
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return voucherDtos, nil
+				},
+			},
+			"ledgerCard": &graphql.Field{
+				Type: graphql.NewNonNull(ledgerCardType),
+				Args: graphql.FieldConfigArgument{
+					"slId":         {Type: graphql.NewNonNull(graphql.Int)},
+					"dlId":         {Type: graphql.Int},
+					"currencyCode": {Type: graphql.String},
+					"calendar":     {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					req := &report.LedgerCardRequest{SLID: p.Args["slId"].(int)}
+					if dlID, ok := p.Args["dlId"].(int); ok {
+						req.DLID = &dlID
+					}
+					if currencyCode, ok := p.Args["currencyCode"].(string); ok {
+						req.CurrencyCode = &currencyCode
+					}
+					if ledgerCalendar, ok := p.Args["calendar"].(string); ok {
+						req.Calendar = calendar.Calendar(ledgerCalendar)
+					}
+					ledgerCardDto, err := s.reportService.GetLedgerCardContext(p.Context, actorFrom(p.Context), req)
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return ledgerCardDto, nil
+				},
+			},
+			"trialBalance": &graphql.Field{
+				Type: graphql.NewNonNull(trialBalanceType),
+				Args: graphql.FieldConfigArgument{"byCurrency": {Type: graphql.Boolean, DefaultValue: false}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					byCurrency, _ := p.Args["byCurrency"].(bool)
+					trialBalanceDto, err := s.reportService.GetTrialBalanceContext(p.Context, actorFrom(p.Context), &report.TrialBalanceRequest{ByCurrency: byCurrency})
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return trialBalanceDto, nil
+				},
+			},
+			"slBalances": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(slBalanceType))),
+				Args: graphql.FieldConfigArgument{"slIds": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					balances, err := s.reportService.GetSLBalancesContext(p.Context, actorFrom(p.Context), &report.SLBalancesRequest{SLIDs: intsArg(p, "slIds")})
+					if err != nil {
+						return nil, serviceError(err)
+					}
+					return balances, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// loadDL loads the DL of a line or ledger card, where 0 stands for none.
+func loadDL(p graphql.ResolveParams, dlID int) interface{} {
+	if dlID == 0 {
+		return nil
+	}
+	return loadersFrom(p.Context).dls.load(dlID)
+}
+
+func intsArg(p graphql.ResolveParams, name string) []int {
+	values, _ := p.Args[name].([]interface{})
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, value.(int))
+	}
+	return ints
+}
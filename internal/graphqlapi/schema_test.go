@@ -0,0 +1,161 @@
+package graphqlapi
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/db"
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/codetemplate"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/idempotency"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/tenant"
+	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/services"
+	"accountingsystem/internal/textrule"
+	"accountingsystem/internal/txretry"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testAdmin = auth.Principal{Username: "test-admin", Role: auth.Admin, TenantID: auth.DefaultTenantID}
+
+var (
+	testDBOnce sync.Once
+	testDB     *gorm.DB
+	testDBErr  error
+)
+
+// openTestDB configures the packages the services depend on and connects to
+// the test database once for the whole package. Unlike the loader tests, the
+// schema tests need the database.
+func openTestDB(t *testing.T) *gorm.DB {
+	testDBOnce.Do(func() {
+		if testDBErr = configs.InitConfig("../../.env.test"); testDBErr != nil {
+			return
+		}
+		for _, initPackage := range []func() error{money.Init, calendar.Init, textrule.Init, codetemplate.Init, txretry.Init, idempotency.Init} {
+			if testDBErr = initPackage(); testDBErr != nil {
+				return
+			}
+		}
+		testDB, testDBErr = db.Init()
+	})
+	require.Nil(t, testDBErr)
+	return testDB
+}
+
+func newTestServer(t *testing.T) *Server {
+	server := &Server{}
+	require.Nil(t, server.InitServer(openTestDB(t)))
+	return server
+}
+
+func randomSuffix(t *testing.T) string {
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	require.Nil(t, err)
+	return hex.EncodeToString(suffix)
+}
+
+func execute(t *testing.T, server *Server, actor auth.Principal, query string) map[string]interface{} {
+	result := server.Execute(context.Background(), actor, &Request{Query: query})
+	require.Empty(t, result.Errors)
+	return result.Data.(map[string]interface{})
+}
+
+// createTestVoucher creates a voucher whose first line is on an SL with a DL
+// and whose second line is on an SL without one.
+func createTestVoucher(t *testing.T, server *Server) (voucherID int, slWithDLID int, dlID int, slWithoutDLID int) {
+	dlDto, err := server.dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + randomSuffix(t), Title: "Test" + randomSuffix(t)})
+	require.Nil(t, err)
+	slWithDL, err := server.slService.CreateSL(testAdmin, &sl.InsertRequest{Code: "SL" + randomSuffix(t), Title: "Test" + randomSuffix(t), HasDL: true})
+	require.Nil(t, err)
+	slWithoutDL, err := server.slService.CreateSL(testAdmin, &sl.InsertRequest{Code: "SL" + randomSuffix(t), Title: "Test" + randomSuffix(t)})
+	require.Nil(t, err)
+	voucherDto, err := server.voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number: "V" + randomSuffix(t),
+		VoucherItems: []voucher.VoucherItemInsertDetail{
+			{SLID: slWithDL.ID, DLID: &dlDto.ID, Debit: 100},
+			{SLID: slWithoutDL.ID, Credit: 100},
+		},
+	})
+	require.Nil(t, err)
+	return voucherDto.ID, slWithDL.ID, dlDto.ID, slWithoutDL.ID
+}
+
+func Test_Execute_ResolvesSLAndDL_OfVoucherItems(t *testing.T) {
+	server := newTestServer(t)
+	voucherID, slWithDLID, dlID, slWithoutDLID := createTestVoucher(t, server)
+
+	data := execute(t, server, testAdmin, fmt.Sprintf("{ voucher(id: %d) { items { sl { id hasDL } dl { id } } } }", voucherID))
+
+	items := data["voucher"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 2)
+	first := items[0].(map[string]interface{})
+	assert.Equal(t, slWithDLID, first["sl"].(map[string]interface{})["id"])
+	assert.Equal(t, true, first["sl"].(map[string]interface{})["hasDL"])
+	assert.Equal(t, dlID, first["dl"].(map[string]interface{})["id"])
+	second := items[1].(map[string]interface{})
+	assert.Equal(t, slWithoutDLID, second["sl"].(map[string]interface{})["id"])
+	assert.Nil(t, second["dl"])
+}
+
+func Test_Execute_ResolvesBalance_OfSL(t *testing.T) {
+	server := newTestServer(t)
+	_, slWithDLID, _, _ := createTestVoucher(t, server)
+
+	data := execute(t, server, testAdmin, fmt.Sprintf("{ sl(id: %d) { balance { sl { id } totalDebit balance } } }", slWithDLID))
+
+	balance := data["sl"].(map[string]interface{})["balance"].(map[string]interface{})
+	assert.Equal(t, slWithDLID, balance["sl"].(map[string]interface{})["id"])
+	assert.Equal(t, int64(100), balance["totalDebit"])
+	assert.Equal(t, int64(100), balance["balance"])
+}
+
+func Test_Execute_ResolvesNothing_WithRecordsOfAnotherTenant(t *testing.T) {
+	server := newTestServer(t)
+	voucherID, slWithDLID, dlID, _ := createTestVoucher(t, server)
+	tenantService := &services.TenantService{}
+	tenantService.InitService(openTestDB(t))
+	tenantDto, err := tenantService.CreateTenant(testAdmin, &tenant.InsertRequest{Code: "T" + randomSuffix(t), Title: "Test" + randomSuffix(t)})
+	require.Nil(t, err)
+	otherTenantAdmin := auth.System(tenantDto.ID)
+
+	data := execute(t, server, otherTenantAdmin, fmt.Sprintf(
+		"{ voucher(id: %d) { id } sl(id: %d) { id } dl(id: %d) { id } sls(ids: [%d]) { id } dls(ids: [%d]) { id } }",
+		voucherID, slWithDLID, dlID, slWithDLID, dlID,
+	))
+
+	assert.Nil(t, data["voucher"])
+	assert.Nil(t, data["sl"])
+	assert.Nil(t, data["dl"])
+	assert.Empty(t, data["sls"])
+	assert.Empty(t, data["dls"])
+}
+
+func Test_Execute_ReportsTooManyIDs_WithMoreIDsThanTheLimit(t *testing.T) {
+	server := newTestServer(t)
+	ids := make([]interface{}, constants.MaxLookupIDs+1)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	result := server.Execute(context.Background(), testAdmin, &Request{
+		Query:     "query($ids: [Int!]!) { sls(ids: $ids) { id } }",
+		Variables: map[string]interface{}{"ids": ids},
+	})
+
+	require.NotEmpty(t, result.Errors)
+	assert.Equal(t, "TOO_MANY_IDS", result.Errors[0].Extensions["code"])
+}
@@ -0,0 +1,77 @@
+package graphqlapi
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/services"
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+)
+
+// Server answers read-only GraphQL queries on DLs, SLs, vouchers and
+// reports. Voucher lines resolve their SL and DL, and the records many rows
+// refer to are loaded in batches, one query per level of the result.
+type Server struct {
+	dlService      *services.DLService
+	slService      *services.SLService
+	voucherService *services.VoucherService
+	reportService  *services.ReportService
+	schema         graphql.Schema
+}
+
+// Request is a GraphQL request as clients post it. JSON keys are matched
+// case-insensitively, so {"query": ...} fills Query.
+type Request struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+}
+
+type actorKey struct{}
+
+type loadersKey struct{}
+
+func (s *Server) InitServer(db *gorm.DB) error {
+	s.dlService = &services.DLService{}
+	s.slService = &services.SLService{}
+	s.voucherService = &services.VoucherService{}
+	s.reportService = &services.ReportService{}
+
+	s.dlService.InitService(db)
+	s.slService.InitService(db)
+	s.voucherService.InitService(db)
+	s.reportService.InitService(db)
+
+	schema, err := s.buildSchema()
+	if err != nil {
+		return err
+	}
+	s.schema = schema
+	return nil
+}
+
+// Execute runs req on behalf of actor. Errors of single fields are reported
+// in the result next to the data that could be read.
+func (s *Server) Execute(ctx context.Context, actor auth.Principal, req *Request) *graphql.Result {
+	ctx = context.WithValue(ctx, actorKey{}, actor)
+	ctx = context.WithValue(ctx, loadersKey{}, s.newLoaders(ctx))
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        ctx,
+	})
+	addErrorCodes(result)
+	return result
+}
+
+func actorFrom(ctx context.Context) auth.Principal {
+	actor, _ := ctx.Value(actorKey{}).(auth.Principal)
+	return actor
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
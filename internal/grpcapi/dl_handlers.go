@@ -0,0 +1,74 @@
+package grpcapi
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/grpcapi/ledgerpb"
+	"accountingsystem/internal/requests/dl"
+	"context"
+)
+
+func (s *Server) CreateDL(ctx context.Context, req *ledgerpb.CreateDLRequest) (*ledgerpb.DL, error) {
+	dlDto, err := s.dlService.CreateDLContext(ctx, actorFrom(ctx), &dl.InsertRequest{
+		Code:           req.GetCode(),
+		Title:          req.GetTitle(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDLMessage(dlDto), nil
+}
+
+func (s *Server) UpdateDL(ctx context.Context, req *ledgerpb.UpdateDLRequest) (*ledgerpb.DL, error) {
+	dlDto, err := s.dlService.UpdateDLContext(ctx, actorFrom(ctx), &dl.UpdateRequest{
+		ID:      int(req.GetId()),
+		Code:    req.GetCode(),
+		Title:   req.GetTitle(),
+		Version: int(req.GetVersion()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDLMessage(dlDto), nil
+}
+
+func (s *Server) DeleteDL(ctx context.Context, req *ledgerpb.DeleteDLRequest) (*ledgerpb.DeleteDLResponse, error) {
+	err := s.dlService.DeleteDLContext(ctx, actorFrom(ctx), &dl.DeleteRequest{ID: int(req.GetId()), Version: int(req.GetVersion())})
+	if err != nil {
+		return nil, err
+	}
+	return &ledgerpb.DeleteDLResponse{}, nil
+}
+
+func (s *Server) GetDL(ctx context.Context, req *ledgerpb.GetDLRequest) (*ledgerpb.DL, error) {
+	dlDto, err := s.dlService.GetDLContext(ctx, actorFrom(ctx), &dl.GetRequest{ID: int(req.GetId())})
+	if err != nil {
+		return nil, err
+	}
+	return toDLMessage(dlDto), nil
+}
+
+func (s *Server) GetDLByCode(ctx context.Context, req *ledgerpb.GetDLByCodeRequest) (*ledgerpb.DL, error) {
+	dlDto, err := s.dlService.GetDLByCodeContext(ctx, actorFrom(ctx), &dl.GetByCodeRequest{Code: req.GetCode()})
+	if err != nil {
+		return nil, err
+	}
+	return toDLMessage(dlDto), nil
+}
+
+func (s *Server) NextDLCode(ctx context.Context, req *ledgerpb.NextDLCodeRequest) (*ledgerpb.NextCodeResponse, error) {
+	code, err := s.dlService.NextDLCodeContext(ctx, actorFrom(ctx), &dl.NextCodeRequest{ParentCode: req.GetParentCode()})
+	if err != nil {
+		return nil, err
+	}
+	return &ledgerpb.NextCodeResponse{Code: code}, nil
+}
+
+func toDLMessage(dlDto *dtos.DLDto) *ledgerpb.DL {
+	return &ledgerpb.DL{
+		Id:         int32(dlDto.ID),
+		Code:       dlDto.Code,
+		Title:      dlDto.Title,
+		RowVersion: int32(dlDto.RowVersion),
+	}
+}
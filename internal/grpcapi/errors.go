@@ -0,0 +1,178 @@
+package grpcapi
+
+import (
+	"accountingsystem/internal/constants"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const errorDomain = "accountingsystem"
+
+type errorStatus struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// errorStatuses maps the service errors that are not validation errors of
+// the request to a status code and the reason reported in the ErrorInfo
+// detail.
+var errorStatuses = []errorStatus{
+	{constants.ErrUnauthenticated, codes.Unauthenticated, "UNAUTHENTICATED"},
+	{constants.ErrForbidden, codes.PermissionDenied, "FORBIDDEN"},
+	{constants.ErrUnexpectedError, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrDeadlineExceeded, codes.DeadlineExceeded, "DEADLINE_EXCEEDED"},
+	{constants.ErrCanceled, codes.Canceled, "CANCELED"},
+
+	{constants.ErrDLNotFound, codes.NotFound, "DL_NOT_FOUND"},
+	{constants.ErrSLNotFound, codes.NotFound, "SL_NOT_FOUND"},
+	{constants.ErrVoucherNotFound, codes.NotFound, "VOUCHER_NOT_FOUND"},
+	{constants.ErrVoucherItemNotFound, codes.NotFound, "VOUCHER_ITEM_NOT_FOUND"},
+	{constants.ErrUserNotFound, codes.NotFound, "USER_NOT_FOUND"},
+	{constants.ErrCurrencyNotFound, codes.NotFound, "CURRENCY_NOT_FOUND"},
+	{constants.ErrExchangeRateNotFound, codes.NotFound, "EXCHANGE_RATE_NOT_FOUND"},
+	{constants.ErrNumberSequenceNotFound, codes.NotFound, "NUMBER_SEQUENCE_NOT_FOUND"},
+	{constants.ErrParentCodeNotFound, codes.NotFound, "PARENT_CODE_NOT_FOUND"},
+	{constants.ErrVoucherTemplateNotFound, codes.NotFound, "VOUCHER_TEMPLATE_NOT_FOUND"},
+	{constants.ErrApprovalRuleNotFound, codes.NotFound, "APPROVAL_RULE_NOT_FOUND"},
+	{constants.ErrTenantNotFound, codes.NotFound, "TENANT_NOT_FOUND"},
+	{constants.ErrWebhookNotFound, codes.NotFound, "WEBHOOK_NOT_FOUND"},
+	{constants.ErrWebhookDeliveryNotFound, codes.NotFound, "WEBHOOK_DELIVERY_NOT_FOUND"},
+
+	{constants.ErrCodeAlreadyExists, codes.AlreadyExists, "CODE_ALREADY_EXISTS"},
+	{constants.ErrTitleAlreadyExists, codes.AlreadyExists, "TITLE_ALREADY_EXISTS"},
+	{constants.ErrVoucherNumberExists, codes.AlreadyExists, "VOUCHER_NUMBER_EXISTS"},
+	{constants.ErrUsernameAlreadyExists, codes.AlreadyExists, "USERNAME_ALREADY_EXISTS"},
+
+	{constants.ErrVersionOutdated, codes.Aborted, "VERSION_OUTDATED"},
+	{constants.ErrTransactionConflict, codes.Aborted, "TRANSACTION_CONFLICT"},
+
+	{constants.ErrThereIsRefrenceToDL, codes.FailedPrecondition, "DL_IS_REFERENCED"},
+	{constants.ErrThereIsRefrenceToSL, codes.FailedPrecondition, "SL_IS_REFERENCED"},
+	{constants.ErrThereIsRefrenceToCurrency, codes.FailedPrecondition, "CURRENCY_IS_REFERENCED"},
+	{constants.ErrSLArchived, codes.FailedPrecondition, "SL_ARCHIVED"},
+	{constants.ErrCodeHasChildren, codes.FailedPrecondition, "CODE_HAS_CHILDREN"},
+	{constants.ErrVoucherAlreadyReversed, codes.FailedPrecondition, "VOUCHER_ALREADY_REVERSED"},
+	{constants.ErrInvalidVoucherStatus, codes.FailedPrecondition, "INVALID_VOUCHER_STATUS"},
+	{constants.ErrAlreadyApproved, codes.FailedPrecondition, "ALREADY_APPROVED"},
+	{constants.ErrSelfApproval, codes.PermissionDenied, "SELF_APPROVAL"},
+	{constants.ErrIdempotencyKeyReused, codes.FailedPrecondition, "IDEMPOTENCY_KEY_REUSED"},
+	{constants.ErrCodeTemplateNotConfigured, codes.FailedPrecondition, "CODE_TEMPLATE_NOT_CONFIGURED"},
+	{constants.ErrNoFreeCode, codes.FailedPrecondition, "NO_FREE_CODE"},
+	{constants.ErrNothingToRevalue, codes.FailedPrecondition, "NOTHING_TO_REVALUE"},
+
+	{constants.ErrAmountOverflow, codes.OutOfRange, "AMOUNT_OVERFLOW"},
+
+	// Configuration errors stop the server from starting and never answer a
+	// call, but are listed so that none of them passes for a bad request.
+	{constants.ErrEnvNotFound, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrInvalidMinorUnits, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrInvalidTextRule, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrInvalidCodeTemplate, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrInvalidTxIsolation, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrInvalidTxRetry, codes.Internal, "UNEXPECTED_ERROR"},
+	{constants.ErrInvalidIdempotencyRetention, codes.Internal, "UNEXPECTED_ERROR"},
+}
+
+// invalidFields names the request field a validation error is about in the
+// BadRequest detail. Errors about a voucher line name the field of the line,
+// and an empty field stands for the request as a whole.
+var invalidFields = []struct {
+	err   error
+	field string
+}{
+	{constants.ErrMalformedRequest, ""},
+	{constants.ErrCodeEmptyOrTooLong, "code"},
+	{constants.ErrCodeFormatInvalid, "code"},
+	{constants.ErrCodeTemplateMismatch, "code"},
+	{constants.ErrTitleEmptyOrTooLong, "title"},
+	{constants.ErrTitleFormatInvalid, "title"},
+	{constants.ErrNumberEmptyOrTooLong, "number"},
+	{constants.ErrNumberFormatInvalid, "number"},
+	{constants.ErrVoucherNumberReserved, "number"},
+	{constants.ErrInvalidDate, "date_input.date_text"},
+	{constants.ErrInvalidCalendar, "date_input.calendar"},
+	{constants.ErrInvalidExportFormat, "format"},
+	{constants.ErrIdempotencyKeyTooLong, "idempotency_key"},
+	{constants.ErrTooManyIDs, "ids"},
+	{constants.ErrVoucherItemsCountOutOfRange, "voucher_items"},
+	{constants.ErrDebitCreditMismatch, "voucher_items"},
+	{constants.ErrDebitOrCreditInvalid, "debit"},
+	{constants.ErrInvalidAmount, "debit"},
+	{constants.ErrSLReferenceMismatch, "sl_code"},
+	{constants.ErrDLReferenceMismatch, "dl_code"},
+	{constants.ErrDLIDRequired, "dl_id"},
+	{constants.ErrDLNotAllowed, "dl_id"},
+	{constants.ErrCurrencyDetailsInvalid, "currency.currency_code"},
+	{constants.ErrInvalidCurrencyCode, "currency.currency_code"},
+	{constants.ErrForeignAmountRequired, "currency.foreign_debit"},
+	{constants.ErrFunctionalAmountMismatch, "currency.exchange_rate"},
+	{constants.ErrInvalidExchangeRate, "currency.exchange_rate"},
+	{constants.ErrPrefixTooLong, "prefix"},
+	{constants.ErrPaddingOutOfRange, "padding"},
+	{constants.ErrFiscalStartMonthOutOfRange, "fiscal_start_month"},
+	{constants.ErrInvalidCronExpression, "cron"},
+	{constants.ErrInvalidFrequency, "frequency"},
+	{constants.ErrScheduleStartRequired, "schedule_start"},
+	{constants.ErrInvalidTemplateItemSide, "side"},
+	{constants.ErrTemplateItemAmountInvalid, "amount"},
+	{constants.ErrInvalidFormula, "formula"},
+	{constants.ErrUsernameEmptyOrTooLong, "username"},
+	{constants.ErrInvalidRole, "role"},
+	{constants.ErrApprovalConditionRequired, "min_amount"},
+	{constants.ErrMinAmountNotPositive, "min_amount"},
+	{constants.ErrRequiredApprovalsInvalid, "required_approvals"},
+	{constants.ErrCommentTooLong, "comment"},
+	{constants.ErrInvalidWebhookURL, "url"},
+	{constants.ErrWebhookEventTypesEmpty, "event_types"},
+	{constants.ErrUnknownEventType, "event_types"},
+	{constants.ErrInvalidWebhookSecret, "secret"},
+	{constants.ErrInvalidDeliveryStatus, "status"},
+}
+
+// statusError turns a service error into a gRPC status error carrying an
+// ErrorInfo detail. Errors that are not listed in errorStatuses are
+// validation errors of the request and also carry a BadRequest detail naming
+// the field when it is known.
+func statusError(err error) error {
+	code, reason := codes.InvalidArgument, "INVALID_REQUEST"
+	for _, errStatus := range errorStatuses {
+		if errors.Is(err, errStatus.err) {
+			code, reason = errStatus.code, errStatus.reason
+			break
+		}
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: map[string]string{"error": err.Error()},
+	}}
+	if code == codes.InvalidArgument {
+		if field := invalidField(err); field != "" {
+			details = append(details, &errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: err.Error()}},
+			})
+		}
+	}
+
+	st := status.New(code, err.Error())
+	detailed, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func invalidField(err error) string {
+	for _, invalid := range invalidFields {
+		if errors.Is(err, invalid.err) {
+			return invalid.field
+		}
+	}
+	return ""
+}
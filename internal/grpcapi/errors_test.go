@@ -0,0 +1,160 @@
+package grpcapi
+
+import (
+	"accountingsystem/internal/constants"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var statusErrorTests = []struct {
+	name   string
+	err    error
+	code   codes.Code
+	reason string
+	field  string
+}{
+	{"ErrUnexpectedError", constants.ErrUnexpectedError, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrEnvNotFound", constants.ErrEnvNotFound, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrCodeEmptyOrTooLong", constants.ErrCodeEmptyOrTooLong, codes.InvalidArgument, "INVALID_REQUEST", "code"},
+	{"ErrTitleEmptyOrTooLong", constants.ErrTitleEmptyOrTooLong, codes.InvalidArgument, "INVALID_REQUEST", "title"},
+	{"ErrCodeAlreadyExists", constants.ErrCodeAlreadyExists, codes.AlreadyExists, "CODE_ALREADY_EXISTS", ""},
+	{"ErrTitleAlreadyExists", constants.ErrTitleAlreadyExists, codes.AlreadyExists, "TITLE_ALREADY_EXISTS", ""},
+	{"ErrDLNotFound", constants.ErrDLNotFound, codes.NotFound, "DL_NOT_FOUND", ""},
+	{"ErrVersionOutdated", constants.ErrVersionOutdated, codes.Aborted, "VERSION_OUTDATED", ""},
+	{"ErrSLNotFound", constants.ErrSLNotFound, codes.NotFound, "SL_NOT_FOUND", ""},
+	{"ErrNumberEmptyOrTooLong", constants.ErrNumberEmptyOrTooLong, codes.InvalidArgument, "INVALID_REQUEST", "number"},
+	{"ErrVoucherNumberExists", constants.ErrVoucherNumberExists, codes.AlreadyExists, "VOUCHER_NUMBER_EXISTS", ""},
+	{"ErrVoucherItemsCountOutOfRange", constants.ErrVoucherItemsCountOutOfRange, codes.InvalidArgument, "INVALID_REQUEST", "voucher_items"},
+	{"ErrDebitOrCreditInvalid", constants.ErrDebitOrCreditInvalid, codes.InvalidArgument, "INVALID_REQUEST", "debit"},
+	{"ErrDLIDRequired", constants.ErrDLIDRequired, codes.InvalidArgument, "INVALID_REQUEST", "dl_id"},
+	{"ErrDLNotAllowed", constants.ErrDLNotAllowed, codes.InvalidArgument, "INVALID_REQUEST", "dl_id"},
+	{"ErrDebitCreditMismatch", constants.ErrDebitCreditMismatch, codes.InvalidArgument, "INVALID_REQUEST", "voucher_items"},
+	{"ErrThereIsRefrenceToDL", constants.ErrThereIsRefrenceToDL, codes.FailedPrecondition, "DL_IS_REFERENCED", ""},
+	{"ErrThereIsRefrenceToSL", constants.ErrThereIsRefrenceToSL, codes.FailedPrecondition, "SL_IS_REFERENCED", ""},
+	{"ErrVoucherItemNotFound", constants.ErrVoucherItemNotFound, codes.NotFound, "VOUCHER_ITEM_NOT_FOUND", ""},
+	{"ErrVoucherNotFound", constants.ErrVoucherNotFound, codes.NotFound, "VOUCHER_NOT_FOUND", ""},
+	{"ErrSLReferenceMismatch", constants.ErrSLReferenceMismatch, codes.InvalidArgument, "INVALID_REQUEST", "sl_code"},
+	{"ErrDLReferenceMismatch", constants.ErrDLReferenceMismatch, codes.InvalidArgument, "INVALID_REQUEST", "dl_code"},
+	{"ErrNumberSequenceNotFound", constants.ErrNumberSequenceNotFound, codes.NotFound, "NUMBER_SEQUENCE_NOT_FOUND", ""},
+	{"ErrPrefixTooLong", constants.ErrPrefixTooLong, codes.InvalidArgument, "INVALID_REQUEST", "prefix"},
+	{"ErrPaddingOutOfRange", constants.ErrPaddingOutOfRange, codes.InvalidArgument, "INVALID_REQUEST", "padding"},
+	{"ErrFiscalStartMonthOutOfRange", constants.ErrFiscalStartMonthOutOfRange, codes.InvalidArgument, "INVALID_REQUEST", "fiscal_start_month"},
+	{"ErrVoucherAlreadyReversed", constants.ErrVoucherAlreadyReversed, codes.FailedPrecondition, "VOUCHER_ALREADY_REVERSED", ""},
+	{"ErrInvalidCronExpression", constants.ErrInvalidCronExpression, codes.InvalidArgument, "INVALID_REQUEST", "cron"},
+	{"ErrInvalidFormula", constants.ErrInvalidFormula, codes.InvalidArgument, "INVALID_REQUEST", "formula"},
+	{"ErrInvalidExportFormat", constants.ErrInvalidExportFormat, codes.InvalidArgument, "INVALID_REQUEST", "format"},
+	{"ErrVoucherTemplateNotFound", constants.ErrVoucherTemplateNotFound, codes.NotFound, "VOUCHER_TEMPLATE_NOT_FOUND", ""},
+	{"ErrInvalidFrequency", constants.ErrInvalidFrequency, codes.InvalidArgument, "INVALID_REQUEST", "frequency"},
+	{"ErrScheduleStartRequired", constants.ErrScheduleStartRequired, codes.InvalidArgument, "INVALID_REQUEST", "schedule_start"},
+	{"ErrInvalidTemplateItemSide", constants.ErrInvalidTemplateItemSide, codes.InvalidArgument, "INVALID_REQUEST", "side"},
+	{"ErrTemplateItemAmountInvalid", constants.ErrTemplateItemAmountInvalid, codes.InvalidArgument, "INVALID_REQUEST", "amount"},
+	{"ErrCurrencyNotFound", constants.ErrCurrencyNotFound, codes.NotFound, "CURRENCY_NOT_FOUND", ""},
+	{"ErrInvalidCurrencyCode", constants.ErrInvalidCurrencyCode, codes.InvalidArgument, "INVALID_REQUEST", "currency.currency_code"},
+	{"ErrInvalidExchangeRate", constants.ErrInvalidExchangeRate, codes.InvalidArgument, "INVALID_REQUEST", "currency.exchange_rate"},
+	{"ErrExchangeRateNotFound", constants.ErrExchangeRateNotFound, codes.NotFound, "EXCHANGE_RATE_NOT_FOUND", ""},
+	{"ErrCurrencyDetailsInvalid", constants.ErrCurrencyDetailsInvalid, codes.InvalidArgument, "INVALID_REQUEST", "currency.currency_code"},
+	{"ErrFunctionalAmountMismatch", constants.ErrFunctionalAmountMismatch, codes.InvalidArgument, "INVALID_REQUEST", "currency.exchange_rate"},
+	{"ErrThereIsRefrenceToCurrency", constants.ErrThereIsRefrenceToCurrency, codes.FailedPrecondition, "CURRENCY_IS_REFERENCED", ""},
+	{"ErrNothingToRevalue", constants.ErrNothingToRevalue, codes.FailedPrecondition, "NOTHING_TO_REVALUE", ""},
+	{"ErrAmountOverflow", constants.ErrAmountOverflow, codes.OutOfRange, "AMOUNT_OVERFLOW", ""},
+	{"ErrInvalidAmount", constants.ErrInvalidAmount, codes.InvalidArgument, "INVALID_REQUEST", "debit"},
+	{"ErrInvalidMinorUnits", constants.ErrInvalidMinorUnits, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrInvalidCalendar", constants.ErrInvalidCalendar, codes.InvalidArgument, "INVALID_REQUEST", "date_input.calendar"},
+	{"ErrInvalidDate", constants.ErrInvalidDate, codes.InvalidArgument, "INVALID_REQUEST", "date_input.date_text"},
+	{"ErrCodeFormatInvalid", constants.ErrCodeFormatInvalid, codes.InvalidArgument, "INVALID_REQUEST", "code"},
+	{"ErrTitleFormatInvalid", constants.ErrTitleFormatInvalid, codes.InvalidArgument, "INVALID_REQUEST", "title"},
+	{"ErrNumberFormatInvalid", constants.ErrNumberFormatInvalid, codes.InvalidArgument, "INVALID_REQUEST", "number"},
+	{"ErrInvalidTextRule", constants.ErrInvalidTextRule, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrInvalidCodeTemplate", constants.ErrInvalidCodeTemplate, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrCodeTemplateMismatch", constants.ErrCodeTemplateMismatch, codes.InvalidArgument, "INVALID_REQUEST", "code"},
+	{"ErrCodeTemplateNotConfigured", constants.ErrCodeTemplateNotConfigured, codes.FailedPrecondition, "CODE_TEMPLATE_NOT_CONFIGURED", ""},
+	{"ErrParentCodeNotFound", constants.ErrParentCodeNotFound, codes.NotFound, "PARENT_CODE_NOT_FOUND", ""},
+	{"ErrCodeHasChildren", constants.ErrCodeHasChildren, codes.FailedPrecondition, "CODE_HAS_CHILDREN", ""},
+	{"ErrNoFreeCode", constants.ErrNoFreeCode, codes.FailedPrecondition, "NO_FREE_CODE", ""},
+	{"ErrForbidden", constants.ErrForbidden, codes.PermissionDenied, "FORBIDDEN", ""},
+	{"ErrUnauthenticated", constants.ErrUnauthenticated, codes.Unauthenticated, "UNAUTHENTICATED", ""},
+	{"ErrUserNotFound", constants.ErrUserNotFound, codes.NotFound, "USER_NOT_FOUND", ""},
+	{"ErrUsernameEmptyOrTooLong", constants.ErrUsernameEmptyOrTooLong, codes.InvalidArgument, "INVALID_REQUEST", "username"},
+	{"ErrUsernameAlreadyExists", constants.ErrUsernameAlreadyExists, codes.AlreadyExists, "USERNAME_ALREADY_EXISTS", ""},
+	{"ErrInvalidRole", constants.ErrInvalidRole, codes.InvalidArgument, "INVALID_REQUEST", "role"},
+	{"ErrMalformedRequest", constants.ErrMalformedRequest, codes.InvalidArgument, "INVALID_REQUEST", ""},
+	{"ErrTenantNotFound", constants.ErrTenantNotFound, codes.NotFound, "TENANT_NOT_FOUND", ""},
+	{"ErrApprovalRuleNotFound", constants.ErrApprovalRuleNotFound, codes.NotFound, "APPROVAL_RULE_NOT_FOUND", ""},
+	{"ErrApprovalConditionRequired", constants.ErrApprovalConditionRequired, codes.InvalidArgument, "INVALID_REQUEST", "min_amount"},
+	{"ErrMinAmountNotPositive", constants.ErrMinAmountNotPositive, codes.InvalidArgument, "INVALID_REQUEST", "min_amount"},
+	{"ErrRequiredApprovalsInvalid", constants.ErrRequiredApprovalsInvalid, codes.InvalidArgument, "INVALID_REQUEST", "required_approvals"},
+	{"ErrInvalidVoucherStatus", constants.ErrInvalidVoucherStatus, codes.FailedPrecondition, "INVALID_VOUCHER_STATUS", ""},
+	{"ErrSelfApproval", constants.ErrSelfApproval, codes.PermissionDenied, "SELF_APPROVAL", ""},
+	{"ErrAlreadyApproved", constants.ErrAlreadyApproved, codes.FailedPrecondition, "ALREADY_APPROVED", ""},
+	{"ErrCommentTooLong", constants.ErrCommentTooLong, codes.InvalidArgument, "INVALID_REQUEST", "comment"},
+	{"ErrDeadlineExceeded", constants.ErrDeadlineExceeded, codes.DeadlineExceeded, "DEADLINE_EXCEEDED", ""},
+	{"ErrCanceled", constants.ErrCanceled, codes.Canceled, "CANCELED", ""},
+	{"ErrInvalidTxIsolation", constants.ErrInvalidTxIsolation, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrInvalidTxRetry", constants.ErrInvalidTxRetry, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrTransactionConflict", constants.ErrTransactionConflict, codes.Aborted, "TRANSACTION_CONFLICT", ""},
+	{"ErrIdempotencyKeyTooLong", constants.ErrIdempotencyKeyTooLong, codes.InvalidArgument, "INVALID_REQUEST", "idempotency_key"},
+	{"ErrIdempotencyKeyReused", constants.ErrIdempotencyKeyReused, codes.FailedPrecondition, "IDEMPOTENCY_KEY_REUSED", ""},
+	{"ErrInvalidIdempotencyRetention", constants.ErrInvalidIdempotencyRetention, codes.Internal, "UNEXPECTED_ERROR", ""},
+	{"ErrWebhookNotFound", constants.ErrWebhookNotFound, codes.NotFound, "WEBHOOK_NOT_FOUND", ""},
+	{"ErrWebhookDeliveryNotFound", constants.ErrWebhookDeliveryNotFound, codes.NotFound, "WEBHOOK_DELIVERY_NOT_FOUND", ""},
+	{"ErrInvalidWebhookURL", constants.ErrInvalidWebhookURL, codes.InvalidArgument, "INVALID_REQUEST", "url"},
+	{"ErrWebhookEventTypesEmpty", constants.ErrWebhookEventTypesEmpty, codes.InvalidArgument, "INVALID_REQUEST", "event_types"},
+	{"ErrUnknownEventType", constants.ErrUnknownEventType, codes.InvalidArgument, "INVALID_REQUEST", "event_types"},
+	{"ErrInvalidWebhookSecret", constants.ErrInvalidWebhookSecret, codes.InvalidArgument, "INVALID_REQUEST", "secret"},
+	{"ErrInvalidDeliveryStatus", constants.ErrInvalidDeliveryStatus, codes.InvalidArgument, "INVALID_REQUEST", "status"},
+	{"ErrTooManyIDs", constants.ErrTooManyIDs, codes.InvalidArgument, "INVALID_REQUEST", "ids"},
+	{"ErrVoucherNumberReserved", constants.ErrVoucherNumberReserved, codes.InvalidArgument, "INVALID_REQUEST", "number"},
+	{"ErrForeignAmountRequired", constants.ErrForeignAmountRequired, codes.InvalidArgument, "INVALID_REQUEST", "currency.foreign_debit"},
+	{"ErrSLArchived", constants.ErrSLArchived, codes.FailedPrecondition, "SL_ARCHIVED", ""},
+}
+
+func Test_StatusError_MapsEveryServiceError(t *testing.T) {
+	for _, test := range statusErrorTests {
+		st := status.Convert(statusError(fmt.Errorf("wrapped: %w", test.err)))
+
+		assert.Equal(t, test.code, st.Code(), test.name)
+		var reason, field string
+		for _, detail := range st.Details() {
+			switch detail := detail.(type) {
+			case *errdetails.ErrorInfo:
+				reason = detail.Reason
+			case *errdetails.BadRequest:
+				require.Len(t, detail.FieldViolations, 1, test.name)
+				field = detail.FieldViolations[0].Field
+				assert.Contains(t, detail.FieldViolations[0].Description, test.err.Error(), test.name)
+			}
+		}
+		assert.Equal(t, test.reason, reason, test.name)
+		assert.Equal(t, test.field, field, test.name)
+	}
+}
+
+func Test_StatusErrorTests_CoverEveryErrorConstant(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../constants/errors.go", nil, 0)
+	require.Nil(t, err)
+	tested := make(map[string]bool, len(statusErrorTests))
+	for _, test := range statusErrorTests {
+		tested[test.name] = true
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				assert.True(t, tested[name.Name], name.Name)
+			}
+		}
+	}
+}
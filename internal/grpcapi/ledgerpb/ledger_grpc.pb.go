@@ -0,0 +1,1133 @@
+// The ledger API over gRPC. It mirrors the DL, SL and voucher services of the
+// HTTP API; every call needs an "authorization: Bearer <token>" metadata
+// entry. Failed calls carry a google.rpc.ErrorInfo detail whose reason names
+// the error and whose "error" metadata holds its message.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: ledger/v1/ledger.proto
+
+package ledgerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	DLService_CreateDL_FullMethodName    = "/accountingsystem.ledger.v1.DLService/CreateDL"
+	DLService_UpdateDL_FullMethodName    = "/accountingsystem.ledger.v1.DLService/UpdateDL"
+	DLService_DeleteDL_FullMethodName    = "/accountingsystem.ledger.v1.DLService/DeleteDL"
+	DLService_GetDL_FullMethodName       = "/accountingsystem.ledger.v1.DLService/GetDL"
+	DLService_GetDLByCode_FullMethodName = "/accountingsystem.ledger.v1.DLService/GetDLByCode"
+	DLService_NextDLCode_FullMethodName  = "/accountingsystem.ledger.v1.DLService/NextDLCode"
+)
+
+// DLServiceClient is the client API for DLService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DLServiceClient interface {
+	CreateDL(ctx context.Context, in *CreateDLRequest, opts ...grpc.CallOption) (*DL, error)
+	UpdateDL(ctx context.Context, in *UpdateDLRequest, opts ...grpc.CallOption) (*DL, error)
+	DeleteDL(ctx context.Context, in *DeleteDLRequest, opts ...grpc.CallOption) (*DeleteDLResponse, error)
+	GetDL(ctx context.Context, in *GetDLRequest, opts ...grpc.CallOption) (*DL, error)
+	GetDLByCode(ctx context.Context, in *GetDLByCodeRequest, opts ...grpc.CallOption) (*DL, error)
+	NextDLCode(ctx context.Context, in *NextDLCodeRequest, opts ...grpc.CallOption) (*NextCodeResponse, error)
+}
+
+type dLServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDLServiceClient(cc grpc.ClientConnInterface) DLServiceClient {
+	return &dLServiceClient{cc}
+}
+
+func (c *dLServiceClient) CreateDL(ctx context.Context, in *CreateDLRequest, opts ...grpc.CallOption) (*DL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DL)
+	err := c.cc.Invoke(ctx, DLService_CreateDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dLServiceClient) UpdateDL(ctx context.Context, in *UpdateDLRequest, opts ...grpc.CallOption) (*DL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DL)
+	err := c.cc.Invoke(ctx, DLService_UpdateDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dLServiceClient) DeleteDL(ctx context.Context, in *DeleteDLRequest, opts ...grpc.CallOption) (*DeleteDLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDLResponse)
+	err := c.cc.Invoke(ctx, DLService_DeleteDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dLServiceClient) GetDL(ctx context.Context, in *GetDLRequest, opts ...grpc.CallOption) (*DL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DL)
+	err := c.cc.Invoke(ctx, DLService_GetDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dLServiceClient) GetDLByCode(ctx context.Context, in *GetDLByCodeRequest, opts ...grpc.CallOption) (*DL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DL)
+	err := c.cc.Invoke(ctx, DLService_GetDLByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dLServiceClient) NextDLCode(ctx context.Context, in *NextDLCodeRequest, opts ...grpc.CallOption) (*NextCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextCodeResponse)
+	err := c.cc.Invoke(ctx, DLService_NextDLCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DLServiceServer is the server API for DLService service.
+// All implementations must embed UnimplementedDLServiceServer
+// for forward compatibility
+type DLServiceServer interface {
+	CreateDL(context.Context, *CreateDLRequest) (*DL, error)
+	UpdateDL(context.Context, *UpdateDLRequest) (*DL, error)
+	DeleteDL(context.Context, *DeleteDLRequest) (*DeleteDLResponse, error)
+	GetDL(context.Context, *GetDLRequest) (*DL, error)
+	GetDLByCode(context.Context, *GetDLByCodeRequest) (*DL, error)
+	NextDLCode(context.Context, *NextDLCodeRequest) (*NextCodeResponse, error)
+	mustEmbedUnimplementedDLServiceServer()
+}
+
+// UnimplementedDLServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDLServiceServer struct {
+}
+
+func (UnimplementedDLServiceServer) CreateDL(context.Context, *CreateDLRequest) (*DL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDL not implemented")
+}
+func (UnimplementedDLServiceServer) UpdateDL(context.Context, *UpdateDLRequest) (*DL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDL not implemented")
+}
+func (UnimplementedDLServiceServer) DeleteDL(context.Context, *DeleteDLRequest) (*DeleteDLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDL not implemented")
+}
+func (UnimplementedDLServiceServer) GetDL(context.Context, *GetDLRequest) (*DL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDL not implemented")
+}
+func (UnimplementedDLServiceServer) GetDLByCode(context.Context, *GetDLByCodeRequest) (*DL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDLByCode not implemented")
+}
+func (UnimplementedDLServiceServer) NextDLCode(context.Context, *NextDLCodeRequest) (*NextCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextDLCode not implemented")
+}
+func (UnimplementedDLServiceServer) mustEmbedUnimplementedDLServiceServer() {}
+
+// UnsafeDLServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DLServiceServer will
+// result in compilation errors.
+type UnsafeDLServiceServer interface {
+	mustEmbedUnimplementedDLServiceServer()
+}
+
+func RegisterDLServiceServer(s grpc.ServiceRegistrar, srv DLServiceServer) {
+	s.RegisterService(&DLService_ServiceDesc, srv)
+}
+
+func _DLService_CreateDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DLServiceServer).CreateDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DLService_CreateDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DLServiceServer).CreateDL(ctx, req.(*CreateDLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DLService_UpdateDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DLServiceServer).UpdateDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DLService_UpdateDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DLServiceServer).UpdateDL(ctx, req.(*UpdateDLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DLService_DeleteDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DLServiceServer).DeleteDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DLService_DeleteDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DLServiceServer).DeleteDL(ctx, req.(*DeleteDLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DLService_GetDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DLServiceServer).GetDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DLService_GetDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DLServiceServer).GetDL(ctx, req.(*GetDLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DLService_GetDLByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDLByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DLServiceServer).GetDLByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DLService_GetDLByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DLServiceServer).GetDLByCode(ctx, req.(*GetDLByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DLService_NextDLCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextDLCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DLServiceServer).NextDLCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DLService_NextDLCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DLServiceServer).NextDLCode(ctx, req.(*NextDLCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DLService_ServiceDesc is the grpc.ServiceDesc for DLService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DLService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "accountingsystem.ledger.v1.DLService",
+	HandlerType: (*DLServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDL",
+			Handler:    _DLService_CreateDL_Handler,
+		},
+		{
+			MethodName: "UpdateDL",
+			Handler:    _DLService_UpdateDL_Handler,
+		},
+		{
+			MethodName: "DeleteDL",
+			Handler:    _DLService_DeleteDL_Handler,
+		},
+		{
+			MethodName: "GetDL",
+			Handler:    _DLService_GetDL_Handler,
+		},
+		{
+			MethodName: "GetDLByCode",
+			Handler:    _DLService_GetDLByCode_Handler,
+		},
+		{
+			MethodName: "NextDLCode",
+			Handler:    _DLService_NextDLCode_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ledger/v1/ledger.proto",
+}
+
+const (
+	SLService_CreateSL_FullMethodName    = "/accountingsystem.ledger.v1.SLService/CreateSL"
+	SLService_UpdateSL_FullMethodName    = "/accountingsystem.ledger.v1.SLService/UpdateSL"
+	SLService_DeleteSL_FullMethodName    = "/accountingsystem.ledger.v1.SLService/DeleteSL"
+	SLService_GetSL_FullMethodName       = "/accountingsystem.ledger.v1.SLService/GetSL"
+	SLService_GetSLByCode_FullMethodName = "/accountingsystem.ledger.v1.SLService/GetSLByCode"
+	SLService_NextSLCode_FullMethodName  = "/accountingsystem.ledger.v1.SLService/NextSLCode"
+)
+
+// SLServiceClient is the client API for SLService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SLServiceClient interface {
+	CreateSL(ctx context.Context, in *CreateSLRequest, opts ...grpc.CallOption) (*SL, error)
+	UpdateSL(ctx context.Context, in *UpdateSLRequest, opts ...grpc.CallOption) (*SL, error)
+	DeleteSL(ctx context.Context, in *DeleteSLRequest, opts ...grpc.CallOption) (*DeleteSLResponse, error)
+	GetSL(ctx context.Context, in *GetSLRequest, opts ...grpc.CallOption) (*SL, error)
+	GetSLByCode(ctx context.Context, in *GetSLByCodeRequest, opts ...grpc.CallOption) (*SL, error)
+	NextSLCode(ctx context.Context, in *NextSLCodeRequest, opts ...grpc.CallOption) (*NextCodeResponse, error)
+}
+
+type sLServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSLServiceClient(cc grpc.ClientConnInterface) SLServiceClient {
+	return &sLServiceClient{cc}
+}
+
+func (c *sLServiceClient) CreateSL(ctx context.Context, in *CreateSLRequest, opts ...grpc.CallOption) (*SL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SL)
+	err := c.cc.Invoke(ctx, SLService_CreateSL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sLServiceClient) UpdateSL(ctx context.Context, in *UpdateSLRequest, opts ...grpc.CallOption) (*SL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SL)
+	err := c.cc.Invoke(ctx, SLService_UpdateSL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sLServiceClient) DeleteSL(ctx context.Context, in *DeleteSLRequest, opts ...grpc.CallOption) (*DeleteSLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSLResponse)
+	err := c.cc.Invoke(ctx, SLService_DeleteSL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sLServiceClient) GetSL(ctx context.Context, in *GetSLRequest, opts ...grpc.CallOption) (*SL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SL)
+	err := c.cc.Invoke(ctx, SLService_GetSL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sLServiceClient) GetSLByCode(ctx context.Context, in *GetSLByCodeRequest, opts ...grpc.CallOption) (*SL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SL)
+	err := c.cc.Invoke(ctx, SLService_GetSLByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sLServiceClient) NextSLCode(ctx context.Context, in *NextSLCodeRequest, opts ...grpc.CallOption) (*NextCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextCodeResponse)
+	err := c.cc.Invoke(ctx, SLService_NextSLCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SLServiceServer is the server API for SLService service.
+// All implementations must embed UnimplementedSLServiceServer
+// for forward compatibility
+type SLServiceServer interface {
+	CreateSL(context.Context, *CreateSLRequest) (*SL, error)
+	UpdateSL(context.Context, *UpdateSLRequest) (*SL, error)
+	DeleteSL(context.Context, *DeleteSLRequest) (*DeleteSLResponse, error)
+	GetSL(context.Context, *GetSLRequest) (*SL, error)
+	GetSLByCode(context.Context, *GetSLByCodeRequest) (*SL, error)
+	NextSLCode(context.Context, *NextSLCodeRequest) (*NextCodeResponse, error)
+	mustEmbedUnimplementedSLServiceServer()
+}
+
+// UnimplementedSLServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSLServiceServer struct {
+}
+
+func (UnimplementedSLServiceServer) CreateSL(context.Context, *CreateSLRequest) (*SL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSL not implemented")
+}
+func (UnimplementedSLServiceServer) UpdateSL(context.Context, *UpdateSLRequest) (*SL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSL not implemented")
+}
+func (UnimplementedSLServiceServer) DeleteSL(context.Context, *DeleteSLRequest) (*DeleteSLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSL not implemented")
+}
+func (UnimplementedSLServiceServer) GetSL(context.Context, *GetSLRequest) (*SL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSL not implemented")
+}
+func (UnimplementedSLServiceServer) GetSLByCode(context.Context, *GetSLByCodeRequest) (*SL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSLByCode not implemented")
+}
+func (UnimplementedSLServiceServer) NextSLCode(context.Context, *NextSLCodeRequest) (*NextCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextSLCode not implemented")
+}
+func (UnimplementedSLServiceServer) mustEmbedUnimplementedSLServiceServer() {}
+
+// UnsafeSLServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SLServiceServer will
+// result in compilation errors.
+type UnsafeSLServiceServer interface {
+	mustEmbedUnimplementedSLServiceServer()
+}
+
+func RegisterSLServiceServer(s grpc.ServiceRegistrar, srv SLServiceServer) {
+	s.RegisterService(&SLService_ServiceDesc, srv)
+}
+
+func _SLService_CreateSL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLServiceServer).CreateSL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLService_CreateSL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLServiceServer).CreateSL(ctx, req.(*CreateSLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SLService_UpdateSL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLServiceServer).UpdateSL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLService_UpdateSL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLServiceServer).UpdateSL(ctx, req.(*UpdateSLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SLService_DeleteSL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLServiceServer).DeleteSL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLService_DeleteSL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLServiceServer).DeleteSL(ctx, req.(*DeleteSLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SLService_GetSL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLServiceServer).GetSL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLService_GetSL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLServiceServer).GetSL(ctx, req.(*GetSLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SLService_GetSLByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSLByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLServiceServer).GetSLByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLService_GetSLByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLServiceServer).GetSLByCode(ctx, req.(*GetSLByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SLService_NextSLCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextSLCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLServiceServer).NextSLCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLService_NextSLCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLServiceServer).NextSLCode(ctx, req.(*NextSLCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SLService_ServiceDesc is the grpc.ServiceDesc for SLService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SLService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "accountingsystem.ledger.v1.SLService",
+	HandlerType: (*SLServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSL",
+			Handler:    _SLService_CreateSL_Handler,
+		},
+		{
+			MethodName: "UpdateSL",
+			Handler:    _SLService_UpdateSL_Handler,
+		},
+		{
+			MethodName: "DeleteSL",
+			Handler:    _SLService_DeleteSL_Handler,
+		},
+		{
+			MethodName: "GetSL",
+			Handler:    _SLService_GetSL_Handler,
+		},
+		{
+			MethodName: "GetSLByCode",
+			Handler:    _SLService_GetSLByCode_Handler,
+		},
+		{
+			MethodName: "NextSLCode",
+			Handler:    _SLService_NextSLCode_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ledger/v1/ledger.proto",
+}
+
+const (
+	VoucherService_CreateVoucher_FullMethodName       = "/accountingsystem.ledger.v1.VoucherService/CreateVoucher"
+	VoucherService_UpdateVoucher_FullMethodName       = "/accountingsystem.ledger.v1.VoucherService/UpdateVoucher"
+	VoucherService_DeleteVoucher_FullMethodName       = "/accountingsystem.ledger.v1.VoucherService/DeleteVoucher"
+	VoucherService_GetVoucher_FullMethodName          = "/accountingsystem.ledger.v1.VoucherService/GetVoucher"
+	VoucherService_GetVoucherByNumber_FullMethodName  = "/accountingsystem.ledger.v1.VoucherService/GetVoucherByNumber"
+	VoucherService_ReverseVoucher_FullMethodName      = "/accountingsystem.ledger.v1.VoucherService/ReverseVoucher"
+	VoucherService_CopyVoucher_FullMethodName         = "/accountingsystem.ledger.v1.VoucherService/CopyVoucher"
+	VoucherService_ExportVoucher_FullMethodName       = "/accountingsystem.ledger.v1.VoucherService/ExportVoucher"
+	VoucherService_SubmitVoucher_FullMethodName       = "/accountingsystem.ledger.v1.VoucherService/SubmitVoucher"
+	VoucherService_ApproveVoucher_FullMethodName      = "/accountingsystem.ledger.v1.VoucherService/ApproveVoucher"
+	VoucherService_RejectVoucher_FullMethodName       = "/accountingsystem.ledger.v1.VoucherService/RejectVoucher"
+	VoucherService_GetApprovalQueue_FullMethodName    = "/accountingsystem.ledger.v1.VoucherService/GetApprovalQueue"
+	VoucherService_GetVoucherApprovals_FullMethodName = "/accountingsystem.ledger.v1.VoucherService/GetVoucherApprovals"
+)
+
+// VoucherServiceClient is the client API for VoucherService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VoucherServiceClient interface {
+	CreateVoucher(ctx context.Context, in *CreateVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error)
+	UpdateVoucher(ctx context.Context, in *UpdateVoucherRequest, opts ...grpc.CallOption) (*Voucher, error)
+	DeleteVoucher(ctx context.Context, in *DeleteVoucherRequest, opts ...grpc.CallOption) (*DeleteVoucherResponse, error)
+	GetVoucher(ctx context.Context, in *GetVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error)
+	GetVoucherByNumber(ctx context.Context, in *GetVoucherByNumberRequest, opts ...grpc.CallOption) (*VoucherWithItems, error)
+	ReverseVoucher(ctx context.Context, in *ReverseVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error)
+	CopyVoucher(ctx context.Context, in *CopyVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error)
+	ExportVoucher(ctx context.Context, in *ExportVoucherRequest, opts ...grpc.CallOption) (*ExportVoucherResponse, error)
+	SubmitVoucher(ctx context.Context, in *SubmitVoucherRequest, opts ...grpc.CallOption) (*Voucher, error)
+	ApproveVoucher(ctx context.Context, in *ApproveVoucherRequest, opts ...grpc.CallOption) (*Voucher, error)
+	RejectVoucher(ctx context.Context, in *RejectVoucherRequest, opts ...grpc.CallOption) (*Voucher, error)
+	GetApprovalQueue(ctx context.Context, in *GetApprovalQueueRequest, opts ...grpc.CallOption) (*GetApprovalQueueResponse, error)
+	GetVoucherApprovals(ctx context.Context, in *GetVoucherApprovalsRequest, opts ...grpc.CallOption) (*GetVoucherApprovalsResponse, error)
+}
+
+type voucherServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVoucherServiceClient(cc grpc.ClientConnInterface) VoucherServiceClient {
+	return &voucherServiceClient{cc}
+}
+
+func (c *voucherServiceClient) CreateVoucher(ctx context.Context, in *CreateVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoucherWithItems)
+	err := c.cc.Invoke(ctx, VoucherService_CreateVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) UpdateVoucher(ctx context.Context, in *UpdateVoucherRequest, opts ...grpc.CallOption) (*Voucher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voucher)
+	err := c.cc.Invoke(ctx, VoucherService_UpdateVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) DeleteVoucher(ctx context.Context, in *DeleteVoucherRequest, opts ...grpc.CallOption) (*DeleteVoucherResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVoucherResponse)
+	err := c.cc.Invoke(ctx, VoucherService_DeleteVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) GetVoucher(ctx context.Context, in *GetVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoucherWithItems)
+	err := c.cc.Invoke(ctx, VoucherService_GetVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) GetVoucherByNumber(ctx context.Context, in *GetVoucherByNumberRequest, opts ...grpc.CallOption) (*VoucherWithItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoucherWithItems)
+	err := c.cc.Invoke(ctx, VoucherService_GetVoucherByNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) ReverseVoucher(ctx context.Context, in *ReverseVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoucherWithItems)
+	err := c.cc.Invoke(ctx, VoucherService_ReverseVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) CopyVoucher(ctx context.Context, in *CopyVoucherRequest, opts ...grpc.CallOption) (*VoucherWithItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoucherWithItems)
+	err := c.cc.Invoke(ctx, VoucherService_CopyVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) ExportVoucher(ctx context.Context, in *ExportVoucherRequest, opts ...grpc.CallOption) (*ExportVoucherResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportVoucherResponse)
+	err := c.cc.Invoke(ctx, VoucherService_ExportVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) SubmitVoucher(ctx context.Context, in *SubmitVoucherRequest, opts ...grpc.CallOption) (*Voucher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voucher)
+	err := c.cc.Invoke(ctx, VoucherService_SubmitVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) ApproveVoucher(ctx context.Context, in *ApproveVoucherRequest, opts ...grpc.CallOption) (*Voucher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voucher)
+	err := c.cc.Invoke(ctx, VoucherService_ApproveVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) RejectVoucher(ctx context.Context, in *RejectVoucherRequest, opts ...grpc.CallOption) (*Voucher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voucher)
+	err := c.cc.Invoke(ctx, VoucherService_RejectVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) GetApprovalQueue(ctx context.Context, in *GetApprovalQueueRequest, opts ...grpc.CallOption) (*GetApprovalQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetApprovalQueueResponse)
+	err := c.cc.Invoke(ctx, VoucherService_GetApprovalQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *voucherServiceClient) GetVoucherApprovals(ctx context.Context, in *GetVoucherApprovalsRequest, opts ...grpc.CallOption) (*GetVoucherApprovalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVoucherApprovalsResponse)
+	err := c.cc.Invoke(ctx, VoucherService_GetVoucherApprovals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VoucherServiceServer is the server API for VoucherService service.
+// All implementations must embed UnimplementedVoucherServiceServer
+// for forward compatibility
+type VoucherServiceServer interface {
+	CreateVoucher(context.Context, *CreateVoucherRequest) (*VoucherWithItems, error)
+	UpdateVoucher(context.Context, *UpdateVoucherRequest) (*Voucher, error)
+	DeleteVoucher(context.Context, *DeleteVoucherRequest) (*DeleteVoucherResponse, error)
+	GetVoucher(context.Context, *GetVoucherRequest) (*VoucherWithItems, error)
+	GetVoucherByNumber(context.Context, *GetVoucherByNumberRequest) (*VoucherWithItems, error)
+	ReverseVoucher(context.Context, *ReverseVoucherRequest) (*VoucherWithItems, error)
+	CopyVoucher(context.Context, *CopyVoucherRequest) (*VoucherWithItems, error)
+	ExportVoucher(context.Context, *ExportVoucherRequest) (*ExportVoucherResponse, error)
+	SubmitVoucher(context.Context, *SubmitVoucherRequest) (*Voucher, error)
+	ApproveVoucher(context.Context, *ApproveVoucherRequest) (*Voucher, error)
+	RejectVoucher(context.Context, *RejectVoucherRequest) (*Voucher, error)
+	GetApprovalQueue(context.Context, *GetApprovalQueueRequest) (*GetApprovalQueueResponse, error)
+	GetVoucherApprovals(context.Context, *GetVoucherApprovalsRequest) (*GetVoucherApprovalsResponse, error)
+	mustEmbedUnimplementedVoucherServiceServer()
+}
+
+// UnimplementedVoucherServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedVoucherServiceServer struct {
+}
+
+func (UnimplementedVoucherServiceServer) CreateVoucher(context.Context, *CreateVoucherRequest) (*VoucherWithItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) UpdateVoucher(context.Context, *UpdateVoucherRequest) (*Voucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) DeleteVoucher(context.Context, *DeleteVoucherRequest) (*DeleteVoucherResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) GetVoucher(context.Context, *GetVoucherRequest) (*VoucherWithItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) GetVoucherByNumber(context.Context, *GetVoucherByNumberRequest) (*VoucherWithItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoucherByNumber not implemented")
+}
+func (UnimplementedVoucherServiceServer) ReverseVoucher(context.Context, *ReverseVoucherRequest) (*VoucherWithItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) CopyVoucher(context.Context, *CopyVoucherRequest) (*VoucherWithItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) ExportVoucher(context.Context, *ExportVoucherRequest) (*ExportVoucherResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) SubmitVoucher(context.Context, *SubmitVoucherRequest) (*Voucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) ApproveVoucher(context.Context, *ApproveVoucherRequest) (*Voucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) RejectVoucher(context.Context, *RejectVoucherRequest) (*Voucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectVoucher not implemented")
+}
+func (UnimplementedVoucherServiceServer) GetApprovalQueue(context.Context, *GetApprovalQueueRequest) (*GetApprovalQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApprovalQueue not implemented")
+}
+func (UnimplementedVoucherServiceServer) GetVoucherApprovals(context.Context, *GetVoucherApprovalsRequest) (*GetVoucherApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoucherApprovals not implemented")
+}
+func (UnimplementedVoucherServiceServer) mustEmbedUnimplementedVoucherServiceServer() {}
+
+// UnsafeVoucherServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VoucherServiceServer will
+// result in compilation errors.
+type UnsafeVoucherServiceServer interface {
+	mustEmbedUnimplementedVoucherServiceServer()
+}
+
+func RegisterVoucherServiceServer(s grpc.ServiceRegistrar, srv VoucherServiceServer) {
+	s.RegisterService(&VoucherService_ServiceDesc, srv)
+}
+
+func _VoucherService_CreateVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).CreateVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_CreateVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).CreateVoucher(ctx, req.(*CreateVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_UpdateVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).UpdateVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_UpdateVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).UpdateVoucher(ctx, req.(*UpdateVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_DeleteVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).DeleteVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_DeleteVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).DeleteVoucher(ctx, req.(*DeleteVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_GetVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).GetVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_GetVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).GetVoucher(ctx, req.(*GetVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_GetVoucherByNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoucherByNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).GetVoucherByNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_GetVoucherByNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).GetVoucherByNumber(ctx, req.(*GetVoucherByNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_ReverseVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).ReverseVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_ReverseVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).ReverseVoucher(ctx, req.(*ReverseVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_CopyVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).CopyVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_CopyVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).CopyVoucher(ctx, req.(*CopyVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_ExportVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).ExportVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_ExportVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).ExportVoucher(ctx, req.(*ExportVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_SubmitVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).SubmitVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_SubmitVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).SubmitVoucher(ctx, req.(*SubmitVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_ApproveVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).ApproveVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_ApproveVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).ApproveVoucher(ctx, req.(*ApproveVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_RejectVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).RejectVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_RejectVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).RejectVoucher(ctx, req.(*RejectVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_GetApprovalQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApprovalQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).GetApprovalQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_GetApprovalQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).GetApprovalQueue(ctx, req.(*GetApprovalQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VoucherService_GetVoucherApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoucherApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VoucherServiceServer).GetVoucherApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VoucherService_GetVoucherApprovals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VoucherServiceServer).GetVoucherApprovals(ctx, req.(*GetVoucherApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VoucherService_ServiceDesc is the grpc.ServiceDesc for VoucherService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VoucherService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "accountingsystem.ledger.v1.VoucherService",
+	HandlerType: (*VoucherServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateVoucher",
+			Handler:    _VoucherService_CreateVoucher_Handler,
+		},
+		{
+			MethodName: "UpdateVoucher",
+			Handler:    _VoucherService_UpdateVoucher_Handler,
+		},
+		{
+			MethodName: "DeleteVoucher",
+			Handler:    _VoucherService_DeleteVoucher_Handler,
+		},
+		{
+			MethodName: "GetVoucher",
+			Handler:    _VoucherService_GetVoucher_Handler,
+		},
+		{
+			MethodName: "GetVoucherByNumber",
+			Handler:    _VoucherService_GetVoucherByNumber_Handler,
+		},
+		{
+			MethodName: "ReverseVoucher",
+			Handler:    _VoucherService_ReverseVoucher_Handler,
+		},
+		{
+			MethodName: "CopyVoucher",
+			Handler:    _VoucherService_CopyVoucher_Handler,
+		},
+		{
+			MethodName: "ExportVoucher",
+			Handler:    _VoucherService_ExportVoucher_Handler,
+		},
+		{
+			MethodName: "SubmitVoucher",
+			Handler:    _VoucherService_SubmitVoucher_Handler,
+		},
+		{
+			MethodName: "ApproveVoucher",
+			Handler:    _VoucherService_ApproveVoucher_Handler,
+		},
+		{
+			MethodName: "RejectVoucher",
+			Handler:    _VoucherService_RejectVoucher_Handler,
+		},
+		{
+			MethodName: "GetApprovalQueue",
+			Handler:    _VoucherService_GetApprovalQueue_Handler,
+		},
+		{
+			MethodName: "GetVoucherApprovals",
+			Handler:    _VoucherService_GetVoucherApprovals_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ledger/v1/ledger.proto",
+}
@@ -0,0 +1,92 @@
+package grpcapi
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/grpcapi/ledgerpb"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/services"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gorm.io/gorm"
+)
+
+// Server exposes the DL, SL and voucher services over gRPC. Like the HTTP
+// API, every call needs an "authorization: Bearer <token>" metadata entry
+// and the user the token belongs to is the actor of the service call.
+type Server struct {
+	ledgerpb.UnimplementedDLServiceServer
+	ledgerpb.UnimplementedSLServiceServer
+	ledgerpb.UnimplementedVoucherServiceServer
+
+	dlService      *services.DLService
+	slService      *services.SLService
+	voucherService *services.VoucherService
+	userService    *services.UserService
+	requestTimeout time.Duration
+	grpcServer     *grpc.Server
+}
+
+type actorKey struct{}
+
+// InitServer registers the services. A positive requestTimeout bounds how
+// long the services may work on a call unless the client set a shorter
+// deadline.
+func (s *Server) InitServer(db *gorm.DB, requestTimeout time.Duration) {
+	s.requestTimeout = requestTimeout
+	s.dlService = &services.DLService{}
+	s.slService = &services.SLService{}
+	s.voucherService = &services.VoucherService{}
+	s.userService = &services.UserService{}
+
+	s.dlService.InitService(db)
+	s.slService.InitService(db)
+	s.voucherService.InitService(db)
+	s.userService.InitService(db)
+
+	s.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(s.intercept))
+	ledgerpb.RegisterDLServiceServer(s.grpcServer, s)
+	ledgerpb.RegisterSLServiceServer(s.grpcServer, s)
+	ledgerpb.RegisterVoucherServiceServer(s.grpcServer, s)
+}
+
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.grpcServer
+}
+
+// intercept authenticates every call and turns the errors of the services
+// into gRPC statuses.
+func (s *Server) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if s.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+	}
+	actor, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp, err := handler(context.WithValue(ctx, actorKey{}, actor), req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return resp, nil
+}
+
+func (s *Server) authenticate(ctx context.Context) (auth.Principal, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			token, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+	}
+	return s.userService.Authenticate(&user.AuthenticateRequest{Token: strings.TrimSpace(token)})
+}
+
+func actorFrom(ctx context.Context) auth.Principal {
+	actor, _ := ctx.Value(actorKey{}).(auth.Principal)
+	return actor
+}
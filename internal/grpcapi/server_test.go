@@ -0,0 +1,172 @@
+package grpcapi
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/db"
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/codetemplate"
+	"accountingsystem/internal/grpcapi/ledgerpb"
+	"accountingsystem/internal/idempotency"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/services"
+	"accountingsystem/internal/textrule"
+	"accountingsystem/internal/txretry"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+)
+
+var (
+	testDBOnce sync.Once
+	testDB     *gorm.DB
+	testDBErr  error
+)
+
+// openTestDB configures the packages the services depend on and connects to
+// the test database once for the whole package. Unlike the tests of the
+// error mapping, the round trip tests need the database.
+func openTestDB(t *testing.T) *gorm.DB {
+	testDBOnce.Do(func() {
+		if testDBErr = configs.InitConfig("../../.env.test"); testDBErr != nil {
+			return
+		}
+		for _, initPackage := range []func() error{money.Init, calendar.Init, textrule.Init, codetemplate.Init, txretry.Init, idempotency.Init} {
+			if testDBErr = initPackage(); testDBErr != nil {
+				return
+			}
+		}
+		testDB, testDBErr = db.Init()
+	})
+	require.Nil(t, testDBErr)
+	return testDB
+}
+
+// dialTestServer serves a Server over an in-memory listener and returns
+// clients whose calls carry the token of a new admin of the default tenant.
+func dialTestServer(t *testing.T) (context.Context, ledgerpb.SLServiceClient, ledgerpb.VoucherServiceClient) {
+	theDB := openTestDB(t)
+	userService := &services.UserService{}
+	userService.InitService(theDB)
+	userDto, err := userService.CreateUser(auth.System(auth.DefaultTenantID), &user.InsertRequest{Username: "grpc" + randomSuffix(t), Role: auth.Admin})
+	require.Nil(t, err)
+
+	server := &Server{}
+	server.InitServer(theDB, 0)
+	listener := bufconn.Listen(1024 * 1024)
+	go server.GRPCServer().Serve(listener)
+	t.Cleanup(server.GRPCServer().Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+userDto.Token)
+	return ctx, ledgerpb.NewSLServiceClient(conn), ledgerpb.NewVoucherServiceClient(conn)
+}
+
+func randomSuffix(t *testing.T) string {
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	require.Nil(t, err)
+	return hex.EncodeToString(suffix)
+}
+
+func createTestSL(t *testing.T, ctx context.Context, client ledgerpb.SLServiceClient) *ledgerpb.SL {
+	sl, err := client.CreateSL(ctx, &ledgerpb.CreateSLRequest{Code: "SL" + randomSuffix(t), Title: "Test" + randomSuffix(t)})
+	require.Nil(t, err)
+	return sl
+}
+
+func Test_UpdateVoucher_InsertsUpdatesAndDeletesItems_OverGRPC(t *testing.T) {
+	ctx, slClient, voucherClient := dialTestServer(t)
+	debitSL := createTestSL(t, ctx, slClient)
+	creditSL := createTestSL(t, ctx, slClient)
+	created, err := voucherClient.CreateVoucher(ctx, &ledgerpb.CreateVoucherRequest{
+		Number: "V" + randomSuffix(t),
+		VoucherItems: []*ledgerpb.VoucherItemInsertDetail{
+			{SlId: debitSL.Id, Debit: 100},
+			{SlId: creditSL.Id, Credit: 60},
+			{SlId: creditSL.Id, Credit: 40},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, created.VoucherItems, 3)
+
+	updated, err := voucherClient.UpdateVoucher(ctx, &ledgerpb.UpdateVoucherRequest{
+		Id:      created.Id,
+		Number:  created.Number,
+		Version: created.RowVersion,
+		Items: &ledgerpb.VoucherItemsUpdate{
+			Inserted: []*ledgerpb.VoucherItemInsertDetail{{SlId: creditSL.Id, Credit: 30}},
+			Updated:  []*ledgerpb.VoucherItemUpdateDetail{{Id: created.VoucherItems[1].Id, SlId: creditSL.Id, Credit: 70}},
+			Deleted:  []int32{created.VoucherItems[2].Id},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, created.Id, updated.Id)
+	assert.Greater(t, updated.RowVersion, created.RowVersion)
+	found, err := voucherClient.GetVoucher(ctx, &ledgerpb.GetVoucherRequest{Id: created.Id})
+	require.Nil(t, err)
+	credits := map[int32]int64{}
+	for _, item := range found.VoucherItems {
+		credits[item.Id] = item.Credit
+	}
+	assert.Len(t, credits, 3)
+	assert.Equal(t, int64(70), credits[created.VoucherItems[1].Id])
+	assert.NotContains(t, credits, created.VoucherItems[2].Id)
+}
+
+func Test_UpdateVoucher_ReturnsBadRequestDetail_OverGRPC_WithUnbalancedItems(t *testing.T) {
+	ctx, slClient, voucherClient := dialTestServer(t)
+	debitSL := createTestSL(t, ctx, slClient)
+	creditSL := createTestSL(t, ctx, slClient)
+	created, err := voucherClient.CreateVoucher(ctx, &ledgerpb.CreateVoucherRequest{
+		Number: "V" + randomSuffix(t),
+		VoucherItems: []*ledgerpb.VoucherItemInsertDetail{
+			{SlId: debitSL.Id, Debit: 100},
+			{SlId: creditSL.Id, Credit: 100},
+		},
+	})
+	require.Nil(t, err)
+
+	_, err = voucherClient.UpdateVoucher(ctx, &ledgerpb.UpdateVoucherRequest{
+		Id:      created.Id,
+		Number:  created.Number,
+		Version: created.RowVersion,
+		Items: &ledgerpb.VoucherItemsUpdate{
+			Inserted: []*ledgerpb.VoucherItemInsertDetail{{SlId: creditSL.Id, Credit: 30}},
+		},
+	})
+
+	require.NotNil(t, err)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = append(violations, badRequest.GetFieldViolations()...)
+		}
+	}
+	require.Len(t, violations, 1)
+	assert.Equal(t, "voucher_items", violations[0].GetField())
+}
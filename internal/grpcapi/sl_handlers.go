@@ -0,0 +1,77 @@
+package grpcapi
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/grpcapi/ledgerpb"
+	"accountingsystem/internal/requests/sl"
+	"context"
+)
+
+func (s *Server) CreateSL(ctx context.Context, req *ledgerpb.CreateSLRequest) (*ledgerpb.SL, error) {
+	slDto, err := s.slService.CreateSLContext(ctx, actorFrom(ctx), &sl.InsertRequest{
+		Code:           req.GetCode(),
+		Title:          req.GetTitle(),
+		HasDL:          req.GetHasDl(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSLMessage(slDto), nil
+}
+
+func (s *Server) UpdateSL(ctx context.Context, req *ledgerpb.UpdateSLRequest) (*ledgerpb.SL, error) {
+	slDto, err := s.slService.UpdateSLContext(ctx, actorFrom(ctx), &sl.UpdateRequest{
+		ID:      int(req.GetId()),
+		Code:    req.GetCode(),
+		Title:   req.GetTitle(),
+		HasDL:   req.GetHasDl(),
+		Version: int(req.GetVersion()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSLMessage(slDto), nil
+}
+
+func (s *Server) DeleteSL(ctx context.Context, req *ledgerpb.DeleteSLRequest) (*ledgerpb.DeleteSLResponse, error) {
+	err := s.slService.DeleteSLContext(ctx, actorFrom(ctx), &sl.DeleteRequest{ID: int(req.GetId()), Version: int(req.GetVersion())})
+	if err != nil {
+		return nil, err
+	}
+	return &ledgerpb.DeleteSLResponse{}, nil
+}
+
+func (s *Server) GetSL(ctx context.Context, req *ledgerpb.GetSLRequest) (*ledgerpb.SL, error) {
+	slDto, err := s.slService.GetSLContext(ctx, actorFrom(ctx), &sl.GetRequest{ID: int(req.GetId())})
+	if err != nil {
+		return nil, err
+	}
+	return toSLMessage(slDto), nil
+}
+
+func (s *Server) GetSLByCode(ctx context.Context, req *ledgerpb.GetSLByCodeRequest) (*ledgerpb.SL, error) {
+	slDto, err := s.slService.GetSLByCodeContext(ctx, actorFrom(ctx), &sl.GetByCodeRequest{Code: req.GetCode()})
+	if err != nil {
+		return nil, err
+	}
+	return toSLMessage(slDto), nil
+}
+
+func (s *Server) NextSLCode(ctx context.Context, req *ledgerpb.NextSLCodeRequest) (*ledgerpb.NextCodeResponse, error) {
+	code, err := s.slService.NextSLCodeContext(ctx, actorFrom(ctx), &sl.NextCodeRequest{ParentCode: req.GetParentCode()})
+	if err != nil {
+		return nil, err
+	}
+	return &ledgerpb.NextCodeResponse{Code: code}, nil
+}
+
+func toSLMessage(slDto *dtos.SLDto) *ledgerpb.SL {
+	return &ledgerpb.SL{
+		Id:         int32(slDto.ID),
+		Code:       slDto.Code,
+		Title:      slDto.Title,
+		HasDl:      slDto.HasDL,
+		RowVersion: int32(slDto.RowVersion),
+	}
+}
@@ -0,0 +1,295 @@
+package grpcapi
+
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/grpcapi/ledgerpb"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/voucher"
+	"bytes"
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateVoucher(ctx context.Context, req *ledgerpb.CreateVoucherRequest) (*ledgerpb.VoucherWithItems, error) {
+	voucherDto, err := s.voucherService.CreateVoucherContext(ctx, actorFrom(ctx), &voucher.InsertRequest{
+		Number:         req.GetNumber(),
+		SequenceCode:   req.GetSequenceCode(),
+		Date:           fromTimestamp(req.GetDate()),
+		DateInput:      fromDateInput(req.GetDateInput()),
+		VoucherItems:   fromInsertDetails(req.GetVoucherItems()),
+		IdempotencyKey: req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherWithItemsMessage(voucherDto), nil
+}
+
+func (s *Server) UpdateVoucher(ctx context.Context, req *ledgerpb.UpdateVoucherRequest) (*ledgerpb.Voucher, error) {
+	items := req.GetItems()
+	deleted := make([]int, len(items.GetDeleted()))
+	for i, id := range items.GetDeleted() {
+		deleted[i] = int(id)
+	}
+	voucherDto, err := s.voucherService.UpdateVoucherContext(ctx, actorFrom(ctx), &voucher.UpdateRequest{
+		ID:        int(req.GetId()),
+		Number:    req.GetNumber(),
+		Date:      fromTimestamp(req.GetDate()),
+		DateInput: fromDateInput(req.GetDateInput()),
+		Version:   int(req.GetVersion()),
+		Items: voucher.VoucherItemsUpdate{
+			Inserted: fromInsertDetails(items.GetInserted()),
+			Updated:  fromUpdateDetails(items.GetUpdated()),
+			Deleted:  deleted,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherMessage(voucherDto), nil
+}
+
+func (s *Server) DeleteVoucher(ctx context.Context, req *ledgerpb.DeleteVoucherRequest) (*ledgerpb.DeleteVoucherResponse, error) {
+	err := s.voucherService.DeleteVoucherContext(ctx, actorFrom(ctx), &voucher.DeleteRequest{ID: int(req.GetId()), Version: int(req.GetVersion())})
+	if err != nil {
+		return nil, err
+	}
+	return &ledgerpb.DeleteVoucherResponse{}, nil
+}
+
+func (s *Server) GetVoucher(ctx context.Context, req *ledgerpb.GetVoucherRequest) (*ledgerpb.VoucherWithItems, error) {
+	voucherDto, err := s.voucherService.GetVoucherContext(ctx, actorFrom(ctx), &voucher.GetRequest{ID: int(req.GetId())})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherWithItemsMessage(voucherDto), nil
+}
+
+func (s *Server) GetVoucherByNumber(ctx context.Context, req *ledgerpb.GetVoucherByNumberRequest) (*ledgerpb.VoucherWithItems, error) {
+	voucherDto, err := s.voucherService.GetVoucherByNumberContext(ctx, actorFrom(ctx), &voucher.GetByNumberRequest{Number: req.GetNumber()})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherWithItemsMessage(voucherDto), nil
+}
+
+func (s *Server) ReverseVoucher(ctx context.Context, req *ledgerpb.ReverseVoucherRequest) (*ledgerpb.VoucherWithItems, error) {
+	voucherDto, err := s.voucherService.ReverseVoucherContext(ctx, actorFrom(ctx), &voucher.ReverseRequest{
+		ID:           int(req.GetId()),
+		Version:      int(req.GetVersion()),
+		Number:       req.GetNumber(),
+		SequenceCode: req.GetSequenceCode(),
+		Date:         fromTimestamp(req.GetDate()),
+		DateInput:    fromDateInput(req.GetDateInput()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherWithItemsMessage(voucherDto), nil
+}
+
+func (s *Server) CopyVoucher(ctx context.Context, req *ledgerpb.CopyVoucherRequest) (*ledgerpb.VoucherWithItems, error) {
+	voucherDto, err := s.voucherService.CopyVoucherContext(ctx, actorFrom(ctx), &voucher.CopyRequest{
+		ID:           int(req.GetId()),
+		Number:       req.GetNumber(),
+		SequenceCode: req.GetSequenceCode(),
+		Date:         fromTimestamp(req.GetDate()),
+		DateInput:    fromDateInput(req.GetDateInput()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherWithItemsMessage(voucherDto), nil
+}
+
+func (s *Server) ExportVoucher(ctx context.Context, req *ledgerpb.ExportVoucherRequest) (*ledgerpb.ExportVoucherResponse, error) {
+	format := export.Format(req.GetFormat())
+	if format == "" {
+		format = export.FormatCSV
+	}
+	var content bytes.Buffer
+	if err := s.voucherService.ExportVoucherContext(ctx, actorFrom(ctx), &voucher.GetRequest{ID: int(req.GetId())}, format, &content); err != nil {
+		return nil, err
+	}
+	return &ledgerpb.ExportVoucherResponse{Content: content.Bytes(), ContentType: format.ContentType()}, nil
+}
+
+func (s *Server) SubmitVoucher(ctx context.Context, req *ledgerpb.SubmitVoucherRequest) (*ledgerpb.Voucher, error) {
+	voucherDto, err := s.voucherService.SubmitVoucherContext(ctx, actorFrom(ctx), &voucher.SubmitRequest{
+		ID:      int(req.GetId()),
+		Version: int(req.GetVersion()),
+		Comment: req.GetComment(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherMessage(voucherDto), nil
+}
+
+func (s *Server) ApproveVoucher(ctx context.Context, req *ledgerpb.ApproveVoucherRequest) (*ledgerpb.Voucher, error) {
+	voucherDto, err := s.voucherService.ApproveVoucherContext(ctx, actorFrom(ctx), &voucher.ApproveRequest{
+		ID:      int(req.GetId()),
+		Version: int(req.GetVersion()),
+		Comment: req.GetComment(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherMessage(voucherDto), nil
+}
+
+func (s *Server) RejectVoucher(ctx context.Context, req *ledgerpb.RejectVoucherRequest) (*ledgerpb.Voucher, error) {
+	voucherDto, err := s.voucherService.RejectVoucherContext(ctx, actorFrom(ctx), &voucher.RejectRequest{
+		ID:      int(req.GetId()),
+		Version: int(req.GetVersion()),
+		Comment: req.GetComment(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVoucherMessage(voucherDto), nil
+}
+
+func (s *Server) GetApprovalQueue(ctx context.Context, req *ledgerpb.GetApprovalQueueRequest) (*ledgerpb.GetApprovalQueueResponse, error) {
+	queueDtos, err := s.voucherService.GetApprovalQueueContext(ctx, actorFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*ledgerpb.ApprovalQueueItem, len(queueDtos))
+	for i := range queueDtos {
+		items[i] = &ledgerpb.ApprovalQueueItem{
+			Voucher:     toVoucherMessage(&queueDtos[i].Voucher),
+			TotalDebit:  int64(queueDtos[i].TotalDebit),
+			SubmittedBy: queueDtos[i].SubmittedBy,
+			Approvals:   int32(queueDtos[i].Approvals),
+		}
+	}
+	return &ledgerpb.GetApprovalQueueResponse{Items: items}, nil
+}
+
+func (s *Server) GetVoucherApprovals(ctx context.Context, req *ledgerpb.GetVoucherApprovalsRequest) (*ledgerpb.GetVoucherApprovalsResponse, error) {
+	approvalDtos, err := s.voucherService.GetVoucherApprovalsContext(ctx, actorFrom(ctx), &voucher.GetRequest{ID: int(req.GetId())})
+	if err != nil {
+		return nil, err
+	}
+	approvals := make([]*ledgerpb.VoucherApproval, len(approvalDtos))
+	for i, approval := range approvalDtos {
+		approvals[i] = &ledgerpb.VoucherApproval{
+			Round:     int32(approval.Round),
+			Decision:  approval.Decision,
+			Username:  approval.Username,
+			Comment:   approval.Comment,
+			CreatedAt: timestamppb.New(approval.CreatedAt),
+		}
+	}
+	return &ledgerpb.GetVoucherApprovalsResponse{Approvals: approvals}, nil
+}
+
+// fromTimestamp returns the zero time for a missing timestamp, which the
+// services read as "no date given".
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func fromDateInput(input *ledgerpb.DateInput) voucher.DateInput {
+	return voucher.DateInput{
+		DateText: input.GetDateText(),
+		Calendar: calendar.Calendar(input.GetCalendar()),
+	}
+}
+
+func fromCurrencyDetail(detail *ledgerpb.CurrencyDetail) voucher.CurrencyDetail {
+	return voucher.CurrencyDetail{
+		CurrencyCode:  detail.GetCurrencyCode(),
+		ForeignDebit:  money.Amount(detail.GetForeignDebit()),
+		ForeignCredit: money.Amount(detail.GetForeignCredit()),
+		ExchangeRate:  detail.GetExchangeRate(),
+	}
+}
+
+func fromOptionalID(id *int32) *int {
+	if id == nil {
+		return nil
+	}
+	value := int(*id)
+	return &value
+}
+
+func fromInsertDetails(items []*ledgerpb.VoucherItemInsertDetail) []voucher.VoucherItemInsertDetail {
+	details := make([]voucher.VoucherItemInsertDetail, len(items))
+	for i, item := range items {
+		details[i] = voucher.VoucherItemInsertDetail{
+			SLID:           int(item.GetSlId()),
+			SLCode:         item.SlCode,
+			DLID:           fromOptionalID(item.DlId),
+			DLCode:         item.DlCode,
+			Debit:          money.Amount(item.GetDebit()),
+			Credit:         money.Amount(item.GetCredit()),
+			CurrencyDetail: fromCurrencyDetail(item.GetCurrency()),
+		}
+	}
+	return details
+}
+
+func fromUpdateDetails(items []*ledgerpb.VoucherItemUpdateDetail) []voucher.VoucherItemUpdateDetail {
+	details := make([]voucher.VoucherItemUpdateDetail, len(items))
+	for i, item := range items {
+		details[i] = voucher.VoucherItemUpdateDetail{
+			ID:             int(item.GetId()),
+			SLID:           int(item.GetSlId()),
+			DLID:           fromOptionalID(item.DlId),
+			Debit:          money.Amount(item.GetDebit()),
+			Credit:         money.Amount(item.GetCredit()),
+			CurrencyDetail: fromCurrencyDetail(item.GetCurrency()),
+		}
+	}
+	return details
+}
+
+func toVoucherMessage(voucherDto *dtos.VoucherDto) *ledgerpb.Voucher {
+	return &ledgerpb.Voucher{
+		Id:                int32(voucherDto.ID),
+		Number:            voucherDto.Number,
+		Date:              timestamppb.New(voucherDto.Date),
+		DateText:          voucherDto.DateText,
+		ReversalOfId:      int32(voucherDto.ReversalOfID),
+		Status:            voucherDto.Status,
+		RequiredApprovals: int32(voucherDto.RequiredApprovals),
+		RowVersion:        int32(voucherDto.RowVersion),
+	}
+}
+
+func toVoucherWithItemsMessage(voucherDto *dtos.VoucherWithItemsDto) *ledgerpb.VoucherWithItems {
+	items := make([]*ledgerpb.VoucherItem, len(voucherDto.VoucherItems))
+	for i, item := range voucherDto.VoucherItems {
+		items[i] = &ledgerpb.VoucherItem{
+			Id:            int32(item.ID),
+			SlId:          int32(item.SLID),
+			DlId:          int32(item.DLID),
+			Debit:         int64(item.Debit),
+			Credit:        int64(item.Credit),
+			CurrencyCode:  item.CurrencyCode,
+			ForeignDebit:  int64(item.ForeignDebit),
+			ForeignCredit: int64(item.ForeignCredit),
+			ExchangeRate:  item.ExchangeRate,
+		}
+	}
+	return &ledgerpb.VoucherWithItems{
+		Id:                int32(voucherDto.ID),
+		Number:            voucherDto.Number,
+		Date:              timestamppb.New(voucherDto.Date),
+		DateText:          voucherDto.DateText,
+		ReversalOfId:      int32(voucherDto.ReversalOfID),
+		Status:            voucherDto.Status,
+		RequiredApprovals: int32(voucherDto.RequiredApprovals),
+		RowVersion:        int32(voucherDto.RowVersion),
+		VoucherItems:      items,
+	}
+}
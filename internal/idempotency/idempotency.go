@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/internal/constants"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+)
+
+// Scopes separate the keys of the different create operations, so the same
+// key may be used once for each of them.
+const (
+	ScopeDL      = "dl"
+	ScopeSL      = "sl"
+	ScopeVoucher = "voucher"
+)
+
+const maxKeyLength = 128
+
+var retention = 24 * time.Hour
+
+// Init reads IDEMPOTENCY_RETENTION, how long the result of a request is kept
+// for replays. It defaults to 24h.
+func Init() error {
+	retention = 24 * time.Hour
+	if value, err := configs.GetEnv("IDEMPOTENCY_RETENTION"); err == nil && value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return constants.ErrInvalidIdempotencyRetention
+		}
+		retention = duration
+	}
+	return nil
+}
+
+func Retention() time.Duration {
+	return retention
+}
+
+func ValidateKey(key string) error {
+	if utf8.RuneCountInString(key) > maxKeyLength {
+		return constants.ErrIdempotencyKeyTooLong
+	}
+	return nil
+}
+
+// Hash fingerprints the payload of a request, without its key, so a replay
+// can be told apart from a different request that reuses the key.
+func Hash(payload any) (string, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,27 @@
+package mappers
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+)
+
+func ToApprovalRuleDto(rule *models.ApprovalRule) *dtos.ApprovalRuleDto {
+	return &dtos.ApprovalRuleDto{
+		ID:                rule.ID,
+		MinAmount:         money.Amount(rule.MinAmount.Int64),
+		SLID:              int(rule.SLID.Int64),
+		RequiredApprovals: rule.RequiredApprovals,
+		RowVersion:        rule.RowVersion,
+	}
+}
+
+func ToVoucherApprovalDto(approval *models.VoucherApproval) *dtos.VoucherApprovalDto {
+	return &dtos.VoucherApprovalDto{
+		Round:     approval.Round,
+		Decision:  approval.Decision,
+		Username:  approval.Username,
+		Comment:   approval.Comment,
+		CreatedAt: approval.CreatedAt,
+	}
+}
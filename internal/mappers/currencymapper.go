@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+)
+
+func ToCurrencyDto(currency *models.Currency) *dtos.CurrencyDto {
+	return &dtos.CurrencyDto{
+		ID:         currency.ID,
+		Code:       currency.Code,
+		Title:      currency.Title,
+		RowVersion: currency.RowVersion,
+	}
+}
+
+func ToExchangeRateDto(currencyCode string, rate *models.CurrencyRate) *dtos.ExchangeRateDto {
+	return &dtos.ExchangeRateDto{
+		CurrencyCode: currencyCode,
+		Date:         rate.RateDate,
+		DateText:     calendar.Default().Format(rate.RateDate),
+		Rate:         rate.Rate,
+	}
+}
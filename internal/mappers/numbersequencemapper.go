@@ -0,0 +1,20 @@
+package mappers
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+)
+
+func ToNumberSequenceDto(sequence *models.NumberSequence) *dtos.NumberSequenceDto {
+	return &dtos.NumberSequenceDto{
+		ID:                   sequence.ID,
+		Code:                 sequence.Code,
+		Prefix:               sequence.Prefix,
+		Padding:              sequence.Padding,
+		ResetYearly:          sequence.ResetYearly,
+		FiscalYearStartMonth: sequence.FiscalYearStartMonth,
+		Calendar:             sequence.Calendar,
+		Gapless:              sequence.Gapless,
+		RowVersion:           sequence.RowVersion,
+	}
+}
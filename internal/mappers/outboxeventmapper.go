@@ -0,0 +1,18 @@
+package mappers
+
+import (
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/models"
+)
+
+func ToEvent(outboxEvent *models.OutboxEvent) events.Event {
+	return events.Event{
+		ID:            outboxEvent.ID,
+		TenantID:      outboxEvent.TenantID,
+		Type:          outboxEvent.Type,
+		AggregateType: outboxEvent.AggregateType,
+		AggregateID:   outboxEvent.AggregateID,
+		Payload:       outboxEvent.Payload,
+		CreatedAt:     outboxEvent.CreatedAt,
+	}
+}
@@ -11,6 +11,7 @@ func ToSlDto(sl *models.SL) *dtos.SLDto {
 		Code:       sl.Code,
 		Title:      sl.Title,
 		HasDL:      sl.HasDL,
+		Archived:   sl.ArchivedAt.Valid,
 		RowVersion: sl.RowVersion,
 	}
 }
@@ -0,0 +1,15 @@
+package mappers
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+)
+
+func ToTenantDto(tenant *models.Tenant) *dtos.TenantDto {
+	return &dtos.TenantDto{
+		ID:         tenant.ID,
+		Code:       tenant.Code,
+		Title:      tenant.Title,
+		RowVersion: tenant.RowVersion,
+	}
+}
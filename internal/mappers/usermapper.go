@@ -0,0 +1,17 @@
+package mappers
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+)
+
+func ToUserDto(user *models.User) *dtos.UserDto {
+	return &dtos.UserDto{
+		ID:         user.ID,
+		Username:   user.Username,
+		Role:       auth.Role(user.Role),
+		Active:     user.Active,
+		RowVersion: user.RowVersion,
+	}
+}
@@ -1,34 +1,57 @@
 package mappers
 
 import (
+	"accountingsystem/internal/calendar"
 	"accountingsystem/internal/dtos"
 	"accountingsystem/internal/models"
 )
 
-func ToVoucherWithItemsDto(voucher *models.Voucher, voucherItems []models.VoucherItem) *dtos.VoucherWithItemsDto {
+// ToVoucherWithItemsDto writes the DateText of the voucher in dateCalendar,
+// or in the configured calendar when it is empty.
+func ToVoucherWithItemsDto(voucher *models.Voucher, voucherItems []models.VoucherItem, dateCalendar calendar.Calendar) *dtos.VoucherWithItemsDto {
 	voucherItemDtos := make([]dtos.VoucherItemDto, len(voucherItems))
-	for i, item := range voucherItems {
-		voucherItemDtos[i] = dtos.VoucherItemDto{
-			ID:     item.ID,
-			SLID:   item.SLID,
-			DLID:   int(item.DLID.Int64),
-			Debit:  item.Debit,
-			Credit: item.Credit,
-		}
+	for i := range voucherItems {
+		voucherItemDtos[i] = *ToVoucherItemDto(&voucherItems[i])
 	}
 
 	return &dtos.VoucherWithItemsDto{
-		ID:           voucher.ID,
-		Number:       voucher.Number,
-		RowVersion:   voucher.RowVersion,
-		VoucherItems: voucherItemDtos,
+		ID:                voucher.ID,
+		Number:            voucher.Number,
+		Date:              voucher.Date,
+		DateText:          calendar.OrDefault(dateCalendar).Format(voucher.Date),
+		ReversalOfID:      int(voucher.ReversalOfID.Int64),
+		Status:            voucher.Status,
+		RequiredApprovals: voucher.RequiredApprovals,
+		RowVersion:        voucher.RowVersion,
+		VoucherItems:      voucherItemDtos,
 	}
 }
 
-func ToVoucherDto(voucher *models.Voucher) *dtos.VoucherDto {
+// ToVoucherDto writes the DateText of the voucher in dateCalendar, or in the
+// configured calendar when it is empty.
+func ToVoucherDto(voucher *models.Voucher, dateCalendar calendar.Calendar) *dtos.VoucherDto {
 	return &dtos.VoucherDto{
-		ID:         voucher.ID,
-		Number:     voucher.Number,
-		RowVersion: voucher.RowVersion,
+		ID:                voucher.ID,
+		Number:            voucher.Number,
+		Date:              voucher.Date,
+		DateText:          calendar.OrDefault(dateCalendar).Format(voucher.Date),
+		ReversalOfID:      int(voucher.ReversalOfID.Int64),
+		Status:            voucher.Status,
+		RequiredApprovals: voucher.RequiredApprovals,
+		RowVersion:        voucher.RowVersion,
+	}
+}
+
+func ToVoucherItemDto(item *models.VoucherItem) *dtos.VoucherItemDto {
+	return &dtos.VoucherItemDto{
+		ID:            item.ID,
+		SLID:          item.SLID,
+		DLID:          int(item.DLID.Int64),
+		Debit:         item.Debit,
+		Credit:        item.Credit,
+		CurrencyCode:  item.CurrencyCode.String,
+		ForeignDebit:  item.ForeignDebit,
+		ForeignCredit: item.ForeignCredit,
+		ExchangeRate:  item.ExchangeRate.String,
 	}
 }
@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"encoding/json"
+	"time"
+)
+
+func ToVoucherTemplateDto(template *models.VoucherTemplate, items []models.VoucherTemplateItem) *dtos.VoucherTemplateDto {
+	itemDtos := make([]dtos.VoucherTemplateItemDto, len(items))
+	for i, item := range items {
+		itemDtos[i] = dtos.VoucherTemplateItemDto{
+			ID:      item.ID,
+			SLID:    item.SLID,
+			DLID:    int(item.DLID.Int64),
+			Side:    item.Side,
+			Amount:  item.Amount,
+			Formula: item.Formula,
+		}
+	}
+
+	variables := map[string]money.Amount{}
+	json.Unmarshal([]byte(template.Variables), &variables)
+
+	var lastRunAt *time.Time
+	if template.LastRunAt.Valid {
+		lastRunAt = &template.LastRunAt.Time
+	}
+
+	return &dtos.VoucherTemplateDto{
+		ID:             template.ID,
+		Code:           template.Code,
+		Title:          template.Title,
+		NumberPrefix:   template.NumberPrefix,
+		Variables:      variables,
+		Frequency:      template.Frequency,
+		CronExpression: template.CronExpression,
+		StartsAt:       template.StartsAt,
+		NextRunAt:      template.NextRunAt,
+		LastRunAt:      lastRunAt,
+		Active:         template.Active,
+		RowVersion:     template.RowVersion,
+		Items:          itemDtos,
+	}
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+)
+
+func ToWebhookSubscriptionDto(subscription *models.WebhookSubscription, eventTypes []string) *dtos.WebhookSubscriptionDto {
+	return &dtos.WebhookSubscriptionDto{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		Active:     subscription.Active,
+		RowVersion: subscription.RowVersion,
+	}
+}
+
+func ToWebhookDeliveryDto(delivery *models.WebhookDelivery, eventType string) *dtos.WebhookDeliveryDto {
+	return &dtos.WebhookDeliveryDto{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      eventType,
+		ReplayOfID:     delivery.ReplayOfID.Int64,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt.Time,
+	}
+}
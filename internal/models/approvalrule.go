@@ -0,0 +1,21 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ApprovalRule struct {
+	ID                int
+	TenantID          int
+	MinAmount         sql.NullInt64
+	SLID              sql.NullInt64
+	RequiredApprovals int
+	RowVersion        int
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ApprovalRule) TableName() string {
+	return "approval_rule"
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Currency struct {
+	ID         int
+	Code       string
+	Title      string
+	RowVersion int
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (Currency) TableName() string {
+	return "currency"
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type CurrencyRate struct {
+	ID         int
+	CurrencyID int
+	RateDate   time.Time `gorm:"type:date"`
+	Rate       string    `gorm:"type:numeric"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (CurrencyRate) TableName() string {
+	return "currency_rate"
+}
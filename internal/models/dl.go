@@ -4,6 +4,7 @@ import "time"
 
 type DL struct {
 	ID         int
+	TenantID   int
 	Code       string
 	Title      string
 	RowVersion int
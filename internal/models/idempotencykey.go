@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type IdempotencyKey struct {
+	TenantID    int
+	Scope       string
+	Key         string
+	RequestHash string
+	Response    []byte
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ExpiresAt   time.Time
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_key"
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"accountingsystem/internal/calendar"
+	"time"
+)
+
+type NumberSequence struct {
+	ID                   int
+	TenantID             int
+	Code                 string
+	Prefix               string
+	Padding              int
+	ResetYearly          bool
+	FiscalYearStartMonth int
+	Calendar             calendar.Calendar
+	Gapless              bool
+	RowVersion           int
+	CreatedAt            time.Time `gorm:"autoCreateTime"`
+	UpdatedAt            time.Time `gorm:"autoUpdateTime"`
+}
+
+func (NumberSequence) TableName() string {
+	return "voucher_number_sequence"
+}
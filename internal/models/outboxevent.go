@@ -0,0 +1,24 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type OutboxEvent struct {
+	ID            int64
+	TenantID      int
+	Type          string
+	AggregateType string
+	AggregateID   int
+	Payload       []byte
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DispatchedAt  sql.NullTime
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_event"
+}
@@ -1,12 +1,17 @@
 package models
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type SL struct {
 	ID         int
+	TenantID   int
 	Code       string
 	Title      string
 	HasDL      bool
+	ArchivedAt sql.NullTime
 	RowVersion int
 	CreatedAt  time.Time `gorm:"autoCreateTime"`
 	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
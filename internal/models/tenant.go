@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Tenant struct {
+	ID         int
+	Code       string
+	Title      string
+	RowVersion int
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (Tenant) TableName() string {
+	return "tenant"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type User struct {
+	ID         int
+	TenantID   int
+	Username   string
+	Role       string
+	TokenHash  string
+	Active     bool
+	RowVersion int
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (User) TableName() string {
+	return "app_user"
+}
@@ -1,13 +1,27 @@
 package models
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type Voucher struct {
-	ID         int
-	Number     string
-	RowVersion int
-	CreatedAt  time.Time `gorm:"autoCreateTime"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+	ID                int
+	TenantID          int
+	Number            string
+	Date              time.Time `gorm:"type:date"`
+	ReversalOfID      sql.NullInt64
+	Status            string
+	RequiredApprovals int
+	ApprovalRound     int
+	SubmittedBy       string
+	CreatedBy         string
+	UpdatedBy         string
+	TemplateID        sql.NullInt64
+	TemplateRunAt     sql.NullTime
+	RowVersion        int
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
 }
 
 func (Voucher) TableName() string {
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type VoucherApproval struct {
+	ID        int
+	TenantID  int
+	VoucherID int
+	Round     int
+	Decision  string
+	UserID    sql.NullInt64
+	Username  string
+	Comment   string
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (VoucherApproval) TableName() string {
+	return "voucher_approval"
+}
@@ -1,19 +1,25 @@
 package models
 
 import (
+	"accountingsystem/internal/money"
 	"database/sql"
 	"time"
 )
 
 type VoucherItem struct {
-	ID        int
-	VoucherID int
-	SLID      int
-	DLID      sql.NullInt64
-	Debit     int
-	Credit    int
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID            int
+	TenantID      int
+	VoucherID     int
+	SLID          int
+	DLID          sql.NullInt64
+	Debit         money.Amount
+	Credit        money.Amount
+	CurrencyCode  sql.NullString
+	ForeignDebit  money.Amount
+	ForeignCredit money.Amount
+	ExchangeRate  sql.NullString `gorm:"type:numeric"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
 }
 
 func (VoucherItem) TableName() string {
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type VoucherTemplate struct {
+	ID             int
+	TenantID       int
+	Code           string
+	Title          string
+	NumberPrefix   string
+	Variables      string `gorm:"type:jsonb"`
+	Frequency      string
+	CronExpression string
+	StartsAt       time.Time
+	NextRunAt      time.Time
+	LastRunAt      sql.NullTime
+	Active         bool
+	RowVersion     int
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+func (VoucherTemplate) TableName() string {
+	return "voucher_template"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"accountingsystem/internal/money"
+	"database/sql"
+	"time"
+)
+
+type VoucherTemplateItem struct {
+	ID         int
+	TemplateID int
+	SLID       int
+	DLID       sql.NullInt64
+	Side       string
+	Amount     money.Amount
+	Formula    string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (VoucherTemplateItem) TableName() string {
+	return "voucher_template_item"
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type WebhookDelivery struct {
+	ID             int64
+	TenantID       int
+	SubscriptionID int
+	EventID        int64
+	ReplayOfID     sql.NullInt64
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	DeliveredAt    sql.NullTime
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type WebhookSubscription struct {
+	ID         int
+	TenantID   int
+	URL        string
+	Secret     string
+	Active     bool
+	RowVersion int
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscription"
+}
@@ -0,0 +1,10 @@
+package models
+
+type WebhookSubscriptionEvent struct {
+	SubscriptionID int    `gorm:"primaryKey"`
+	EventType      string `gorm:"primaryKey"`
+}
+
+func (WebhookSubscriptionEvent) TableName() string {
+	return "webhook_subscription_event"
+}
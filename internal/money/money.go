@@ -0,0 +1,132 @@
+package money
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/internal/constants"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Amount is a monetary value counted in minor units (e.g. cents), stored as
+// BIGINT. All arithmetic on amounts is checked and fails with
+// constants.ErrAmountOverflow instead of wrapping around.
+type Amount int64
+
+const maxMinorUnits = 6
+
+var minorUnits = 0
+
+// Init reads MONEY_MINOR_UNITS, the number of decimal places of the
+// functional currency. It defaults to 0, as for the rial.
+func Init() error {
+	value, err := configs.GetEnv("MONEY_MINOR_UNITS")
+	if err != nil {
+		minorUnits = 0
+		return nil
+	}
+	units, err := strconv.Atoi(value)
+	if err != nil || units < 0 || units > maxMinorUnits {
+		return constants.ErrInvalidMinorUnits
+	}
+	minorUnits = units
+	return nil
+}
+
+func MinorUnits() int {
+	return minorUnits
+}
+
+func (a Amount) Add(b Amount) (Amount, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, constants.ErrAmountOverflow
+	}
+	return a + b, nil
+}
+
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, constants.ErrAmountOverflow
+	}
+	return a - b, nil
+}
+
+func (a Amount) Neg() (Amount, error) {
+	if a == math.MinInt64 {
+		return 0, constants.ErrAmountOverflow
+	}
+	return -a, nil
+}
+
+// MulRat multiplies the amount by an exact rate and rounds half away from
+// zero.
+func (a Amount) MulRat(rate *big.Rat) (Amount, error) {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(a)), rate)
+	quotient, remainder := new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+	if remainder.Abs(remainder).Mul(remainder, big.NewInt(2)).Cmp(product.Denom()) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return 0, constants.ErrAmountOverflow
+	}
+	return Amount(quotient.Int64()), nil
+}
+
+// Sum adds up amounts, failing on the first overflow.
+func Sum(amounts ...Amount) (Amount, error) {
+	var total Amount
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// String formats the amount in major units with MinorUnits decimals.
+func (a Amount) String() string {
+	digits := strconv.FormatInt(int64(a), 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if minorUnits == 0 {
+		return sign + digits
+	}
+	if len(digits) <= minorUnits {
+		digits = strings.Repeat("0", minorUnits-len(digits)+1) + digits
+	}
+	split := len(digits) - minorUnits
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// Parse reads an amount written in major units, e.g. "1250.75", with at
+// most MinorUnits decimals.
+func Parse(text string) (Amount, error) {
+	sign := int64(1)
+	if strings.HasPrefix(text, "-") {
+		sign, text = -1, text[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(text, ".")
+	if whole == "" || len(fraction) > minorUnits || (hasFraction && fraction == "") {
+		return 0, constants.ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", minorUnits-len(fraction))
+
+	for _, c := range whole + fraction {
+		if c < '0' || c > '9' {
+			return 0, constants.ErrInvalidAmount
+		}
+	}
+	value, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, constants.ErrAmountOverflow
+	}
+	return Amount(sign * value), nil
+}
@@ -0,0 +1,120 @@
+package normalize
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	zeroWidthNonJoiner = '\u200c'
+	zeroWidthJoiner    = '\u200d'
+)
+
+// letterReplacer unifies Arabic code points that Persian keyboards and
+// imported data use interchangeably with their Persian forms.
+var letterReplacer = strings.NewReplacer(
+	"ك", "ک", // Arabic kaf -> Persian keheh
+	"ي", "ی", // Arabic yeh -> Persian yeh
+	"ى", "ی", // Arabic alef maksura -> Persian yeh
+)
+
+// invisibleRunes have nothing to draw and never change how Persian text is
+// shaped, so they are dropped.
+var invisibleRunes = map[rune]bool{
+	zeroWidthJoiner: true,
+	'\u200b':        true, // zero width space
+	'\u2060':        true, // word joiner
+	'\ufeff':        true, // byte order mark
+}
+
+// rightJoiningLetters only join the letter before them, so a ZWNJ after them
+// changes nothing.
+var rightJoiningLetters = map[rune]bool{
+	'ا': true, 'آ': true, 'أ': true, 'إ': true, 'ؤ': true, 'ة': true,
+	'د': true, 'ذ': true, 'ر': true, 'ز': true, 'ژ': true, 'و': true,
+}
+
+// Text brings a code, title or number to the form it is compared and stored
+// in: Unicode NFC, Arabic letters unified to Persian ones, Persian and
+// Arabic-Indic digits folded to ASCII, zero-width characters that do not
+// change how the text is drawn removed, surrounding whitespace trimmed and
+// inner runs of whitespace collapsed to a single space.
+func Text(text string) string {
+	text = norm.NFC.String(text)
+	text = letterReplacer.Replace(text)
+	text = strings.Map(foldDigit, text)
+	text = strings.Join(strings.FieldsFunc(text, unicode.IsSpace), " ")
+	return foldJoiners(text)
+}
+
+// Pointer normalizes an optional value in place.
+func Pointer(text *string) {
+	if text != nil {
+		*text = Text(*text)
+	}
+}
+
+func foldDigit(r rune) rune {
+	switch {
+	case r >= '۰' && r <= '۹':
+		return '0' + r - '۰'
+	case r >= '٠' && r <= '٩':
+		return '0' + r - '٠'
+	}
+	return r
+}
+
+// foldJoiners drops ZWJ and the other invisible characters, and keeps a
+// single ZWNJ only where it stops two letters from joining, as in "می\u200cشود".
+// A ZWNJ next to a space, a digit or a letter that would not join anyway is
+// dropped.
+func foldJoiners(text string) string {
+	runes := make([]rune, 0, len(text))
+	for _, r := range text {
+		if !invisibleRunes[r] {
+			runes = append(runes, r)
+		}
+	}
+
+	folded := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if r != zeroWidthNonJoiner {
+			folded = append(folded, r)
+			continue
+		}
+		if joinsNext(letterBefore(folded)) && joinsPrevious(letterAfter(runes, i)) {
+			folded = append(folded, r)
+		}
+	}
+	return string(folded)
+}
+
+// letterBefore is the last rune written before a ZWNJ, skipping marks. A
+// ZWNJ already kept stands in the way, so runs of ZWNJ fold to one.
+func letterBefore(folded []rune) rune {
+	for i := len(folded) - 1; i >= 0; i-- {
+		if !unicode.Is(unicode.Mn, folded[i]) {
+			return folded[i]
+		}
+	}
+	return 0
+}
+
+func letterAfter(runes []rune, at int) rune {
+	for _, r := range runes[at+1:] {
+		if r != zeroWidthNonJoiner && !unicode.Is(unicode.Mn, r) {
+			return r
+		}
+	}
+	return 0
+}
+
+func joinsNext(r rune) bool {
+	return joinsPrevious(r) && !rightJoiningLetters[r]
+}
+
+func joinsPrevious(r rune) bool {
+	return unicode.Is(unicode.Arabic, r) && unicode.IsLetter(r) && r != 'ء'
+}
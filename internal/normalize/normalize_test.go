@@ -0,0 +1,64 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Text_UnifiesLettersDigitsAndWhitespace(t *testing.T) {
+	tests := map[string]string{
+		"كد":            "کد",
+		"علي":           "علی",
+		"۱۴۰۳":          "1403",
+		"١٢٣":           "123",
+		"  سند   ۱۲  ":  "سند 12",
+		"e\u0301":       "\u00e9",
+		"Bank\t\nMelli": "Bank Melli",
+	}
+	for text, normalized := range tests {
+		assert.Equal(t, normalized, Text(text), text)
+	}
+}
+
+func Test_Text_KeepsZWNJ_BetweenJoiningLetters(t *testing.T) {
+	tests := map[string]string{
+		"می\u200cشود":             "می\u200cشود",
+		"می\u200c\u200cشود":       "می\u200cشود",
+		"نامه\u200cها":            "نامه\u200cها",
+		"مي\u200cشود":             "می\u200cشود",
+		"میَ\u200cشود":            "میَ\u200cشود",
+		"میشود":                   "میشود",
+		"\u200cمی\u200cشود\u200c": "می\u200cشود",
+	}
+	for text, normalized := range tests {
+		assert.Equal(t, normalized, Text(text), text)
+	}
+}
+
+func Test_Text_DropsZWNJ_WhereLettersWouldNotJoin(t *testing.T) {
+	tests := map[string]string{
+		"دارو\u200cها":    "داروها",
+		"سند \u200cها":    "سند ها",
+		"سند\u200c ها":    "سند ها",
+		"سند\u200c12":     "سند12",
+		"Bank\u200cMelli": "BankMelli",
+		"\u200c":          "",
+	}
+	for text, normalized := range tests {
+		assert.Equal(t, normalized, Text(text), text)
+	}
+}
+
+func Test_Text_DropsZWJAndOtherInvisibleCharacters(t *testing.T) {
+	tests := map[string]string{
+		"س\u200dس":          "سس",
+		"\ufeffکد":          "کد",
+		"کد\u200b1":         "کد1",
+		"کد\u2060۱":         "کد1",
+		"می\u200d\u200cشود": "می\u200cشود",
+	}
+	for text, normalized := range tests {
+		assert.Equal(t, normalized, Text(text), text)
+	}
+}
@@ -0,0 +1,6 @@
+package approvalrule
+
+type DeleteRequest struct {
+	ID      int
+	Version int
+}
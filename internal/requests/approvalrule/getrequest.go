@@ -0,0 +1,5 @@
+package approvalrule
+
+type GetRequest struct {
+	ID int
+}
@@ -0,0 +1,12 @@
+package approvalrule
+
+import "accountingsystem/internal/money"
+
+// InsertRequest describes when vouchers need approval: when their total
+// debit reaches MinAmount or one of their lines uses SLID. At least one of
+// the two has to be set.
+type InsertRequest struct {
+	MinAmount         *money.Amount
+	SLID              *int
+	RequiredApprovals int
+}
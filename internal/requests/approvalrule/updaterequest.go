@@ -0,0 +1,11 @@
+package approvalrule
+
+import "accountingsystem/internal/money"
+
+type UpdateRequest struct {
+	ID                int
+	MinAmount         *money.Amount
+	SLID              *int
+	RequiredApprovals int
+	Version           int
+}
@@ -0,0 +1,6 @@
+package currency
+
+type DeleteRequest struct {
+	ID      int
+	Version int
+}
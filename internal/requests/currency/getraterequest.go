@@ -0,0 +1,8 @@
+package currency
+
+import "time"
+
+type GetRateRequest struct {
+	CurrencyCode string
+	Date         time.Time
+}
@@ -0,0 +1,5 @@
+package currency
+
+type GetRequest struct {
+	ID int
+}
@@ -0,0 +1,6 @@
+package currency
+
+type InsertRequest struct {
+	Code  string
+	Title string
+}
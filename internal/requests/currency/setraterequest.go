@@ -0,0 +1,9 @@
+package currency
+
+import "time"
+
+type SetRateRequest struct {
+	CurrencyCode string
+	Date         time.Time
+	Rate         string
+}
@@ -0,0 +1,7 @@
+package currency
+
+type UpdateRequest struct {
+	ID      int
+	Title   string
+	Version int
+}
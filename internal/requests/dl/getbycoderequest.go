@@ -0,0 +1,5 @@
+package dl
+
+type GetByCodeRequest struct {
+	Code string
+}
@@ -0,0 +1,7 @@
+package dl
+
+// GetManyRequest looks up several DLs at once. IDs that do not belong to
+// a DL of the tenant are left out of the result.
+type GetManyRequest struct {
+	IDs []int
+}
@@ -1,6 +1,9 @@
 package dl
 
+// InsertRequest creates a DL. A request that repeats the IdempotencyKey
+// of an earlier one returns the DL that request created.
 type InsertRequest struct {
-	Code  string
-	Title string
+	Code           string
+	Title          string
+	IdempotencyKey string
 }
@@ -0,0 +1,7 @@
+package dl
+
+type NextCodeRequest struct {
+	// ParentCode is the code to allocate a child under; empty allocates a
+	// top-level code.
+	ParentCode string
+}
@@ -0,0 +1,6 @@
+package numbersequence
+
+type DeleteRequest struct {
+	ID      int
+	Version int
+}
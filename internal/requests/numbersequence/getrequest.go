@@ -0,0 +1,5 @@
+package numbersequence
+
+type GetRequest struct {
+	ID int
+}
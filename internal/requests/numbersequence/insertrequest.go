@@ -0,0 +1,16 @@
+package numbersequence
+
+import "accountingsystem/internal/calendar"
+
+type InsertRequest struct {
+	Code                 string
+	Prefix               string
+	Padding              int
+	ResetYearly          bool
+	FiscalYearStartMonth int
+	// Calendar the fiscal year and the {year} prefix placeholder are counted
+	// in; FiscalYearStartMonth is a month of this calendar, so 1 is Farvardin
+	// for jalali. It defaults to the configured calendar.
+	Calendar calendar.Calendar
+	Gapless  bool
+}
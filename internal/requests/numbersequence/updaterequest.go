@@ -0,0 +1,18 @@
+package numbersequence
+
+import "accountingsystem/internal/calendar"
+
+type UpdateRequest struct {
+	ID                   int
+	Code                 string
+	Prefix               string
+	Padding              int
+	ResetYearly          bool
+	FiscalYearStartMonth int
+	// Calendar the fiscal year and the {year} prefix placeholder are counted
+	// in; FiscalYearStartMonth is a month of this calendar, so 1 is Farvardin
+	// for jalali. It defaults to the configured calendar.
+	Calendar calendar.Calendar
+	Gapless  bool
+	Version  int
+}
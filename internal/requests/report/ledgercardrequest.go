@@ -0,0 +1,14 @@
+package report
+
+import "accountingsystem/internal/calendar"
+
+type LedgerCardRequest struct {
+	SLID int
+	DLID *int
+	// CurrencyCode limits the card to lines kept in one foreign currency and
+	// adds a running balance in that currency.
+	CurrencyCode *string
+	// Calendar voucher dates are labelled in; it defaults to the configured
+	// calendar.
+	Calendar calendar.Calendar
+}
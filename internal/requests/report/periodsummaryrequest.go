@@ -0,0 +1,17 @@
+package report
+
+import "accountingsystem/internal/calendar"
+
+type PeriodSummaryRequest struct {
+	SLID int
+	DLID *int
+	// Calendar the fiscal year is split into months of; it defaults to the
+	// configured calendar.
+	Calendar calendar.Calendar
+	// FiscalYear is named after the calendar year it starts in and defaults
+	// to the current one.
+	FiscalYear int
+	// FiscalYearStartMonth is a month of Calendar, so 1 is Farvardin for
+	// jalali. It defaults to 1.
+	FiscalYearStartMonth int
+}
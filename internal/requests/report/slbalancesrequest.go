@@ -0,0 +1,7 @@
+package report
+
+// SLBalancesRequest asks for the balances of several SLs over their posted
+// vouchers. IDs that do not belong to an SL of the tenant are left out.
+type SLBalancesRequest struct {
+	SLIDs []int
+}
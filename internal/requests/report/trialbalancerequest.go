@@ -0,0 +1,7 @@
+package report
+
+type TrialBalanceRequest struct {
+	// ByCurrency splits each SL into one row per currency with its balance in
+	// that currency next to the functional-currency balance.
+	ByCurrency bool
+}
@@ -0,0 +1,15 @@
+package revaluation
+
+import "time"
+
+type PostRequest struct {
+	Date           time.Time
+	CurrencyCode   *string
+	Number         string
+	ReversalNumber string
+	SequenceCode   string
+	GainSLID       int
+	GainDLID       *int
+	LossSLID       int
+	LossDLID       *int
+}
@@ -0,0 +1,8 @@
+package revaluation
+
+import "time"
+
+type PreviewRequest struct {
+	Date         time.Time
+	CurrencyCode *string
+}
@@ -0,0 +1,6 @@
+package sl
+
+type ArchiveRequest struct {
+	ID      int
+	Version int
+}
@@ -0,0 +1,5 @@
+package sl
+
+type GetByCodeRequest struct {
+	Code string
+}
@@ -0,0 +1,7 @@
+package sl
+
+// GetManyRequest looks up several SLs at once. IDs that do not belong to
+// an SL of the tenant are left out of the result.
+type GetManyRequest struct {
+	IDs []int
+}
@@ -1,7 +1,10 @@
 package sl
 
+// InsertRequest creates an SL. A request that repeats the IdempotencyKey
+// of an earlier one returns the SL that request created.
 type InsertRequest struct {
-	Code  string
-	Title string
-	HasDL bool
+	Code           string
+	Title          string
+	HasDL          bool
+	IdempotencyKey string
 }
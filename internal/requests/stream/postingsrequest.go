@@ -0,0 +1,12 @@
+package stream
+
+// PostingsRequest opens a stream of the voucher events of the tenant and the
+// SL balances they change. Only vouchers with a line on one of SLIDs and one
+// of DLIDs are streamed; an empty list does not filter. When Cursor is set
+// the stream resumes after the event it names, otherwise it starts with the
+// next event.
+type PostingsRequest struct {
+	Cursor int64
+	SLIDs  []int
+	DLIDs  []int
+}
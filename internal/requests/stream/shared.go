@@ -0,0 +1,6 @@
+package stream
+
+// MessageSLBalanceChanged is the type of the messages carrying the balance of
+// an SL after a voucher event. The other messages have the type of their
+// event.
+const MessageSLBalanceChanged = "SLBalanceChanged"
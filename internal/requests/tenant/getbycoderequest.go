@@ -0,0 +1,5 @@
+package tenant
+
+type GetByCodeRequest struct {
+	Code string
+}
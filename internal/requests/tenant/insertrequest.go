@@ -0,0 +1,6 @@
+package tenant
+
+type InsertRequest struct {
+	Code  string
+	Title string
+}
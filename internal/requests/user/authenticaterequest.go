@@ -0,0 +1,5 @@
+package user
+
+type AuthenticateRequest struct {
+	Token string
+}
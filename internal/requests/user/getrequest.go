@@ -0,0 +1,5 @@
+package user
+
+type GetRequest struct {
+	ID int
+}
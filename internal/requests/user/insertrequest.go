@@ -0,0 +1,8 @@
+package user
+
+import "accountingsystem/internal/auth"
+
+type InsertRequest struct {
+	Username string
+	Role     auth.Role
+}
@@ -0,0 +1,6 @@
+package user
+
+type RotateTokenRequest struct {
+	ID      int
+	Version int
+}
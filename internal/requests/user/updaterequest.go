@@ -0,0 +1,10 @@
+package user
+
+import "accountingsystem/internal/auth"
+
+type UpdateRequest struct {
+	ID      int
+	Role    auth.Role
+	Active  bool
+	Version int
+}
@@ -0,0 +1,7 @@
+package voucher
+
+type ApproveRequest struct {
+	ID      int
+	Version int
+	Comment string
+}
@@ -0,0 +1,11 @@
+package voucher
+
+import "time"
+
+type CopyRequest struct {
+	ID           int
+	Number       string
+	SequenceCode string
+	Date         time.Time
+	DateInput
+}
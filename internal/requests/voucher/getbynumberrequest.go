@@ -0,0 +1,10 @@
+package voucher
+
+import "accountingsystem/internal/calendar"
+
+// GetByNumberRequest looks up a voucher by its number. Its DateText is
+// written in Calendar, or in the configured calendar when Calendar is empty.
+type GetByNumberRequest struct {
+	Number   string
+	Calendar calendar.Calendar
+}
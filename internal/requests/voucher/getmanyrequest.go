@@ -0,0 +1,12 @@
+package voucher
+
+import "accountingsystem/internal/calendar"
+
+// GetManyRequest looks up several vouchers with their lines at once. IDs that
+// do not belong to a voucher of the tenant are left out of the result. Their
+// DateText is written in Calendar, or in the configured calendar when
+// Calendar is empty.
+type GetManyRequest struct {
+	IDs      []int
+	Calendar calendar.Calendar
+}
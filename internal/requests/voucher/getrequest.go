@@ -1,5 +1,10 @@
 package voucher
 
+import "accountingsystem/internal/calendar"
+
+// GetRequest looks up a voucher. Its DateText is written in Calendar, or in
+// the configured calendar when Calendar is empty.
 type GetRequest struct {
-	ID int
+	ID       int
+	Calendar calendar.Calendar
 }
@@ -1,6 +1,14 @@
 package voucher
 
+import "time"
+
+// InsertRequest creates a voucher. A request that repeats the
+// IdempotencyKey of an earlier one returns the voucher that request created.
 type InsertRequest struct {
 	Number       string
-	VoucherItems []VoucherItemInsertDetail
+	SequenceCode string
+	Date         time.Time
+	DateInput
+	VoucherItems   []VoucherItemInsertDetail
+	IdempotencyKey string
 }
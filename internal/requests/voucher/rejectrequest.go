@@ -0,0 +1,7 @@
+package voucher
+
+type RejectRequest struct {
+	ID      int
+	Version int
+	Comment string
+}
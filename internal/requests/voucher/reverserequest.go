@@ -0,0 +1,12 @@
+package voucher
+
+import "time"
+
+type ReverseRequest struct {
+	ID           int
+	Version      int
+	Number       string
+	SequenceCode string
+	Date         time.Time
+	DateInput
+}
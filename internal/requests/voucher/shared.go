@@ -1,8 +1,54 @@
 package voucher
 
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/money"
+)
+
+// Statuses of a voucher. Only posted vouchers count in reports; a voucher that
+// matches an approval rule stays a draft until it is submitted and approved.
+const (
+	StatusDraft    = "draft"
+	StatusPending  = "pending"
+	StatusRejected = "rejected"
+	StatusPosted   = "posted"
+)
+
+// Decisions recorded in the approval history of a voucher.
+const (
+	DecisionSubmit  = "submit"
+	DecisionApprove = "approve"
+	DecisionReject  = "reject"
+)
+
+// CurrencyDetail describes a line kept in a foreign currency. When
+// CurrencyCode is empty the line is in the functional currency. Otherwise
+// exactly one of ForeignDebit or ForeignCredit is set and Debit/Credit are
+// derived from it using ExchangeRate, or the currency's rate on the voucher
+// date when ExchangeRate is empty. Only revaluations, and the reversals of
+// their vouchers, book a line with a currency but no foreign amount; it
+// keeps the given Debit/Credit.
+type CurrencyDetail struct {
+	CurrencyCode  string
+	ForeignDebit  money.Amount
+	ForeignCredit money.Amount
+	ExchangeRate  string
+}
+
+// DateInput carries the voucher date as text, e.g. "1403/01/15", read in
+// Calendar or in the configured calendar when Calendar is empty. When DateText
+// is set it takes precedence over the request's Date.
+type DateInput struct {
+	DateText string
+	Calendar calendar.Calendar
+}
+
 type VoucherItemInsertDetail struct {
 	SLID   int
+	SLCode *string
 	DLID   *int
-	Debit  int
-	Credit int
+	DLCode *string
+	Debit  money.Amount
+	Credit money.Amount
+	CurrencyDetail
 }
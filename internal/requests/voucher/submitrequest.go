@@ -0,0 +1,7 @@
+package voucher
+
+type SubmitRequest struct {
+	ID      int
+	Version int
+	Comment string
+}
@@ -1,11 +1,17 @@
 package voucher
 
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
 type VoucherItemUpdateDetail struct {
 	ID     int
 	SLID   int
 	DLID   *int
-	Debit  int
-	Credit int
+	Debit  money.Amount
+	Credit money.Amount
+	CurrencyDetail
 }
 
 type VoucherItemsUpdate struct {
@@ -15,8 +21,10 @@ type VoucherItemsUpdate struct {
 }
 
 type UpdateRequest struct {
-	ID      int
-	Number  string
+	ID     int
+	Number string
+	Date   time.Time
+	DateInput
 	Version int
 	Items   VoucherItemsUpdate
 }
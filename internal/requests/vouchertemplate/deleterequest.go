@@ -0,0 +1,6 @@
+package vouchertemplate
+
+type DeleteRequest struct {
+	ID      int
+	Version int
+}
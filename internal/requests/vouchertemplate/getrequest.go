@@ -0,0 +1,5 @@
+package vouchertemplate
+
+type GetRequest struct {
+	ID int
+}
@@ -0,0 +1,17 @@
+package vouchertemplate
+
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type InsertRequest struct {
+	Code           string
+	Title          string
+	NumberPrefix   string
+	Variables      map[string]money.Amount
+	Items          []ItemDetail
+	Frequency      string
+	CronExpression string
+	StartsAt       time.Time
+}
@@ -0,0 +1,20 @@
+package vouchertemplate
+
+import "accountingsystem/internal/money"
+
+const (
+	FrequencyMonthly   = "monthly"
+	FrequencyQuarterly = "quarterly"
+	FrequencyCron      = "cron"
+
+	SideDebit  = "debit"
+	SideCredit = "credit"
+)
+
+type ItemDetail struct {
+	SLID    int
+	DLID    *int
+	Side    string
+	Amount  money.Amount
+	Formula string
+}
@@ -0,0 +1,20 @@
+package vouchertemplate
+
+import (
+	"accountingsystem/internal/money"
+	"time"
+)
+
+type UpdateRequest struct {
+	ID             int
+	Code           string
+	Title          string
+	NumberPrefix   string
+	Variables      map[string]money.Amount
+	Items          []ItemDetail
+	Frequency      string
+	CronExpression string
+	StartsAt       time.Time
+	Active         bool
+	Version        int
+}
@@ -0,0 +1,6 @@
+package webhook
+
+type DeleteRequest struct {
+	ID      int
+	Version int
+}
@@ -0,0 +1,8 @@
+package webhook
+
+// GetDeliveriesRequest lists the latest deliveries of a subscription, only
+// those in Status when it is set.
+type GetDeliveriesRequest struct {
+	SubscriptionID int
+	Status         string
+}
@@ -0,0 +1,5 @@
+package webhook
+
+type GetRequest struct {
+	ID int
+}
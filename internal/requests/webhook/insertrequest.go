@@ -0,0 +1,10 @@
+package webhook
+
+// InsertRequest subscribes URL to the events of the given types. The payloads
+// are signed with Secret, or with a generated secret when it is empty; the
+// secret is only returned by the creation.
+type InsertRequest struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
@@ -0,0 +1,5 @@
+package webhook
+
+type ReplayRequest struct {
+	DeliveryID int64
+}
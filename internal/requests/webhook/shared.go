@@ -0,0 +1,9 @@
+package webhook
+
+// Statuses of a webhook delivery. A pending delivery is retried until it
+// succeeds or runs out of attempts and fails.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
@@ -0,0 +1,13 @@
+package webhook
+
+// UpdateRequest replaces the URL and event types of a subscription. The
+// secret is kept when Secret is empty. Inactive subscriptions get no new
+// deliveries.
+type UpdateRequest struct {
+	ID         int
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+	Version    int
+}
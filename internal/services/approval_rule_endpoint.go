@@ -0,0 +1,98 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/approvalrule"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type ApprovalRuleService struct {
+	db       *gorm.DB
+	tenantID int
+}
+
+func (s *ApprovalRuleService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+func (s *ApprovalRuleService) forTenant(tenantID int) *ApprovalRuleService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+func (s *ApprovalRuleService) CreateApprovalRule(actor auth.Principal, req *approvalrule.InsertRequest) (*dtos.ApprovalRuleDto, error) {
+	if err := auth.Authorize(actor, auth.EntityApprovalRule, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	if err := s.validateApprovalRuleInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	ruleDto, err := s.applyApprovalRuleCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating approval rule: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return ruleDto, nil
+}
+
+func (s *ApprovalRuleService) UpdateApprovalRule(actor auth.Principal, req *approvalrule.UpdateRequest) (*dtos.ApprovalRuleDto, error) {
+	if err := auth.Authorize(actor, auth.EntityApprovalRule, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetRule, err := s.validateApprovalRuleUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleDto, err := s.applyApprovalRuleUpdate(req, targetRule)
+	if err != nil {
+		log.Printf("unexpected error while updating approval rule: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return ruleDto, nil
+}
+
+func (s *ApprovalRuleService) DeleteApprovalRule(actor auth.Principal, req *approvalrule.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityApprovalRule, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetRule, err := s.validateApprovalRuleDeleteRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyApprovalRuleDeletion(targetRule); err != nil {
+		log.Printf("unexpected error while deleting approval rule: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+func (s *ApprovalRuleService) GetApprovalRule(actor auth.Principal, req *approvalrule.GetRequest) (*dtos.ApprovalRuleDto, error) {
+	if err := auth.Authorize(actor, auth.EntityApprovalRule, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetRule, err := s.validateApprovalRuleExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyApprovalRuleGet(targetRule), nil
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/approvalrule"
+	"database/sql"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (s *ApprovalRuleService) applyApprovalRuleCreation(req *approvalrule.InsertRequest) (*dtos.ApprovalRuleDto, error) {
+	rule := models.ApprovalRule{
+		TenantID:          s.tenantID,
+		MinAmount:         s.convertAmountToNullInt64(req.MinAmount),
+		SLID:              s.convertIDToNullInt64(req.SLID),
+		RequiredApprovals: req.RequiredApprovals,
+		RowVersion:        0,
+	}
+
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToApprovalRuleDto(&rule), nil
+}
+
+func (s *ApprovalRuleService) validateApprovalRuleInsertRequest(req *approvalrule.InsertRequest) error {
+	return s.validateApprovalRuleFields(req.MinAmount, req.SLID, req.RequiredApprovals)
+}
+
+func (s *ApprovalRuleService) validateApprovalRuleFields(minAmount *money.Amount, slID *int, requiredApprovals int) error {
+	if minAmount == nil && slID == nil {
+		return constants.ErrApprovalConditionRequired
+	}
+	if minAmount != nil && *minAmount <= 0 {
+		return constants.ErrMinAmountNotPositive
+	}
+	if slID != nil {
+		if err := s.validateSLExists(*slID); err != nil {
+			return err
+		}
+	}
+	if requiredApprovals < 1 || requiredApprovals > 10 {
+		return constants.ErrRequiredApprovalsInvalid
+	}
+	return nil
+}
+
+func (s *ApprovalRuleService) validateSLExists(id int) error {
+	var targetSL models.SL
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetSL).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrSLNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ApprovalRuleService) convertAmountToNullInt64(amount *money.Amount) sql.NullInt64 {
+	if amount == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*amount), Valid: true}
+}
+
+func (s *ApprovalRuleService) convertIDToNullInt64(id *int) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*id), Valid: true}
+}
+
+func (s *ApprovalRuleService) applyApprovalRuleUpdate(req *approvalrule.UpdateRequest, targetRule *models.ApprovalRule) (*dtos.ApprovalRuleDto, error) {
+	targetRule.MinAmount = s.convertAmountToNullInt64(req.MinAmount)
+	targetRule.SLID = s.convertIDToNullInt64(req.SLID)
+	targetRule.RequiredApprovals = req.RequiredApprovals
+	targetRule.RowVersion++
+
+	if err := s.db.Save(targetRule).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToApprovalRuleDto(targetRule), nil
+}
+
+func (s *ApprovalRuleService) validateApprovalRuleUpdateRequest(req *approvalrule.UpdateRequest) (*models.ApprovalRule, error) {
+	targetRule, err := s.validateApprovalRuleExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetRule.RowVersion); err != nil {
+		return nil, err
+	}
+	if err := s.validateApprovalRuleFields(req.MinAmount, req.SLID, req.RequiredApprovals); err != nil {
+		return nil, err
+	}
+	return targetRule, nil
+}
+
+func (s *ApprovalRuleService) validateApprovalRuleExists(id int) (*models.ApprovalRule, error) {
+	var targetRule models.ApprovalRule
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetRule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrApprovalRuleNotFound
+		}
+		return nil, err
+	}
+	return &targetRule, nil
+}
+
+func (s *ApprovalRuleService) validateVersion(reqVersion int, targetVersion int) error {
+	if reqVersion != targetVersion {
+		return constants.ErrVersionOutdated
+	}
+	return nil
+}
+
+func (s *ApprovalRuleService) applyApprovalRuleDeletion(targetRule *models.ApprovalRule) error {
+	return s.db.Delete(targetRule).Error
+}
+
+func (s *ApprovalRuleService) validateApprovalRuleDeleteRequest(req *approvalrule.DeleteRequest) (*models.ApprovalRule, error) {
+	targetRule, err := s.validateApprovalRuleExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetRule.RowVersion); err != nil {
+		return nil, err
+	}
+	return targetRule, nil
+}
+
+func (s *ApprovalRuleService) applyApprovalRuleGet(targetRule *models.ApprovalRule) *dtos.ApprovalRuleDto {
+	return mappers.ToApprovalRuleDto(targetRule)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/approvalrule"
+	"accountingsystem/internal/requests/sl"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateApprovalRule_Succeeds_WithMinAmount(t *testing.T) {
+	admin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	minAmount := money.Amount(1000)
+
+	ruleDto, err := approvalRuleService.CreateApprovalRule(admin, &approvalrule.InsertRequest{MinAmount: &minAmount, RequiredApprovals: 2})
+
+	require.Nil(t, err)
+	assert.Equal(t, minAmount, ruleDto.MinAmount)
+	assert.Equal(t, 2, ruleDto.RequiredApprovals)
+	found, err := approvalRuleService.GetApprovalRule(admin, &approvalrule.GetRequest{ID: ruleDto.ID})
+	require.Nil(t, err)
+	assert.Equal(t, ruleDto, found)
+}
+
+func Test_CreateApprovalRule_ReturnsErrApprovalConditionRequired_WithoutMinAmountAndSL(t *testing.T) {
+	ruleDto, err := approvalRuleService.CreateApprovalRule(testAdmin, &approvalrule.InsertRequest{RequiredApprovals: 1})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrApprovalConditionRequired)
+	assert.Nil(t, ruleDto)
+}
+
+func Test_CreateApprovalRule_ReturnsErrRequiredApprovalsInvalid_WithZeroApprovals(t *testing.T) {
+	minAmount := money.Amount(1000)
+
+	ruleDto, err := approvalRuleService.CreateApprovalRule(testAdmin, &approvalrule.InsertRequest{MinAmount: &minAmount, RequiredApprovals: 0})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrRequiredApprovalsInvalid)
+	assert.Nil(t, ruleDto)
+}
+
+func Test_CreateApprovalRule_ReturnsErrSLNotFound_WithSLOfAnotherTenant(t *testing.T) {
+	otherSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	admin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+
+	ruleDto, err := approvalRuleService.CreateApprovalRule(admin, &approvalrule.InsertRequest{SLID: &otherSL.ID, RequiredApprovals: 1})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLNotFound)
+	assert.Nil(t, ruleDto)
+}
+
+func Test_CreateApprovalRule_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	accountant := auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}
+	minAmount := money.Amount(1000)
+
+	ruleDto, err := approvalRuleService.CreateApprovalRule(accountant, &approvalrule.InsertRequest{MinAmount: &minAmount, RequiredApprovals: 1})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, ruleDto)
+}
+
+func Test_DeleteSL_ReturnsErrThereIsRefrenceToSL_WithSLUsedByApprovalRule(t *testing.T) {
+	admin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	slDto, err := slService.CreateSL(admin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+	_, err = approvalRuleService.CreateApprovalRule(admin, &approvalrule.InsertRequest{SLID: &slDto.ID, RequiredApprovals: 1})
+	require.Nil(t, err)
+
+	err = slService.DeleteSL(admin, &sl.DeleteRequest{ID: slDto.ID, Version: slDto.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrThereIsRefrenceToSL)
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"context"
+	"errors"
+)
+
+// contextError returns ErrDeadlineExceeded or ErrCanceled when ctx has ended,
+// since whatever failed did so because of it, and err unchanged otherwise.
+func contextError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return constants.ErrDeadlineExceeded
+	case errors.Is(ctx.Err(), context.Canceled):
+		return constants.ErrCanceled
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/requests/currency"
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type CurrencyService struct {
+	db *gorm.DB
+}
+
+func (s *CurrencyService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+// withContext returns a copy of the service whose queries run under ctx.
+func (s *CurrencyService) withContext(ctx context.Context) *CurrencyService {
+	scoped := *s
+	scoped.db = s.db.WithContext(ctx)
+	return &scoped
+}
+
+func (s *CurrencyService) CreateCurrency(actor auth.Principal, req *currency.InsertRequest) (*dtos.CurrencyDto, error) {
+	if err := auth.Authorize(actor, auth.EntityCurrency, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCurrencyInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	currencyDto, err := s.applyCurrencyCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating currency: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return currencyDto, nil
+}
+
+func (s *CurrencyService) UpdateCurrency(actor auth.Principal, req *currency.UpdateRequest) (*dtos.CurrencyDto, error) {
+	if err := auth.Authorize(actor, auth.EntityCurrency, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	targetCurrency, err := s.validateCurrencyUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyDto, err := s.applyCurrencyUpdate(req, targetCurrency)
+	if err != nil {
+		log.Printf("unexpected error while updating currency: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return currencyDto, nil
+}
+
+func (s *CurrencyService) DeleteCurrency(actor auth.Principal, req *currency.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityCurrency, auth.ActionDelete); err != nil {
+		return err
+	}
+
+	targetCurrency, err := s.validateCurrencyDeleteRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyCurrencyDeletion(targetCurrency); err != nil {
+		log.Printf("unexpected error while deleting currency: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+func (s *CurrencyService) GetCurrency(actor auth.Principal, req *currency.GetRequest) (*dtos.CurrencyDto, error) {
+	if err := auth.Authorize(actor, auth.EntityCurrency, auth.ActionRead); err != nil {
+		return nil, err
+	}
+
+	targetCurrency, err := s.validateCurrencyExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToCurrencyDto(targetCurrency), nil
+}
+
+func (s *CurrencyService) SetExchangeRate(actor auth.Principal, req *currency.SetRateRequest) (*dtos.ExchangeRateDto, error) {
+	if err := auth.Authorize(actor, auth.EntityCurrency, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	targetCurrency, rate, err := s.validateSetRateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rateDto, err := s.applyExchangeRateSet(req, targetCurrency, rate)
+	if err != nil {
+		log.Printf("unexpected error while setting exchange rate: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return rateDto, nil
+}
+
+func (s *CurrencyService) GetExchangeRate(actor auth.Principal, req *currency.GetRateRequest) (*dtos.ExchangeRateDto, error) {
+	if err := auth.Authorize(actor, auth.EntityCurrency, auth.ActionRead); err != nil {
+		return nil, err
+	}
+
+	targetCurrency, err := s.validateCurrencyExistsByCode(req.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.findExchangeRate(targetCurrency.ID, s.dateOrToday(req.Date))
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToExchangeRateDto(targetCurrency.Code, rate), nil
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/currency"
+	"accountingsystem/internal/textrule"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s *CurrencyService) applyCurrencyCreation(req *currency.InsertRequest) (*dtos.CurrencyDto, error) {
+	newCurrency := models.Currency{
+		Code:       req.Code,
+		Title:      req.Title,
+		RowVersion: 0,
+	}
+
+	if err := s.db.Create(&newCurrency).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToCurrencyDto(&newCurrency), nil
+}
+
+func (s *CurrencyService) validateCurrencyInsertRequest(req *currency.InsertRequest) error {
+	if err := s.validateCurrencyCode(req.Code); err != nil {
+		return err
+	}
+	if err := s.validateTitleLength(req.Title); err != nil {
+		return err
+	}
+	if err := s.validateCodeUnique(req.Code); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *CurrencyService) validateCurrencyCode(code string) error {
+	if len(code) != 3 {
+		return constants.ErrInvalidCurrencyCode
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return constants.ErrInvalidCurrencyCode
+		}
+	}
+	return nil
+}
+
+func (s *CurrencyService) validateTitleLength(title string) error {
+	return textrule.CurrencyTitle.Validate(title)
+}
+
+func (s *CurrencyService) validateCodeUnique(code string) error {
+	var existingCurrency models.Currency
+	if err := s.db.Where("code = ?", code).First(&existingCurrency).Error; err == nil {
+		return constants.ErrCodeAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *CurrencyService) validateCurrencyExists(id int) (*models.Currency, error) {
+	var targetCurrency models.Currency
+	if err := s.db.Where("id = ?", id).First(&targetCurrency).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrCurrencyNotFound
+		}
+		return nil, err
+	}
+	return &targetCurrency, nil
+}
+
+func (s *CurrencyService) validateCurrencyExistsByCode(code string) (*models.Currency, error) {
+	var targetCurrency models.Currency
+	if err := s.db.Where("code = ?", code).First(&targetCurrency).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrCurrencyNotFound
+		}
+		return nil, err
+	}
+	return &targetCurrency, nil
+}
+
+func (s *CurrencyService) validateVersion(reqVersion int, targetVersion int) error {
+	if reqVersion != targetVersion {
+		return constants.ErrVersionOutdated
+	}
+	return nil
+}
+
+func (s *CurrencyService) applyCurrencyUpdate(req *currency.UpdateRequest, targetCurrency *models.Currency) (*dtos.CurrencyDto, error) {
+	targetCurrency.Title = req.Title
+	targetCurrency.RowVersion++
+
+	if err := s.db.Save(targetCurrency).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToCurrencyDto(targetCurrency), nil
+}
+
+func (s *CurrencyService) validateCurrencyUpdateRequest(req *currency.UpdateRequest) (*models.Currency, error) {
+	if err := s.validateTitleLength(req.Title); err != nil {
+		return nil, err
+	}
+	targetCurrency, err := s.validateCurrencyExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetCurrency.RowVersion); err != nil {
+		return nil, err
+	}
+	return targetCurrency, nil
+}
+
+func (s *CurrencyService) applyCurrencyDeletion(targetCurrency *models.Currency) error {
+	if err := s.db.Delete(targetCurrency).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *CurrencyService) validateCurrencyDeleteRequest(req *currency.DeleteRequest) (*models.Currency, error) {
+	targetCurrency, err := s.validateCurrencyExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetCurrency.RowVersion); err != nil {
+		return nil, err
+	}
+	if err := s.validateCurrencyHasNoReferences(targetCurrency.Code); err != nil {
+		return nil, err
+	}
+	return targetCurrency, nil
+}
+
+func (s *CurrencyService) validateCurrencyHasNoReferences(code string) error {
+	var VoucherItemRefrencingThisCurrency models.VoucherItem
+	if err := s.db.Where("currency_code = ?", code).First(&VoucherItemRefrencingThisCurrency).Error; err == nil {
+		return constants.ErrThereIsRefrenceToCurrency
+	}
+	return nil
+}
+
+func (s *CurrencyService) validateSetRateRequest(req *currency.SetRateRequest) (*models.Currency, string, error) {
+	targetCurrency, err := s.validateCurrencyExistsByCode(req.CurrencyCode)
+	if err != nil {
+		return nil, "", err
+	}
+	rate, err := s.parseExchangeRate(req.Rate)
+	if err != nil {
+		return nil, "", err
+	}
+	return targetCurrency, rate.FloatString(10), nil
+}
+
+// parseExchangeRate accepts a positive decimal and rounds it to the ten
+// fractional digits the rate columns can hold.
+func (s *CurrencyService) parseExchangeRate(text string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(text)
+	if !ok || strings.ContainsAny(text, "/eE") || rate.Sign() <= 0 {
+		return nil, constants.ErrInvalidExchangeRate
+	}
+	rate, _ = new(big.Rat).SetString(rate.FloatString(10))
+	if rate.Sign() <= 0 {
+		return nil, constants.ErrInvalidExchangeRate
+	}
+	return rate, nil
+}
+
+func (s *CurrencyService) dateOrToday(date time.Time) time.Time {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *CurrencyService) applyExchangeRateSet(req *currency.SetRateRequest, targetCurrency *models.Currency, rate string) (*dtos.ExchangeRateDto, error) {
+	currencyRate := models.CurrencyRate{
+		CurrencyID: targetCurrency.ID,
+		RateDate:   s.dateOrToday(req.Date),
+		Rate:       rate,
+	}
+
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency_id"}, {Name: "rate_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&currencyRate).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToExchangeRateDto(targetCurrency.Code, &currencyRate), nil
+}
+
+func (s *CurrencyService) findExchangeRate(currencyID int, date time.Time) (*models.CurrencyRate, error) {
+	var rate models.CurrencyRate
+	err := s.db.Where("currency_id = ? AND rate_date <= ?", currencyID, date).
+		Order("rate_date DESC").
+		First(&rate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrExchangeRateNotFound
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/currency"
+	"accountingsystem/internal/textrule"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateRandomCurrencyCode() string {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	b := make([]byte, 3)
+	for i := range b {
+		b[i] = letters[seededRand.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// Currency codes only have three letters, so a random code may already be
+// taken by an earlier test run.
+func createRandomCurrency() (*dtos.CurrencyDto, error) {
+	for {
+		createdCurrency, err := currencyService.CreateCurrency(testAdmin, &currency.InsertRequest{
+			Code:  generateRandomCurrencyCode(),
+			Title: "Test" + generateRandomString(20),
+		})
+		if !errors.Is(err, constants.ErrCodeAlreadyExists) {
+			return createdCurrency, err
+		}
+	}
+}
+
+func createRandomCurrencyWithRate(date time.Time, rate string) (*dtos.CurrencyDto, error) {
+	createdCurrency, err := createRandomCurrency()
+	if err != nil {
+		return nil, err
+	}
+	_, err = currencyService.SetExchangeRate(testAdmin, &currency.SetRateRequest{
+		CurrencyCode: createdCurrency.Code,
+		Date:         date,
+		Rate:         rate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return createdCurrency, nil
+}
+
+func Test_CreateCurrency_Succeeds_WithValidRequest(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+
+	require.Nil(t, err)
+	assert.Len(t, createdCurrency.Code, 3)
+	assert.Equal(t, 0, createdCurrency.RowVersion)
+}
+
+func Test_CreateCurrency_ReturnsErrInvalidCurrencyCode_WithLowercaseCode(t *testing.T) {
+	createdCurrency, err := currencyService.CreateCurrency(testAdmin, &currency.InsertRequest{Code: "usd", Title: "US Dollar"})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidCurrencyCode)
+	assert.Nil(t, createdCurrency)
+}
+
+func Test_CreateCurrency_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	accountant := auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}
+
+	createdCurrency, err := currencyService.CreateCurrency(accountant, &currency.InsertRequest{Code: generateRandomCurrencyCode(), Title: "Test" + generateRandomString(20)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, createdCurrency)
+}
+
+func Test_SetExchangeRate_ReturnsErrForbidden_WithViewer(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	viewer := auth.Principal{Username: "viewer", Role: auth.Viewer, TenantID: auth.DefaultTenantID}
+
+	rate, err := currencyService.SetExchangeRate(viewer, &currency.SetRateRequest{CurrencyCode: createdCurrency.Code, Date: time.Now(), Rate: "2"})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, rate)
+}
+
+func Test_CreateCurrency_Succeeds_WithPersianTitleLongerThan64Bytes(t *testing.T) {
+	title := strings.Repeat("ریال", 10)
+
+	createdCurrency, err := currencyService.CreateCurrency(testAdmin, &currency.InsertRequest{Code: generateRandomCurrencyCode(), Title: title})
+
+	if errors.Is(err, constants.ErrCodeAlreadyExists) {
+		t.Skip("random currency code is already taken")
+	}
+	require.Nil(t, err)
+	assert.Equal(t, title, createdCurrency.Title)
+}
+
+func Test_CreateCurrency_ReturnsErrTitleEmptyOrTooLong_WithTitleLongerThanConfiguredMaxLength(t *testing.T) {
+	defaultRule := textrule.CurrencyTitle.Rule()
+	require.Nil(t, textrule.CurrencyTitle.SetRule(textrule.Rule{MinLength: 1, MaxLength: 8}))
+	defer textrule.CurrencyTitle.SetRule(defaultRule)
+
+	createdCurrency, err := currencyService.CreateCurrency(testAdmin, &currency.InsertRequest{Code: generateRandomCurrencyCode(), Title: "Test" + generateRandomString(20)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
+	assert.Nil(t, createdCurrency)
+}
+
+func Test_SetExchangeRate_ReturnsErrInvalidExchangeRate_WithNegativeRate(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+
+	rate, err := currencyService.SetExchangeRate(testAdmin, &currency.SetRateRequest{
+		CurrencyCode: createdCurrency.Code,
+		Rate:         "-1.5",
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidExchangeRate)
+	assert.Nil(t, rate)
+}
+
+func Test_GetExchangeRate_ReturnsLatestRateOnOrBeforeDate(t *testing.T) {
+	createdCurrency, err := createRandomCurrencyWithRate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "500000")
+	require.Nil(t, err)
+	_, err = currencyService.SetExchangeRate(testAdmin, &currency.SetRateRequest{
+		CurrencyCode: createdCurrency.Code,
+		Date:         time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Rate:         "520000.25",
+	})
+	require.Nil(t, err)
+
+	rate, err := currencyService.GetExchangeRate(testAdmin, &currency.GetRateRequest{
+		CurrencyCode: createdCurrency.Code,
+		Date:         time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, "500000.0000000000", rate.Rate)
+}
+
+func Test_GetExchangeRate_ReturnsErrExchangeRateNotFound_BeforeFirstRate(t *testing.T) {
+	createdCurrency, err := createRandomCurrencyWithRate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "500000")
+	require.Nil(t, err)
+
+	rate, err := currencyService.GetExchangeRate(testAdmin, &currency.GetRateRequest{
+		CurrencyCode: createdCurrency.Code,
+		Date:         time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrExchangeRateNotFound)
+	assert.Nil(t, rate)
+}
@@ -1,30 +1,92 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
 	"accountingsystem/internal/mappers"
 	"accountingsystem/internal/requests/dl"
+	"context"
+	"errors"
 	"log"
 
 	"gorm.io/gorm"
 )
 
 type DLService struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID int
 }
 
 func (s *DLService) InitService(db *gorm.DB) {
 	s.db = db
 }
 
-func (s *DLService) CreateDL(req *dl.InsertRequest) (*dtos.DLDto, error) {
-	if err := s.validateDLInsertRequest(req); err != nil {
+func (s *DLService) forTenant(tenantID int) *DLService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+// withContext returns a copy of the service whose queries run under ctx, so
+// they are abandoned once it is canceled or its deadline passes.
+func (s *DLService) withContext(ctx context.Context) *DLService {
+	scoped := *s
+	scoped.db = s.db.WithContext(ctx)
+	return &scoped
+}
+
+func (s *DLService) CreateDL(actor auth.Principal, req *dl.InsertRequest) (*dtos.DLDto, error) {
+	return s.CreateDLContext(context.Background(), actor, req)
+}
+
+func (s *DLService) CreateDLContext(ctx context.Context, actor auth.Principal, req *dl.InsertRequest) (*dtos.DLDto, error) {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionCreate); err != nil {
 		return nil, err
 	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	creation, err := s.validateDLIdempotencyKey(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	replayed, err := s.replayDLCreation(creation)
+	if errors.Is(err, constants.ErrIdempotencyKeyReused) {
+		return nil, err
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while replaying DL creation: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+	if replayed != nil {
+		return replayed, nil
+	}
+
+	if err := s.validateDLInsertRequest(req); err != nil {
+		// A concurrent request with the same key may have created the DL
+		// since the key was looked up.
+		if errors.Is(err, constants.ErrCodeAlreadyExists) || errors.Is(err, constants.ErrTitleAlreadyExists) {
+			if replayed, replayErr := s.replayDLCreation(creation); replayErr == nil && replayed != nil {
+				return replayed, nil
+			}
+		}
+		return nil, contextError(ctx, err)
+	}
 
-	dlDto, err := s.applyDLCreation(req)
+	dlDto, err := s.applyDLCreation(req, creation)
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		dlDto, err = s.replayDLCreation(creation)
+	}
+	if errors.Is(err, constants.ErrIdempotencyKeyReused) {
+		return nil, err
+	}
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while creating DL: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
@@ -32,14 +94,26 @@ func (s *DLService) CreateDL(req *dl.InsertRequest) (*dtos.DLDto, error) {
 	return dlDto, nil
 }
 
-func (s *DLService) UpdateDL(req *dl.UpdateRequest) (*dtos.DLDto, error) {
+func (s *DLService) UpdateDL(actor auth.Principal, req *dl.UpdateRequest) (*dtos.DLDto, error) {
+	return s.UpdateDLContext(context.Background(), actor, req)
+}
+
+func (s *DLService) UpdateDLContext(ctx context.Context, actor auth.Principal, req *dl.UpdateRequest) (*dtos.DLDto, error) {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetDL, err := s.validateDLUpdateRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 
 	dlDto, err := s.applyDLUpdate(req, targetDL)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while updating DL: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
@@ -47,13 +121,25 @@ func (s *DLService) UpdateDL(req *dl.UpdateRequest) (*dtos.DLDto, error) {
 	return dlDto, nil
 }
 
-func (s *DLService) DeleteDL(req *dl.DeleteRequest) error {
+func (s *DLService) DeleteDL(actor auth.Principal, req *dl.DeleteRequest) error {
+	return s.DeleteDLContext(context.Background(), actor, req)
+}
+
+func (s *DLService) DeleteDLContext(ctx context.Context, actor auth.Principal, req *dl.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetDL, err := s.validateDLDeleteRequest(req)
 	if err != nil {
-		return err
+		return contextError(ctx, err)
 	}
 
 	if err := s.applyDLDeletion(targetDL); err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
 		log.Printf("unexpected error while deleting DL: %v", err)
 		return constants.ErrUnexpectedError
 	}
@@ -61,11 +147,94 @@ func (s *DLService) DeleteDL(req *dl.DeleteRequest) error {
 	return nil
 }
 
-func (s *DLService) GetDL(req *dl.GetRequest) (*dtos.DLDto, error) {
+func (s *DLService) GetDL(actor auth.Principal, req *dl.GetRequest) (*dtos.DLDto, error) {
+	return s.GetDLContext(context.Background(), actor, req)
+}
+
+func (s *DLService) GetDLContext(ctx context.Context, actor auth.Principal, req *dl.GetRequest) (*dtos.DLDto, error) {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetDL, err := s.validateDLGetRequest(req)
 	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	return mappers.ToDLDto(targetDL), nil
+}
+
+func (s *DLService) GetDLByCode(actor auth.Principal, req *dl.GetByCodeRequest) (*dtos.DLDto, error) {
+	return s.GetDLByCodeContext(context.Background(), actor, req)
+}
+
+func (s *DLService) GetDLByCodeContext(ctx context.Context, actor auth.Principal, req *dl.GetByCodeRequest) (*dtos.DLDto, error) {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionRead); err != nil {
 		return nil, err
 	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetDL, err := s.validateDLGetByCodeRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
 
 	return mappers.ToDLDto(targetDL), nil
 }
+
+func (s *DLService) GetDLs(actor auth.Principal, req *dl.GetManyRequest) ([]dtos.DLDto, error) {
+	return s.GetDLsContext(context.Background(), actor, req)
+}
+
+func (s *DLService) GetDLsContext(ctx context.Context, actor auth.Principal, req *dl.GetManyRequest) ([]dtos.DLDto, error) {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	if err := s.validateDLGetManyRequest(req); err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	dlDtos, err := s.applyDLsGet(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting DLs: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return dlDtos, nil
+}
+
+func (s *DLService) NextDLCode(actor auth.Principal, req *dl.NextCodeRequest) (string, error) {
+	return s.NextDLCodeContext(context.Background(), actor, req)
+}
+
+func (s *DLService) NextDLCodeContext(ctx context.Context, actor auth.Principal, req *dl.NextCodeRequest) (string, error) {
+	if err := auth.Authorize(actor, auth.EntityDL, auth.ActionCreate); err != nil {
+		return "", err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	template, err := s.validateDLNextCodeRequest(req)
+	if err != nil {
+		return "", contextError(ctx, err)
+	}
+
+	code, err := s.applyDLNextCode(req, template)
+	if errors.Is(err, constants.ErrNoFreeCode) {
+		return "", contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", contextError(ctx, err)
+		}
+		log.Printf("unexpected error while allocating next DL code: %v", err)
+		return "", constants.ErrUnexpectedError
+	}
+
+	return code, nil
+}
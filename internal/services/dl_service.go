@@ -1,53 +1,96 @@
 package services
 
 import (
+	"accountingsystem/internal/codetemplate"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/idempotency"
 	"accountingsystem/internal/mappers"
 	"accountingsystem/internal/models"
+	"accountingsystem/internal/normalize"
 	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/textrule"
 	"errors"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
 )
 
-func (s *DLService) applyDLCreation(req *dl.InsertRequest) (*dtos.DLDto, error) {
+func (s *DLService) applyDLCreation(req *dl.InsertRequest, creation *idempotentCreation) (*dtos.DLDto, error) {
 	dl := models.DL{
+		TenantID:   s.tenantID,
 		Code:       req.Code,
 		Title:      req.Title,
 		RowVersion: 0,
 	}
 
-	if err := s.db.Create(&dl).Error; err != nil {
+	var dlDto *dtos.DLDto
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := creation.claim(tx); err != nil {
+			return err
+		}
+		if err := tx.Create(&dl).Error; err != nil {
+			return err
+		}
+		dlDto = mappers.ToDLDto(&dl)
+		if err := recordEvent(tx, s.tenantID, events.DLCreated, events.AggregateDL, dl.ID, dlDto); err != nil {
+			return err
+		}
+		return creation.record(tx, dlDto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return mappers.ToDLDto(&dl), nil
+	return dlDto, nil
+}
+
+func (s *DLService) validateDLIdempotencyKey(req *dl.InsertRequest) (*idempotentCreation, error) {
+	payload := *req
+	payload.IdempotencyKey = ""
+	return newIdempotentCreation(s.db, s.tenantID, idempotency.ScopeDL, req.IdempotencyKey, payload)
+}
+
+// replayDLCreation returns the DL recorded for the idempotency key of the
+// request, or nil when there is none.
+func (s *DLService) replayDLCreation(creation *idempotentCreation) (*dtos.DLDto, error) {
+	var dlDto dtos.DLDto
+	found, err := creation.replay(&dlDto)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &dlDto, nil
 }
 
 func (s *DLService) validateDLInsertRequest(req *dl.InsertRequest) error {
-	if err := s.validateCodeAndTitleLength(req.Code, req.Title); err != nil {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := s.validateCodeAndTitleRules(req.Code, req.Title); err != nil {
 		return err
 	}
 	if err := s.validateCodeAndTitleUnique(req.Code, req.Title); err != nil {
 		return err
 	}
+	if err := s.validateCodeTemplate(req.Code); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (s *DLService) validateCodeAndTitleLength(code string, title string) error {
-	if code == "" || len(code) > 64 {
-		return constants.ErrCodeEmptyOrTooLong
+func (s *DLService) validateCodeAndTitleRules(code string, title string) error {
+	if err := textrule.DLCode.Validate(code); err != nil {
+		return err
 	}
-	if title == "" || len(title) > 64 {
-		return constants.ErrTitleEmptyOrTooLong
+	if err := textrule.DLTitle.Validate(title); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (s *DLService) validateCodeAndTitleUnique(code string, title string) error {
 	var existingDL models.DL
-	if err := s.db.Where("code = ? OR title = ?", code, title).First(&existingDL).Error; err == nil {
+	if err := s.db.Where("tenant_id = ? AND (code = ? OR title = ?)", s.tenantID, code, title).First(&existingDL).Error; err == nil {
 		if existingDL.Code == code {
 			return constants.ErrCodeAlreadyExists
 		}
@@ -64,15 +107,25 @@ func (s *DLService) applyDLUpdate(req *dl.UpdateRequest, targetDL *models.DL) (*
 	targetDL.Code = req.Code
 	targetDL.Title = req.Title
 	targetDL.RowVersion++
-	if err := s.db.Save(targetDL).Error; err != nil {
+
+	dlDto := mappers.ToDLDto(targetDL)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(targetDL).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, s.tenantID, events.DLUpdated, events.AggregateDL, targetDL.ID, dlDto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return mappers.ToDLDto(targetDL), nil
+	return dlDto, nil
 }
 
 func (s *DLService) validateDLUpdateRequest(req *dl.UpdateRequest) (*models.DL, error) {
-	if err := s.validateCodeAndTitleLength(req.Code, req.Title); err != nil {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := s.validateCodeAndTitleRules(req.Code, req.Title); err != nil {
 		return nil, err
 	}
 	targetDL, err := s.validateDLExists(req.ID)
@@ -85,12 +138,20 @@ func (s *DLService) validateDLUpdateRequest(req *dl.UpdateRequest) (*models.DL,
 	if err := s.validateCodeAndTitleUniqueWithDifferentId(req.Code, req.Title, req.ID); err != nil {
 		return nil, err
 	}
+	if err := s.validateCodeTemplate(req.Code); err != nil {
+		return nil, err
+	}
+	if req.Code != targetDL.Code {
+		if err := s.validateCodeHasNoChildren(targetDL.Code); err != nil {
+			return nil, err
+		}
+	}
 	return targetDL, nil
 }
 
 func (s *DLService) validateCodeAndTitleUniqueWithDifferentId(code string, title string, id int) error {
 	var existingDL models.DL
-	if err := s.db.Where("(code = ? OR title = ?) AND id != ?", code, title, id).First(&existingDL).Error; err == nil {
+	if err := s.db.Where("tenant_id = ? AND (code = ? OR title = ?) AND id != ?", s.tenantID, code, title, id).First(&existingDL).Error; err == nil {
 		if existingDL.Code == code {
 			return constants.ErrCodeAlreadyExists
 		}
@@ -112,7 +173,7 @@ func (s *DLService) validateVersion(reqVersion int, targetVersion int) error {
 
 func (s *DLService) validateDLExists(id int) (*models.DL, error) {
 	var targetDL models.DL
-	if err := s.db.Where("id = ?", id).First(&targetDL).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetDL).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, constants.ErrDLNotFound
 		}
@@ -122,10 +183,12 @@ func (s *DLService) validateDLExists(id int) (*models.DL, error) {
 }
 
 func (s *DLService) applyDLDeletion(targetDL *models.DL) error {
-	if err := s.db.Delete(&targetDL).Error; err != nil {
-		return err
-	}
-	return nil
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&targetDL).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, s.tenantID, events.DLDeleted, events.AggregateDL, targetDL.ID, mappers.ToDLDto(targetDL))
+	})
 }
 
 func (s *DLService) validateDLDeleteRequest(req *dl.DeleteRequest) (*models.DL, error) {
@@ -139,6 +202,9 @@ func (s *DLService) validateDLDeleteRequest(req *dl.DeleteRequest) (*models.DL,
 	if err := s.validateDLHasNoReferences(req.ID); err != nil {
 		return nil, err
 	}
+	if err := s.validateCodeHasNoChildren(targetDL.Code); err != nil {
+		return nil, err
+	}
 	return targetDL, nil
 }
 
@@ -147,6 +213,10 @@ func (s *DLService) validateDLHasNoReferences(id int) error {
 	if err := s.db.Where("dl_id = ?", id).First(&VoucherItemRefrencingThisDL).Error; err == nil {
 		return constants.ErrThereIsRefrenceToDL
 	}
+	var TemplateItemRefrencingThisDL models.VoucherTemplateItem
+	if err := s.db.Where("dl_id = ?", id).First(&TemplateItemRefrencingThisDL).Error; err == nil {
+		return constants.ErrThereIsRefrenceToDL
+	}
 	return nil
 }
 
@@ -157,3 +227,120 @@ func (s *DLService) validateDLGetRequest(req *dl.GetRequest) (*models.DL, error)
 	}
 	return targetDL, nil
 }
+
+func (s *DLService) validateDLExistsByCode(code string) (*models.DL, error) {
+	var targetDL models.DL
+	if err := s.db.Where("tenant_id = ? AND code = ?", s.tenantID, normalize.Text(code)).First(&targetDL).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrDLNotFound
+		}
+		return nil, err
+	}
+	return &targetDL, nil
+}
+
+func (s *DLService) validateDLGetByCodeRequest(req *dl.GetByCodeRequest) (*models.DL, error) {
+	targetDL, err := s.validateDLExistsByCode(req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return targetDL, nil
+}
+
+func (s *DLService) validateDLGetManyRequest(req *dl.GetManyRequest) error {
+	if len(req.IDs) > constants.MaxLookupIDs {
+		return constants.ErrTooManyIDs
+	}
+	return nil
+}
+
+func (s *DLService) applyDLsGet(req *dl.GetManyRequest) ([]dtos.DLDto, error) {
+	dlDtos := []dtos.DLDto{}
+	if len(req.IDs) == 0 {
+		return dlDtos, nil
+	}
+
+	var dls []models.DL
+	if err := s.db.Where("tenant_id = ? AND id IN ?", s.tenantID, req.IDs).Order("id").Find(&dls).Error; err != nil {
+		return nil, err
+	}
+	for i := range dls {
+		dlDtos = append(dlDtos, *mappers.ToDLDto(&dls[i]))
+	}
+	return dlDtos, nil
+}
+
+func (s *DLService) validateCodeTemplate(code string) error {
+	template := codetemplate.DL.Template()
+	if err := template.Validate(code); err != nil {
+		return err
+	}
+	parentCode := template.ParentOf(code)
+	if !template.RequireParent || parentCode == "" {
+		return nil
+	}
+	if _, err := s.validateDLExistsByCode(parentCode); err != nil {
+		if errors.Is(err, constants.ErrDLNotFound) {
+			return constants.ErrParentCodeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *DLService) validateCodeHasNoChildren(code string) error {
+	if !codetemplate.DL.Template().IsHierarchical() {
+		return nil
+	}
+	var child models.DL
+	length := utf8.RuneCountInString(code)
+	err := s.db.Where("tenant_id = ? AND char_length(code) > ? AND left(code, ?) = ?", s.tenantID, length, length, code).First(&child).Error
+	if err == nil {
+		return constants.ErrCodeHasChildren
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *DLService) validateDLNextCodeRequest(req *dl.NextCodeRequest) (codetemplate.Template, error) {
+	template := codetemplate.DL.Template()
+	if !template.IsHierarchical() {
+		return codetemplate.Template{}, constants.ErrCodeTemplateNotConfigured
+	}
+	req.ParentCode = normalize.Text(req.ParentCode)
+	if req.ParentCode == "" {
+		return template, nil
+	}
+	if err := template.Validate(req.ParentCode); err != nil {
+		return codetemplate.Template{}, err
+	}
+	if _, err := s.validateDLExistsByCode(req.ParentCode); err != nil {
+		if errors.Is(err, constants.ErrDLNotFound) {
+			return codetemplate.Template{}, constants.ErrParentCodeNotFound
+		}
+		return codetemplate.Template{}, err
+	}
+	return template, nil
+}
+
+func (s *DLService) applyDLNextCode(req *dl.NextCodeRequest, template codetemplate.Template) (string, error) {
+	level := 1
+	if req.ParentCode != "" {
+		level = template.Level(req.ParentCode) + 1
+	}
+	if level > len(template.Segments) {
+		return "", constants.ErrNoFreeCode
+	}
+
+	var siblings []string
+	parentLength := utf8.RuneCountInString(req.ParentCode)
+	if err := s.db.Model(&models.DL{}).
+		Where("tenant_id = ? AND char_length(code) = ? AND left(code, ?) = ?", s.tenantID, template.LengthAt(level), parentLength, req.ParentCode).
+		Pluck("code", &siblings).Error; err != nil {
+		return "", err
+	}
+
+	return template.NextCode(req.ParentCode, siblings)
+}
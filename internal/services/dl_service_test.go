@@ -1,10 +1,14 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/requests/dl"
 	"accountingsystem/internal/requests/sl"
 	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/textrule"
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,7 +23,7 @@ func Test_CreateDL_Succeeds_WithValidRequest(t *testing.T) {
 		Title: randomTitle,
 	}
 
-	dl, err := dlService.CreateDL(req)
+	dl, err := dlService.CreateDL(testAdmin, req)
 
 	require.Nil(t, err)
 	assert.Equal(t, dl.Code, req.Code)
@@ -33,7 +37,7 @@ func Test_CreateDL_ReturnsErrCodeEmptyOrTooLong_WithEmptyCode(t *testing.T) {
 		Title: randomTitle,
 	}
 
-	dl, err := dlService.CreateDL(req)
+	dl, err := dlService.CreateDL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -48,7 +52,7 @@ func Test_CreateDL_ReturnsErrCodeEmptyOrTooLong_WithTooLongCode(t *testing.T) {
 		Title: randomTitle,
 	}
 
-	dl, err := dlService.CreateDL(req)
+	dl, err := dlService.CreateDL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -62,7 +66,7 @@ func Test_CreateDL_ReturnsErrTitleEmptyOrTooLong_WithEmptyTitle(t *testing.T) {
 		Title: "",
 	}
 
-	dl, err := dlService.CreateDL(req)
+	dl, err := dlService.CreateDL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -77,7 +81,7 @@ func Test_CreateDL_ReturnsErrTitleEmptyOrTooLong_WithTooLongTitle(t *testing.T)
 		Title: randomTitle,
 	}
 
-	dl, err := dlService.CreateDL(req)
+	dl, err := dlService.CreateDL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -92,7 +96,7 @@ func Test_CreateDL_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
 		Title: randomTitle,
 	}
 
-	_, err := dlService.CreateDL(validReq)
+	_, err := dlService.CreateDL(testAdmin, validReq)
 	require.Nil(t, err)
 
 	randomTitleNotExisting := "Test" + generateRandomString(20)
@@ -101,7 +105,7 @@ func Test_CreateDL_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
 		Title: randomTitleNotExisting,
 	}
 
-	dl, err := dlService.CreateDL(reqWithExistingCode)
+	dl, err := dlService.CreateDL(testAdmin, reqWithExistingCode)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
@@ -116,7 +120,7 @@ func Test_CreateDL_ReturnsErrTitleAlreadyExists_WithExistingTitle(t *testing.T)
 		Title: randomTitle,
 	}
 
-	_, err := dlService.CreateDL(validReq)
+	_, err := dlService.CreateDL(testAdmin, validReq)
 	require.Nil(t, err)
 
 	randomCodeNotExisting := "DL" + generateRandomString(20)
@@ -125,7 +129,7 @@ func Test_CreateDL_ReturnsErrTitleAlreadyExists_WithExistingTitle(t *testing.T)
 		Title: randomTitle,
 	}
 
-	dl, err := dlService.CreateDL(reqWithExistingTitle)
+	dl, err := dlService.CreateDL(testAdmin, reqWithExistingTitle)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleAlreadyExists)
@@ -145,7 +149,7 @@ func Test_UpdateDL_Succeeds_WithValidRequest(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.Nil(t, err)
 	assert.Equal(t, updatedDL.Code, updateReq.Code)
@@ -163,7 +167,7 @@ func Test_UpdateDL_ReturnsErrCodeEmptyOrTooLong_WithEmptyCode(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -182,7 +186,7 @@ func Test_UpdateDL_ReturnsErrCodeEmptyOrTooLong_WithTooLongCode(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -200,7 +204,7 @@ func Test_UpdateDL_ReturnsErrTitleEmptyOrTooLong_WithEmptyTitle(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -219,7 +223,7 @@ func Test_UpdateDL_ReturnsErrTitleEmptyOrTooLong_WithTooLongTitle(t *testing.T)
 		Version: createdDL.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -237,7 +241,7 @@ func Test_UpdateDL_ReturnsErrDLNotFound_WithNonExistingID(t *testing.T) {
 		Title: newRandomTitle,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotFound)
@@ -256,7 +260,7 @@ func Test_UpdateDL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	_, err := dlService.UpdateDL(updateReq)
+	_, err := dlService.UpdateDL(testAdmin, updateReq)
 	require.Nil(t, err)
 
 	newRandomCode2 := "DL" + generateRandomString(20)
@@ -268,7 +272,7 @@ func Test_UpdateDL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReqWithOutdatedVersion)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReqWithOutdatedVersion)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
@@ -287,7 +291,7 @@ func Test_UpdateDL_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
 		Version: createdDL1.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
@@ -306,7 +310,7 @@ func Test_UpdateDL_ReturnsErrTitleAlreadyExists_WithExistingTitle(t *testing.T)
 		Version: createdDL1.RowVersion,
 	}
 
-	updatedDL, err := dlService.UpdateDL(updateReq)
+	updatedDL, err := dlService.UpdateDL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleAlreadyExists)
@@ -321,7 +325,7 @@ func Test_DeleteDL_Succeeds_WithValidRequest(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	err := dlService.DeleteDL(deleteReq)
+	err := dlService.DeleteDL(testAdmin, deleteReq)
 
 	require.Nil(t, err)
 }
@@ -333,7 +337,7 @@ func Test_DeleteDL_ReturnsErrDLNotFound_WithNonExistingID(t *testing.T) {
 		ID: newID,
 	}
 
-	err := dlService.DeleteDL(deleteReq)
+	err := dlService.DeleteDL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotFound)
@@ -347,11 +351,11 @@ func Test_DeleteDL_ReturnsErrDLNotFound_WithDeletedID(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	err := dlService.DeleteDL(deleteReq)
+	err := dlService.DeleteDL(testAdmin, deleteReq)
 
 	require.Nil(t, err)
 
-	err = dlService.DeleteDL(deleteReq)
+	err = dlService.DeleteDL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotFound)
@@ -369,7 +373,7 @@ func Test_DeleteDL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	_, err := dlService.UpdateDL(updateReq)
+	_, err := dlService.UpdateDL(testAdmin, updateReq)
 	require.Nil(t, err)
 
 	deleteReq := &dl.DeleteRequest{
@@ -377,14 +381,14 @@ func Test_DeleteDL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdDL.RowVersion,
 	}
 
-	err = dlService.DeleteDL(deleteReq)
+	err = dlService.DeleteDL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
 }
 
 func Test_DeleteDL_ReturnsErrThereIsRefrenceToDL_WithExistingReference(t *testing.T) {
-	slWithDL, err := slService.CreateSL(&sl.InsertRequest{
+	slWithDL, err := slService.CreateSL(testAdmin, &sl.InsertRequest{
 		Code:  "SL" + generateRandomString(20),
 		Title: "SLWithDL" + generateRandomString(20),
 		HasDL: true,
@@ -412,14 +416,14 @@ func Test_DeleteDL_ReturnsErrThereIsRefrenceToDL_WithExistingReference(t *testin
 		Number:       generateRandomString(20),
 		VoucherItems: items,
 	}
-	_, err = voucherService.CreateVoucher(req)
+	_, err = voucherService.CreateVoucher(testAdmin, req)
 	require.Nil(t, err)
 
 	deleteReq := &dl.DeleteRequest{
 		ID:      createdDL.ID,
 		Version: createdDL.RowVersion,
 	}
-	err = dlService.DeleteDL(deleteReq)
+	err = dlService.DeleteDL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrThereIsRefrenceToDL)
@@ -432,7 +436,7 @@ func Test_GetDLByID_Succeeds_WithValidID(t *testing.T) {
 		ID: createdDL.ID,
 	}
 
-	foundDL, err := dlService.GetDL(getReq)
+	foundDL, err := dlService.GetDL(testAdmin, getReq)
 
 	require.Nil(t, err)
 	assert.Equal(t, createdDL.ID, foundDL.ID)
@@ -448,9 +452,245 @@ func Test_GetDLByID_ReturnsErrDLNotFound_WithNonExistingID(t *testing.T) {
 		ID: newID,
 	}
 
-	foundDL, err := dlService.GetDL(getReq)
+	foundDL, err := dlService.GetDL(testAdmin, getReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotFound)
 	assert.Nil(t, foundDL)
 }
+
+func Test_GetDLByCode_Succeeds_WithExistingCode(t *testing.T) {
+	createdDL, err := createRandomDL()
+	require.Nil(t, err)
+
+	foundDL, err := dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: createdDL.Code})
+
+	require.Nil(t, err)
+	assert.Equal(t, createdDL.ID, foundDL.ID)
+	assert.Equal(t, createdDL.Title, foundDL.Title)
+}
+
+func Test_GetDLByCode_ReturnsErrDLNotFound_WithNonExistingCode(t *testing.T) {
+	foundDL, err := dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: "DL" + generateRandomString(30)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDLNotFound)
+	assert.Nil(t, foundDL)
+}
+
+func Test_GetDLs_ReturnsExistingDLs_WithMixedIDs(t *testing.T) {
+	first, err := createRandomDL()
+	require.Nil(t, err)
+	second, err := createRandomDL()
+	require.Nil(t, err)
+	admin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	otherTenantDL, err := dlService.CreateDL(admin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+
+	dlDtos, err := dlService.GetDLs(testAdmin, &dl.GetManyRequest{IDs: []int{second.ID, first.ID, otherTenantDL.ID, -1}})
+
+	require.Nil(t, err)
+	require.Len(t, dlDtos, 2)
+	assert.Equal(t, first.ID, dlDtos[0].ID)
+	assert.Equal(t, first.Code, dlDtos[0].Code)
+	assert.Equal(t, second.ID, dlDtos[1].ID)
+}
+
+func Test_GetDLs_ReturnsErrTooManyIDs_WithMoreThan500IDs(t *testing.T) {
+	dlDtos, err := dlService.GetDLs(testAdmin, &dl.GetManyRequest{IDs: make([]int, 501)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTooManyIDs)
+	assert.Nil(t, dlDtos)
+}
+
+func Test_CreateDL_ReturnsErrTitleAlreadyExists_WithArabicKafVariantOfExistingTitle(t *testing.T) {
+	suffix := generateRandomString(20)
+	_, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "کد " + suffix})
+	require.Nil(t, err)
+
+	createdDL, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "كد " + suffix})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTitleAlreadyExists)
+	assert.Nil(t, createdDL)
+}
+
+func Test_CreateDL_ReturnsErrTitleAlreadyExists_WithZeroWidthVariantOfExistingTitle(t *testing.T) {
+	suffix := generateRandomString(20)
+	_, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "می\u200cشود " + suffix})
+	require.Nil(t, err)
+
+	createdDL, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "می\u200c\u200d\u200cشود\u200c " + suffix})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTitleAlreadyExists)
+	assert.Nil(t, createdDL)
+}
+
+func Test_CreateDL_StoresNormalizedCodeAndTitle_WithPersianDigitsAndSurroundingSpaces(t *testing.T) {
+	suffix := generateRandomString(20)
+
+	createdDL, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "  DL۱۲۳" + suffix + " ", Title: " علي   " + suffix})
+
+	require.Nil(t, err)
+	assert.Equal(t, "DL123"+suffix, createdDL.Code)
+	assert.Equal(t, "علی "+suffix, createdDL.Title)
+
+	fetchedDL, err := dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: "DL١٢٣" + suffix})
+	require.Nil(t, err)
+	assert.Equal(t, createdDL.ID, fetchedDL.ID)
+}
+
+func Test_CreateDL_Succeeds_WithPersianTitleLongerThan64Bytes(t *testing.T) {
+	req := &dl.InsertRequest{
+		Code:  "DL" + generateRandomString(20),
+		Title: strings.Repeat("ح", 36) + generateRandomString(4),
+	}
+
+	createdDL, err := dlService.CreateDL(testAdmin, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, req.Title, createdDL.Title)
+}
+
+func Test_CreateDL_ReturnsErrTitleFormatInvalid_WithCharacterOutsideConfiguredClasses(t *testing.T) {
+	defaultRule := textrule.DLTitle.Rule()
+	require.Nil(t, textrule.DLTitle.SetRule(textrule.Rule{MinLength: 1, MaxLength: 64, Classes: []textrule.Class{textrule.Letters, textrule.Spaces}}))
+	defer textrule.DLTitle.SetRule(defaultRule)
+
+	createdDL, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test#" + generateRandomString(10)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTitleFormatInvalid)
+	assert.Nil(t, createdDL)
+}
+
+func Test_NextDLCode_ReturnsErrCodeTemplateNotConfigured_WithoutSegments(t *testing.T) {
+	code, err := dlService.NextDLCode(testAdmin, &dl.NextCodeRequest{})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeTemplateNotConfigured)
+	assert.Empty(t, code)
+}
+
+func Test_CreateDL_ReturnsErrForbidden_WithViewer(t *testing.T) {
+	req := &dl.InsertRequest{
+		Code:  "DL" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	}
+
+	dl, err := dlService.CreateDL(auth.Principal{Username: "viewer", Role: auth.Viewer, TenantID: auth.DefaultTenantID}, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, dl)
+}
+
+func Test_CreateDL_Succeeds_WithAccountant(t *testing.T) {
+	req := &dl.InsertRequest{
+		Code:  "DL" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	}
+
+	dl, err := dlService.CreateDL(auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, req.Code, dl.Code)
+}
+
+func Test_DeleteDL_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	err = dlService.DeleteDL(auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}, &dl.DeleteRequest{ID: dlDto.ID, Version: dlDto.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	_, err = dlService.GetDL(testAdmin, &dl.GetRequest{ID: dlDto.ID})
+	assert.Nil(t, err)
+}
+
+func Test_GetDL_ReturnsErrForbidden_WithoutRole(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	dl, err := dlService.GetDL(auth.Principal{}, &dl.GetRequest{ID: dlDto.ID})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, dl)
+}
+
+func Test_CreateDLContext_ReturnsErrCanceled_WithCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := &dl.InsertRequest{
+		Code:  "DL" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	}
+
+	dlDto, err := dlService.CreateDLContext(ctx, testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCanceled)
+	assert.Nil(t, dlDto)
+	_, err = dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: req.Code})
+	assert.ErrorIs(t, err, constants.ErrDLNotFound)
+}
+
+func Test_GetDLsContext_ReturnsErrCanceled_WithCanceledContext(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dlDtos, err := dlService.GetDLsContext(ctx, testAdmin, &dl.GetManyRequest{IDs: []int{dlDto.ID}})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCanceled)
+	assert.Nil(t, dlDtos)
+}
+
+func Test_CreateDL_ReturnsOriginalDL_WithReplayedIdempotencyKey(t *testing.T) {
+	req := &dl.InsertRequest{
+		Code:           "DL" + generateRandomString(20),
+		Title:          "Test" + generateRandomString(20),
+		IdempotencyKey: generateRandomString(32),
+	}
+	replay := *req
+
+	created, err := dlService.CreateDL(testAdmin, req)
+	require.Nil(t, err)
+	replayed, err := dlService.CreateDL(testAdmin, &replay)
+
+	require.Nil(t, err)
+	assert.Equal(t, created, replayed)
+}
+
+func Test_CreateDL_ReturnsErrIdempotencyKeyReused_WithDifferentPayload(t *testing.T) {
+	key := generateRandomString(32)
+	_, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20), IdempotencyKey: key})
+	require.Nil(t, err)
+
+	dlDto, err := dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20), IdempotencyKey: key})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrIdempotencyKeyReused)
+	assert.Nil(t, dlDto)
+}
+
+func Test_CreateDL_ReturnsErrIdempotencyKeyTooLong_WithLongKey(t *testing.T) {
+	req := &dl.InsertRequest{
+		Code:           "DL" + generateRandomString(20),
+		Title:          "Test" + generateRandomString(20),
+		IdempotencyKey: generateRandomString(129),
+	}
+
+	dlDto, err := dlService.CreateDL(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrIdempotencyKeyTooLong)
+	assert.Nil(t, dlDto)
+}
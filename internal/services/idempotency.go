@@ -0,0 +1,105 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/idempotency"
+	"accountingsystem/internal/models"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// errIdempotencyKeyTaken reports that a concurrent request with the same key
+// claimed it first; the creation is rolled back and the result of that
+// request is replayed instead.
+var errIdempotencyKeyTaken = errors.New("idempotency key was recorded concurrently")
+
+// idempotentCreation remembers the result of a create request under the key
+// its client sent. A nil *idempotentCreation stands for a request without a
+// key, for which nothing is replayed or recorded.
+type idempotentCreation struct {
+	db          *gorm.DB
+	tenantID    int
+	scope       string
+	key         string
+	requestHash string
+}
+
+func newIdempotentCreation(db *gorm.DB, tenantID int, scope string, key string, payload any) (*idempotentCreation, error) {
+	if key == "" {
+		return nil, nil
+	}
+	if err := idempotency.ValidateKey(key); err != nil {
+		return nil, err
+	}
+	requestHash, err := idempotency.Hash(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &idempotentCreation{db: db, tenantID: tenantID, scope: scope, key: key, requestHash: requestHash}, nil
+}
+
+// replay decodes the recorded result into result and reports whether there
+// was one. It fails with ErrIdempotencyKeyReused when the key was recorded
+// for a different payload.
+func (c *idempotentCreation) replay(result any) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	var recorded models.IdempotencyKey
+	err := c.db.Where("tenant_id = ? AND scope = ? AND key = ? AND expires_at > ?", c.tenantID, c.scope, c.key, time.Now().UTC()).
+		First(&recorded).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if recorded.RequestHash != c.requestHash {
+		return false, constants.ErrIdempotencyKeyReused
+	}
+	if err := json.Unmarshal(recorded.Response, result); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// claim takes the key in tx before anything is created, so a concurrent
+// request with the same key waits for tx and then fails with
+// errIdempotencyKeyTaken instead of creating a duplicate. A record whose
+// retention has passed is taken over.
+func (c *idempotentCreation) claim(tx *gorm.DB) error {
+	if c == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	inserted := tx.Exec(`INSERT INTO idempotency_key (tenant_id, scope, key, request_hash, response, created_at, expires_at)
+		VALUES (?, ?, ?, ?, 'null', ?, ?)
+		ON CONFLICT (tenant_id, scope, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response = EXCLUDED.response, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at`,
+		c.tenantID, c.scope, c.key, c.requestHash, now, now.Add(idempotency.Retention()))
+	if inserted.Error != nil {
+		return inserted.Error
+	}
+	if inserted.RowsAffected == 0 {
+		return errIdempotencyKeyTaken
+	}
+	return nil
+}
+
+// record stores result under the key claimed in tx, the transaction that
+// created it, so the two are committed together.
+func (c *idempotentCreation) record(tx *gorm.DB, result any) error {
+	if c == nil {
+		return nil
+	}
+	response, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return tx.Exec(`UPDATE idempotency_key SET response = ? WHERE tenant_id = ? AND scope = ? AND key = ?`,
+		string(response), c.tenantID, c.scope, c.key).Error
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/models"
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyService removes the results kept for replays once their
+// retention has passed, so the idempotency_key table does not grow forever.
+type IdempotencyService struct {
+	db *gorm.DB
+}
+
+func (s *IdempotencyService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+// PurgeExpired deletes the expired results and returns how many there were.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", time.Now().UTC()).Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		if ctx.Err() != nil {
+			return 0, contextError(ctx, result.Error)
+		}
+		log.Printf("unexpected error while purging idempotency keys: %v", result.Error)
+		return 0, constants.ErrUnexpectedError
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (s *IdempotencyService) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.PurgeExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
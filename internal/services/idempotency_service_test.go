@@ -0,0 +1,64 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/voucher"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PurgeExpired_DeletesOnlyExpiredIdempotencyKeys(t *testing.T) {
+	now := time.Now().UTC()
+	expired := models.IdempotencyKey{TenantID: auth.DefaultTenantID, Scope: "dl", Key: generateRandomString(32), RequestHash: generateRandomString(64), Response: []byte("{}"), ExpiresAt: now.Add(-time.Minute)}
+	live := models.IdempotencyKey{TenantID: auth.DefaultTenantID, Scope: "dl", Key: generateRandomString(32), RequestHash: generateRandomString(64), Response: []byte("{}"), ExpiresAt: now.Add(time.Hour)}
+	require.Nil(t, idempotencyService.db.Create(&expired).Error)
+	require.Nil(t, idempotencyService.db.Create(&live).Error)
+
+	purged, err := idempotencyService.PurgeExpired(context.Background())
+
+	require.Nil(t, err)
+	assert.GreaterOrEqual(t, purged, int64(1))
+	var remaining []string
+	require.Nil(t, idempotencyService.db.Model(&models.IdempotencyKey{}).Where("key IN ?", []string{expired.Key, live.Key}).Pluck("key", &remaining).Error)
+	assert.Equal(t, []string{live.Key}, remaining)
+}
+
+func Test_CreateVoucher_CreatesOnce_WithConcurrentRequestsSharingIdempotencyKey(t *testing.T) {
+	sequence, err := createRandomNumberSequence(true)
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	key := generateRandomString(32)
+
+	const count = 4
+	created := make([]*dtos.VoucherWithItemsDto, count)
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			created[i], errs[i] = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+				SequenceCode:   sequence.Code,
+				VoucherItems:   items,
+				IdempotencyKey: key,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < count; i++ {
+		require.Nil(t, errs[i])
+		assert.Equal(t, created[0].ID, created[i].ID)
+	}
+	next, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: sequence.Code, VoucherItems: items})
+	require.Nil(t, err)
+	assert.Equal(t, sequence.Prefix+"00002", next.Number)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type NormalizationService struct {
+	db *gorm.DB
+}
+
+func (s *NormalizationService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+// FindTextCollisions lists rows stored before codes, titles and numbers were
+// normalized that become equal once normalized. It looks into the books of
+// every tenant, so only admins may run it.
+func (s *NormalizationService) FindTextCollisions(actor auth.Principal) ([]dtos.TextCollisionDto, error) {
+	if err := auth.Authorize(actor, auth.EntityTenant, auth.ActionRead); err != nil {
+		return nil, err
+	}
+
+	collisions, err := s.applyTextCollisionSearch()
+	if err != nil {
+		log.Printf("unexpected error while finding text collisions: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return collisions, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/normalize"
+)
+
+type normalizedColumn struct {
+	table  string
+	column string
+}
+
+func (s *NormalizationService) normalizedColumns() []normalizedColumn {
+	return []normalizedColumn{
+		{table: "dl", column: "code"},
+		{table: "dl", column: "title"},
+		{table: "sl", column: "code"},
+		{table: "sl", column: "title"},
+		{table: "voucher", column: "number"},
+		{table: "voucher_template", column: "code"},
+	}
+}
+
+func (s *NormalizationService) applyTextCollisionSearch() ([]dtos.TextCollisionDto, error) {
+	collisions := []dtos.TextCollisionDto{}
+	for _, target := range s.normalizedColumns() {
+		columnCollisions, err := s.findColumnCollisions(target)
+		if err != nil {
+			return nil, err
+		}
+		collisions = append(collisions, columnCollisions...)
+	}
+	return collisions, nil
+}
+
+func (s *NormalizationService) findColumnCollisions(target normalizedColumn) ([]dtos.TextCollisionDto, error) {
+	var storedValues []struct {
+		ID       int
+		TenantID int
+		Value    string
+	}
+	if err := s.db.Table(target.table).Select("id, tenant_id, " + target.column + " AS value").Order("id").Scan(&storedValues).Error; err != nil {
+		return nil, err
+	}
+
+	// Values only have to be unique within a tenant.
+	type groupKey struct {
+		tenantID        int
+		normalizedValue string
+	}
+	groups := map[groupKey]*dtos.TextCollisionDto{}
+	keys := []groupKey{}
+	for _, stored := range storedValues {
+		key := groupKey{tenantID: stored.TenantID, normalizedValue: normalize.Text(stored.Value)}
+		group, ok := groups[key]
+		if !ok {
+			group = &dtos.TextCollisionDto{Table: target.table, Column: target.column, TenantID: key.tenantID, NormalizedValue: key.normalizedValue}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.IDs = append(group.IDs, stored.ID)
+		group.Values = append(group.Values, stored.Value)
+	}
+
+	collisions := []dtos.TextCollisionDto{}
+	for _, key := range keys {
+		if group := groups[key]; len(group.IDs) > 1 {
+			collisions = append(collisions, *group)
+		}
+	}
+	return collisions, nil
+}
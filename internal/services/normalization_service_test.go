@@ -0,0 +1,39 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindTextCollisions_ReportsRows_WithTitlesStoredBeforeNormalization(t *testing.T) {
+	suffix := generateRandomString(20)
+	arabicDL := models.DL{TenantID: auth.DefaultTenantID, Code: "DL" + generateRandomString(20), Title: "كد " + suffix}
+	persianDL := models.DL{TenantID: auth.DefaultTenantID, Code: "DL" + generateRandomString(20), Title: "کد " + suffix}
+	require.Nil(t, normalizationService.db.Create(&arabicDL).Error)
+	require.Nil(t, normalizationService.db.Create(&persianDL).Error)
+
+	collisions, err := normalizationService.FindTextCollisions(testAdmin)
+
+	require.Nil(t, err)
+	found := false
+	for _, collision := range collisions {
+		if collision.Table == "dl" && collision.TenantID == auth.DefaultTenantID && collision.Column == "title" && collision.NormalizedValue == "کد "+suffix {
+			found = true
+			assert.Equal(t, []int{arabicDL.ID, persianDL.ID}, collision.IDs)
+		}
+	}
+	assert.True(t, found)
+}
+
+func Test_FindTextCollisions_ReturnsErrForbidden_WithViewer(t *testing.T) {
+	collisions, err := normalizationService.FindTextCollisions(auth.Principal{Username: "viewer", Role: auth.Viewer, TenantID: auth.DefaultTenantID})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, collisions)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/requests/numbersequence"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type NumberSequenceService struct {
+	db       *gorm.DB
+	tenantID int
+}
+
+func (s *NumberSequenceService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+func (s *NumberSequenceService) forTenant(tenantID int) *NumberSequenceService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+func (s *NumberSequenceService) CreateNumberSequence(actor auth.Principal, req *numbersequence.InsertRequest) (*dtos.NumberSequenceDto, error) {
+	if err := auth.Authorize(actor, auth.EntityNumberSequence, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	if err := s.validateNumberSequenceInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	sequenceDto, err := s.applyNumberSequenceCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating number sequence: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return sequenceDto, nil
+}
+
+func (s *NumberSequenceService) UpdateNumberSequence(actor auth.Principal, req *numbersequence.UpdateRequest) (*dtos.NumberSequenceDto, error) {
+	if err := auth.Authorize(actor, auth.EntityNumberSequence, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSequence, err := s.validateNumberSequenceUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sequenceDto, err := s.applyNumberSequenceUpdate(req, targetSequence)
+	if err != nil {
+		log.Printf("unexpected error while updating number sequence: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return sequenceDto, nil
+}
+
+func (s *NumberSequenceService) DeleteNumberSequence(actor auth.Principal, req *numbersequence.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityNumberSequence, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSequence, err := s.validateNumberSequenceDeleteRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyNumberSequenceDeletion(targetSequence); err != nil {
+		log.Printf("unexpected error while deleting number sequence: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+func (s *NumberSequenceService) GetNumberSequence(actor auth.Principal, req *numbersequence.GetRequest) (*dtos.NumberSequenceDto, error) {
+	if err := auth.Authorize(actor, auth.EntityNumberSequence, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSequence, err := s.validateNumberSequenceExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToNumberSequenceDto(targetSequence), nil
+}
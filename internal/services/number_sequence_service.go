@@ -0,0 +1,151 @@
+package services
+
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/numbersequence"
+	"accountingsystem/internal/textrule"
+	"errors"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+func (s *NumberSequenceService) applyNumberSequenceCreation(req *numbersequence.InsertRequest) (*dtos.NumberSequenceDto, error) {
+	sequence := models.NumberSequence{
+		TenantID:             s.tenantID,
+		Code:                 req.Code,
+		Prefix:               req.Prefix,
+		Padding:              req.Padding,
+		ResetYearly:          req.ResetYearly,
+		FiscalYearStartMonth: s.fiscalYearStartMonthOrDefault(req.FiscalYearStartMonth),
+		Calendar:             calendar.OrDefault(req.Calendar),
+		Gapless:              req.Gapless,
+		RowVersion:           0,
+	}
+
+	if err := s.db.Create(&sequence).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToNumberSequenceDto(&sequence), nil
+}
+
+func (s *NumberSequenceService) fiscalYearStartMonthOrDefault(month int) int {
+	if month == 0 {
+		return 1
+	}
+	return month
+}
+
+func (s *NumberSequenceService) validateNumberSequenceInsertRequest(req *numbersequence.InsertRequest) error {
+	if err := s.validateNumberSequenceFields(req.Code, req.Prefix, req.Padding, req.FiscalYearStartMonth, req.Calendar); err != nil {
+		return err
+	}
+	if err := s.validateCodeUnique(req.Code, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *NumberSequenceService) validateNumberSequenceFields(code string, prefix string, padding int, fiscalYearStartMonth int, sequenceCalendar calendar.Calendar) error {
+	if err := textrule.NumberSequenceCode.Validate(code); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(prefix) > 32 {
+		return constants.ErrPrefixTooLong
+	}
+	if padding < 0 || padding > 20 {
+		return constants.ErrPaddingOutOfRange
+	}
+	if fiscalYearStartMonth < 0 || fiscalYearStartMonth > 12 {
+		return constants.ErrFiscalStartMonthOutOfRange
+	}
+	if sequenceCalendar != "" && !sequenceCalendar.IsValid() {
+		return constants.ErrInvalidCalendar
+	}
+	return nil
+}
+
+func (s *NumberSequenceService) validateCodeUnique(code string, id int) error {
+	var existingSequence models.NumberSequence
+	if err := s.db.Where("tenant_id = ? AND code = ? AND id != ?", s.tenantID, code, id).First(&existingSequence).Error; err == nil {
+		return constants.ErrCodeAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *NumberSequenceService) validateNumberSequenceExists(id int) (*models.NumberSequence, error) {
+	var targetSequence models.NumberSequence
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetSequence).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrNumberSequenceNotFound
+		}
+		return nil, err
+	}
+	return &targetSequence, nil
+}
+
+func (s *NumberSequenceService) validateVersion(reqVersion int, targetVersion int) error {
+	if reqVersion != targetVersion {
+		return constants.ErrVersionOutdated
+	}
+	return nil
+}
+
+func (s *NumberSequenceService) applyNumberSequenceUpdate(req *numbersequence.UpdateRequest, targetSequence *models.NumberSequence) (*dtos.NumberSequenceDto, error) {
+	targetSequence.Code = req.Code
+	targetSequence.Prefix = req.Prefix
+	targetSequence.Padding = req.Padding
+	targetSequence.ResetYearly = req.ResetYearly
+	targetSequence.FiscalYearStartMonth = s.fiscalYearStartMonthOrDefault(req.FiscalYearStartMonth)
+	targetSequence.Calendar = calendar.OrDefault(req.Calendar)
+	targetSequence.Gapless = req.Gapless
+	targetSequence.RowVersion++
+
+	if err := s.db.Save(targetSequence).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToNumberSequenceDto(targetSequence), nil
+}
+
+func (s *NumberSequenceService) validateNumberSequenceUpdateRequest(req *numbersequence.UpdateRequest) (*models.NumberSequence, error) {
+	if err := s.validateNumberSequenceFields(req.Code, req.Prefix, req.Padding, req.FiscalYearStartMonth, req.Calendar); err != nil {
+		return nil, err
+	}
+	targetSequence, err := s.validateNumberSequenceExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetSequence.RowVersion); err != nil {
+		return nil, err
+	}
+	if err := s.validateCodeUnique(req.Code, req.ID); err != nil {
+		return nil, err
+	}
+	return targetSequence, nil
+}
+
+func (s *NumberSequenceService) applyNumberSequenceDeletion(targetSequence *models.NumberSequence) error {
+	if err := s.db.Delete(targetSequence).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *NumberSequenceService) validateNumberSequenceDeleteRequest(req *numbersequence.DeleteRequest) (*models.NumberSequence, error) {
+	targetSequence, err := s.validateNumberSequenceExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetSequence.RowVersion); err != nil {
+		return nil, err
+	}
+	return targetSequence, nil
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/numbersequence"
+	"accountingsystem/internal/requests/voucher"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomNumberSequence(gapless bool) (*dtos.NumberSequenceDto, error) {
+	req := &numbersequence.InsertRequest{
+		Code:    "SEQ" + generateRandomString(20),
+		Prefix:  generateRandomString(10) + "-",
+		Padding: 5,
+		Gapless: gapless,
+	}
+	return numberSequenceService.CreateNumberSequence(testAdmin, req)
+}
+
+func Test_CreateNumberSequence_Succeeds_WithValidRequest(t *testing.T) {
+	req := &numbersequence.InsertRequest{
+		Code:        "SEQ" + generateRandomString(20),
+		Prefix:      "JV-{year}-",
+		Padding:     6,
+		ResetYearly: true,
+	}
+
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, req.Code, sequence.Code)
+	assert.Equal(t, req.Prefix, sequence.Prefix)
+	assert.Equal(t, 1, sequence.FiscalYearStartMonth)
+}
+
+func Test_CreateNumberSequence_ReturnsErrPaddingOutOfRange_WithTooLargePadding(t *testing.T) {
+	req := &numbersequence.InsertRequest{
+		Code:    "SEQ" + generateRandomString(20),
+		Padding: 21,
+	}
+
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrPaddingOutOfRange)
+	assert.Nil(t, sequence)
+}
+
+func Test_CreateNumberSequence_ReturnsErrFiscalStartMonthOutOfRange_WithInvalidMonth(t *testing.T) {
+	req := &numbersequence.InsertRequest{
+		Code:                 "SEQ" + generateRandomString(20),
+		FiscalYearStartMonth: 13,
+	}
+
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrFiscalStartMonthOutOfRange)
+	assert.Nil(t, sequence)
+}
+
+func Test_CreateNumberSequence_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
+	createdSequence, err := createRandomNumberSequence(false)
+	require.Nil(t, err)
+
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, &numbersequence.InsertRequest{Code: createdSequence.Code})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
+	assert.Nil(t, sequence)
+}
+
+func Test_UpdateNumberSequence_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
+	createdSequence, err := createRandomNumberSequence(false)
+	require.Nil(t, err)
+
+	updateReq := &numbersequence.UpdateRequest{
+		ID:      createdSequence.ID,
+		Code:    createdSequence.Code,
+		Prefix:  "NEW-",
+		Version: createdSequence.RowVersion,
+	}
+	_, err = numberSequenceService.UpdateNumberSequence(testAdmin, updateReq)
+	require.Nil(t, err)
+
+	updatedSequence, err := numberSequenceService.UpdateNumberSequence(testAdmin, updateReq)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
+	assert.Nil(t, updatedSequence)
+}
+
+func Test_DeleteNumberSequence_Succeeds_WithValidRequest(t *testing.T) {
+	createdSequence, err := createRandomNumberSequence(false)
+	require.Nil(t, err)
+
+	err = numberSequenceService.DeleteNumberSequence(testAdmin, &numbersequence.DeleteRequest{ID: createdSequence.ID, Version: createdSequence.RowVersion})
+
+	require.Nil(t, err)
+	_, err = numberSequenceService.GetNumberSequence(testAdmin, &numbersequence.GetRequest{ID: createdSequence.ID})
+	assert.ErrorIs(t, err, constants.ErrNumberSequenceNotFound)
+}
+
+func Test_CreateNumberSequence_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	accountant := auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}
+
+	sequence, err := numberSequenceService.CreateNumberSequence(accountant, &numbersequence.InsertRequest{Code: "SEQ" + generateRandomString(20), Padding: 5})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, sequence)
+}
+
+func Test_CreateVoucher_AllocatesConsecutiveNumbers_WithSequenceCode(t *testing.T) {
+	sequence, err := createRandomNumberSequence(false)
+	require.Nil(t, err)
+
+	firstItems, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	firstVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: sequence.Code, VoucherItems: firstItems})
+	require.Nil(t, err)
+
+	secondItems, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	secondVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: sequence.Code, VoucherItems: secondItems})
+	require.Nil(t, err)
+
+	assert.Equal(t, sequence.Prefix+"00001", firstVoucher.Number)
+	assert.Equal(t, sequence.Prefix+"00002", secondVoucher.Number)
+}
+
+func Test_CreateVoucher_ReplacesYearPlaceholder_WithYearlySequence(t *testing.T) {
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, &numbersequence.InsertRequest{
+		Code:        "SEQ" + generateRandomString(20),
+		Prefix:      generateRandomString(5) + "/{year}/",
+		ResetYearly: true,
+	})
+	require.Nil(t, err)
+
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: sequence.Code, VoucherItems: items})
+
+	require.Nil(t, err)
+	expectedPrefix := strings.ReplaceAll(sequence.Prefix, "{year}", strconv.Itoa(time.Now().Year()))
+	assert.Equal(t, expectedPrefix+"1", createdVoucher.Number)
+}
+
+func Test_CreateVoucher_AllocatesDistinctNumbers_WithConcurrentRequestsOnGaplessSequence(t *testing.T) {
+	sequence, err := createRandomNumberSequence(true)
+	require.Nil(t, err)
+
+	const count = 8
+	numbers := make([]string, count)
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		items, err := createRandomBalancedItems(100)
+		require.Nil(t, err)
+		wg.Add(1)
+		go func(i int, items []voucher.VoucherItemInsertDetail) {
+			defer wg.Done()
+			createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: sequence.Code, VoucherItems: items})
+			errs[i] = err
+			if err == nil {
+				numbers[i] = createdVoucher.Number
+			}
+		}(i, items)
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for i := 0; i < count; i++ {
+		require.Nil(t, errs[i])
+		seen[numbers[i]] = true
+	}
+	assert.Len(t, seen, count)
+	for i := 1; i <= count; i++ {
+		assert.True(t, seen[sequence.Prefix+"0000"+strconv.Itoa(i)])
+	}
+}
+
+func Test_CreateVoucher_SkipsNumbersTakenByHand_WithSequenceCode(t *testing.T) {
+	sequence, err := createRandomNumberSequence(false)
+	require.Nil(t, err)
+	manualItems, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: sequence.Prefix + "00001", VoucherItems: manualItems})
+	require.Nil(t, err)
+
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: sequence.Code, VoucherItems: items})
+
+	require.Nil(t, err)
+	assert.Equal(t, sequence.Prefix+"00002", createdVoucher.Number)
+}
+
+func Test_CreateVoucher_ReturnsErrVoucherNumberReserved_WithNumberInGaplessSequenceFormat(t *testing.T) {
+	sequence, err := createRandomNumberSequence(true)
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: sequence.Prefix + "00001", VoucherItems: items})
+
+	assert.ErrorIs(t, err, constants.ErrVoucherNumberReserved)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrNumberSequenceNotFound_WithUnknownSequenceCode(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{SequenceCode: "SEQ" + generateRandomString(30), VoucherItems: items})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrNumberSequenceNotFound)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_CountsFiscalYearInJalali_WithJalaliSequence(t *testing.T) {
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, &numbersequence.InsertRequest{
+		Code:        "SEQ" + generateRandomString(20),
+		Prefix:      generateRandomString(5) + "/{year}/",
+		ResetYearly: true,
+		Calendar:    calendar.Jalali,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, calendar.Jalali, sequence.Calendar)
+
+	lastDayOf1402Items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	lastDayOf1402Voucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		SequenceCode: sequence.Code,
+		Date:         time.Date(2024, 3, 19, 0, 0, 0, 0, time.UTC),
+		VoucherItems: lastDayOf1402Items,
+	})
+	require.Nil(t, err)
+
+	firstDayOf1403Items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	firstDayOf1403Voucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		SequenceCode: sequence.Code,
+		Date:         time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		VoucherItems: firstDayOf1403Items,
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, strings.ReplaceAll(sequence.Prefix, "{year}", "1402")+"1", lastDayOf1402Voucher.Number)
+	assert.Equal(t, strings.ReplaceAll(sequence.Prefix, "{year}", "1403")+"1", firstDayOf1403Voucher.Number)
+}
+
+func Test_CreateNumberSequence_ReturnsErrInvalidCalendar_WithUnknownCalendar(t *testing.T) {
+	req := &numbersequence.InsertRequest{
+		Code:     "SEQ" + generateRandomString(20),
+		Calendar: calendar.Calendar("hebrew"),
+	}
+
+	sequence, err := numberSequenceService.CreateNumberSequence(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidCalendar)
+	assert.Nil(t, sequence)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/webhook"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// recordEvent writes a domain event to the outbox in tx, the transaction of
+// the change it describes, so the event and its webhook deliveries exist
+// exactly when the change was committed.
+func recordEvent(tx *gorm.DB, tenantID int, eventType string, aggregateType string, aggregateID int, payload any) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	event := models.OutboxEvent{
+		TenantID:      tenantID,
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       encoded,
+		NextAttemptAt: time.Now().UTC(),
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+	return enqueueWebhookDeliveries(tx, &event)
+}
+
+// enqueueWebhookDeliveries queues a delivery of the event for every active
+// webhook subscription of its tenant that subscribed to its type.
+func enqueueWebhookDeliveries(tx *gorm.DB, event *models.OutboxEvent) error {
+	return tx.Exec(`INSERT INTO webhook_delivery (tenant_id, subscription_id, event_id, status, next_attempt_at)
+		SELECT webhook_subscription.tenant_id, webhook_subscription.id, ?, ?, ?
+		FROM webhook_subscription
+		JOIN webhook_subscription_event ON webhook_subscription_event.subscription_id = webhook_subscription.id
+		WHERE webhook_subscription.tenant_id = ? AND webhook_subscription.active AND webhook_subscription_event.event_type = ?`,
+		event.ID, webhook.DeliveryPending, event.NextAttemptAt, event.TenantID, event.Type).Error
+}
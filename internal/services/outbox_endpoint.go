@@ -0,0 +1,91 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/events"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxService delivers the domain events recorded with the ledger changes
+// to the registered sinks. Delivery is at least once: an event is handed to
+// every sink again until all of them accepted it in the same attempt.
+type OutboxService struct {
+	db    *gorm.DB
+	mu    sync.RWMutex
+	sinks []namedSink
+}
+
+type namedSink struct {
+	name string
+	sink events.Sink
+}
+
+func (s *OutboxService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+// AddSink registers a sink under a name used in the delivery errors.
+func (s *OutboxService) AddSink(name string, sink events.Sink) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sinks = append(s.sinks, namedSink{name: name, sink: sink})
+}
+
+// DispatchPending delivers a batch of the events that are due and returns how
+// many of them were delivered. As a batch holds one event per aggregate, a
+// caller that wants every due event delivered repeats it while it delivers
+// some. While no sink is registered, due events are marked dispatched instead.
+func (s *OutboxService) DispatchPending(ctx context.Context) (int, error) {
+	dispatchedCount, err := s.applyPendingEventsDispatch(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() != nil {
+			return dispatchedCount, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while dispatching outbox events: %v", err)
+		return dispatchedCount, constants.ErrUnexpectedError
+	}
+
+	return dispatchedCount, nil
+}
+
+// PruneDispatched deletes the events that were dispatched more than the
+// retention ago and returns how many there were. A stream resumed from an
+// older event ID starts at the oldest event that is left.
+func (s *OutboxService) PruneDispatched(ctx context.Context, retention time.Duration) (int64, error) {
+	prunedCount, err := s.applyDispatchedEventsPrune(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while pruning outbox events: %v", err)
+		return 0, constants.ErrUnexpectedError
+	}
+
+	return prunedCount, nil
+}
+
+func (s *OutboxService) RunDispatcher(ctx context.Context, interval time.Duration, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			dispatchedCount, err := s.DispatchPending(ctx)
+			if err != nil || dispatchedCount == 0 {
+				break
+			}
+		}
+		s.PruneDispatched(ctx, retention)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
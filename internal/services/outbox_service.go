@@ -0,0 +1,179 @@
+package services
+
+import (
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const outboxBatchSize = 100
+
+const (
+	outboxRetryBaseDelay = time.Second
+	outboxRetryMaxDelay  = time.Hour
+	outboxClaimTimeout   = 30 * time.Minute
+)
+
+// applyPendingEventsDispatch delivers a batch of due events in id order. The
+// batch is claimed in a short transaction that moves next_attempt_at past the
+// claim timeout, so concurrent dispatchers skip it while the sinks are called
+// without holding any lock, and a dispatcher that dies only delays it. Without
+// sinks there is nobody to deliver to, so the due events are only marked
+// dispatched and get pruned like the others.
+func (s *OutboxService) applyPendingEventsDispatch(ctx context.Context, now time.Time) (int, error) {
+	sinks := s.registeredSinks()
+	if len(sinks) == 0 {
+		return s.markDueEventsDispatched(ctx, now)
+	}
+
+	claimedEvents, err := s.claimDueEvents(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatchedCount := 0
+	for i := range claimedEvents {
+		outboxEvent := &claimedEvents[i]
+		if ctx.Err() != nil {
+			if err := s.releaseClaim(outboxEvent, now); err != nil {
+				return dispatchedCount, err
+			}
+			continue
+		}
+
+		deliveryErr := s.deliverEvent(ctx, sinks, outboxEvent)
+		if deliveryErr != nil && ctx.Err() != nil {
+			if err := s.releaseClaim(outboxEvent, now); err != nil {
+				return dispatchedCount, err
+			}
+			continue
+		}
+		if err := s.saveDeliveryOutcome(outboxEvent, deliveryErr, now); err != nil {
+			return dispatchedCount, err
+		}
+		if deliveryErr == nil {
+			dispatchedCount++
+		}
+	}
+	if ctx.Err() != nil {
+		return dispatchedCount, ctx.Err()
+	}
+	return dispatchedCount, nil
+}
+
+// claimDueEvents locks the due events that are the earliest undispatched
+// event of their aggregate, and moves them out of reach of the other
+// dispatchers for the claim timeout. An earlier event blocks the later ones
+// whether it waits for a retry or is being delivered by another dispatcher,
+// so each aggregate's events are delivered in order and one at a time.
+func (s *OutboxService) claimDueEvents(ctx context.Context, now time.Time) ([]models.OutboxEvent, error) {
+	var claimedEvents []models.OutboxEvent
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched_at IS NULL AND next_attempt_at <= ?", now).
+			Where(`NOT EXISTS (SELECT 1 FROM outbox_event earlier
+				WHERE earlier.tenant_id = outbox_event.tenant_id
+				AND earlier.aggregate_type = outbox_event.aggregate_type
+				AND earlier.aggregate_id = outbox_event.aggregate_id
+				AND earlier.id < outbox_event.id
+				AND earlier.dispatched_at IS NULL)`).
+			Order("id").
+			Limit(outboxBatchSize).
+			Find(&claimedEvents).Error; err != nil {
+			return err
+		}
+		if len(claimedEvents) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(claimedEvents))
+		for i := range claimedEvents {
+			ids[i] = claimedEvents[i].ID
+		}
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(outboxClaimTimeout)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimedEvents, nil
+}
+
+// markDueEventsDispatched marks the due events dispatched without delivering
+// them.
+func (s *OutboxService) markDueEventsDispatched(ctx context.Context, now time.Time) (int, error) {
+	result := s.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("dispatched_at IS NULL AND next_attempt_at <= ?", now).
+		Update("dispatched_at", now)
+	return int(result.RowsAffected), result.Error
+}
+
+// releaseClaim makes an event that was claimed but not attempted due again.
+func (s *OutboxService) releaseClaim(outboxEvent *models.OutboxEvent, now time.Time) error {
+	return s.db.Model(outboxEvent).Update("next_attempt_at", now).Error
+}
+
+// applyDispatchedEventsPrune deletes the events dispatched before the cutoff
+// together with their webhook delivery logs. Events that still have a pending
+// webhook delivery are kept.
+func (s *OutboxService) applyDispatchedEventsPrune(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("dispatched_at IS NOT NULL AND dispatched_at < ?", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM webhook_delivery WHERE webhook_delivery.event_id = outbox_event.id AND webhook_delivery.status = 'pending')").
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+func (s *OutboxService) registeredSinks() []namedSink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]namedSink(nil), s.sinks...)
+}
+
+func (s *OutboxService) deliverEvent(ctx context.Context, sinks []namedSink, outboxEvent *models.OutboxEvent) error {
+	event := mappers.ToEvent(outboxEvent)
+	var deliveryErrs []error
+	for _, sink := range sinks {
+		if err := sink.sink(ctx, event); err != nil {
+			deliveryErrs = append(deliveryErrs, fmt.Errorf("%s: %w", sink.name, err))
+		}
+	}
+	return errors.Join(deliveryErrs...)
+}
+
+// saveDeliveryOutcome is not bound to the dispatch context, so the outcome of
+// a delivery is kept when the dispatcher is stopped right after it.
+func (s *OutboxService) saveDeliveryOutcome(outboxEvent *models.OutboxEvent, deliveryErr error, now time.Time) error {
+	outboxEvent.Attempts++
+	if deliveryErr == nil {
+		outboxEvent.DispatchedAt.Time = now
+		outboxEvent.DispatchedAt.Valid = true
+		outboxEvent.LastError = ""
+	} else {
+		outboxEvent.NextAttemptAt = now.Add(s.retryDelay(outboxEvent.Attempts))
+		outboxEvent.LastError = deliveryErr.Error()
+	}
+
+	return s.db.Model(outboxEvent).Updates(map[string]interface{}{
+		"attempts":        outboxEvent.Attempts,
+		"next_attempt_at": outboxEvent.NextAttemptAt,
+		"last_error":      outboxEvent.LastError,
+		"dispatched_at":   outboxEvent.DispatchedAt,
+	}).Error
+}
+
+// retryDelay doubles the wait after each failed attempt up to an hour.
+func (s *OutboxService) retryDelay(attempts int) time.Duration {
+	delay := outboxRetryBaseDelay
+	for i := 1; i < attempts && delay < outboxRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxRetryMaxDelay)
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/voucher"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+)
+
+func findOutboxEvents(t *testing.T, aggregateType string, aggregateID int) []models.OutboxEvent {
+	var outboxEvents []models.OutboxEvent
+	require.Nil(t, outboxService.db.Where("aggregate_type = ? AND aggregate_id = ?", aggregateType, aggregateID).Order("id").Find(&outboxEvents).Error)
+	return outboxEvents
+}
+
+// dispatchAll runs the dispatcher until no due event is left.
+func dispatchAll(t *testing.T, outbox *OutboxService) {
+	for {
+		dispatchedCount, err := outbox.DispatchPending(context.Background())
+		require.Nil(t, err)
+		if dispatchedCount == 0 {
+			return
+		}
+	}
+}
+
+func Test_CreateDL_RecordsDLCreatedEvent(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	outboxEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+
+	require.Len(t, outboxEvents, 1)
+	assert.Equal(t, events.DLCreated, outboxEvents[0].Type)
+	var payload dtos.DLDto
+	require.Nil(t, json.Unmarshal(outboxEvents[0].Payload, &payload))
+	assert.Equal(t, *dlDto, payload)
+}
+
+func Test_DeleteDL_RecordsDLDeletedEvent(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	err = dlService.DeleteDL(testAdmin, &dl.DeleteRequest{ID: dlDto.ID, Version: dlDto.RowVersion})
+	require.Nil(t, err)
+
+	outboxEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, outboxEvents, 2)
+	assert.Equal(t, events.DLDeleted, outboxEvents[1].Type)
+}
+
+func Test_CreateDL_RecordsNoEvent_WhenCreationFails(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	_, err = dlService.CreateDL(testAdmin, &dl.InsertRequest{Code: dlDto.Code, Title: "Test" + generateRandomString(20)})
+	require.NotNil(t, err)
+
+	assert.Len(t, findOutboxEvents(t, events.AggregateDL, dlDto.ID), 1)
+}
+
+func Test_UpdateVoucher_RecordsItemDeltas(t *testing.T) {
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	SLWithoutDL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	req := &voucher.UpdateRequest{
+		ID:      voucherDto.ID,
+		Version: voucherDto.RowVersion,
+		Number:  generateRandomString(20),
+		Items: voucher.VoucherItemsUpdate{
+			Inserted: []voucher.VoucherItemInsertDetail{
+				{SLID: SLWithoutDL.ID, Debit: 0, Credit: voucherDto.VoucherItems[1].Credit},
+			},
+			Deleted: []int{voucherDto.VoucherItems[1].ID},
+		},
+	}
+	_, err = voucherService.UpdateVoucher(testAdmin, req)
+	require.Nil(t, err)
+
+	outboxEvents := findOutboxEvents(t, events.AggregateVoucher, voucherDto.ID)
+	require.Len(t, outboxEvents, 2)
+	assert.Equal(t, events.VoucherCreated, outboxEvents[0].Type)
+	assert.Equal(t, events.VoucherUpdated, outboxEvents[1].Type)
+	var payload events.VoucherUpdatedPayload
+	require.Nil(t, json.Unmarshal(outboxEvents[1].Payload, &payload))
+	assert.Equal(t, req.Number, payload.Voucher.Number)
+	require.Len(t, payload.InsertedItems, 1)
+	assert.Equal(t, SLWithoutDL.ID, payload.InsertedItems[0].SLID)
+	require.Len(t, payload.DeletedItems, 1)
+	assert.Equal(t, voucherDto.VoucherItems[1].ID, payload.DeletedItems[0].ID)
+	assert.Empty(t, payload.UpdatedItems)
+}
+
+func Test_DispatchPending_DeliversEventAndMarksItDispatched(t *testing.T) {
+	outbox := &OutboxService{}
+	outbox.InitService(outboxService.db)
+	subscribers := &events.Subscribers{}
+	var delivered []events.Event
+	subscribers.Subscribe(func(ctx context.Context, event events.Event) error {
+		delivered = append(delivered, event)
+		return nil
+	}, events.DLCreated)
+	outbox.AddSink("test", subscribers.Sink())
+
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	dispatchAll(t, outbox)
+
+	outboxEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, outboxEvents, 1)
+	assert.True(t, outboxEvents[0].DispatchedAt.Valid)
+	assert.Equal(t, 1, outboxEvents[0].Attempts)
+	found := false
+	for _, event := range delivered {
+		if event.ID == outboxEvents[0].ID {
+			found = true
+			assert.Equal(t, dlDto.ID, event.AggregateID)
+		}
+	}
+	assert.True(t, found)
+}
+
+func Test_DispatchPending_KeepsEventPending_WhenSinkFails(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	outbox := &OutboxService{}
+	outbox.InitService(outboxService.db)
+	outbox.AddSink("test", func(ctx context.Context, event events.Event) error {
+		if event.AggregateType == events.AggregateDL && event.AggregateID == dlDto.ID {
+			return errors.New("sink is down")
+		}
+		return nil
+	})
+	dispatchAll(t, outbox)
+
+	outboxEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, outboxEvents, 1)
+	assert.False(t, outboxEvents[0].DispatchedAt.Valid)
+	assert.Equal(t, 1, outboxEvents[0].Attempts)
+	assert.Contains(t, outboxEvents[0].LastError, "sink is down")
+}
+
+func Test_DispatchPending_HoldsBackLaterEventsOfAggregate_WhenEarlierEventFails(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	_, err = dlService.UpdateDL(testAdmin, &dl.UpdateRequest{ID: dlDto.ID, Code: dlDto.Code, Title: "Test" + generateRandomString(20), Version: dlDto.RowVersion})
+	require.Nil(t, err)
+	recordedEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, recordedEvents, 2)
+
+	outbox := &OutboxService{}
+	outbox.InitService(outboxService.db)
+	outbox.AddSink("test", func(ctx context.Context, event events.Event) error {
+		if event.ID == recordedEvents[0].ID {
+			return errors.New("sink is down")
+		}
+		return nil
+	})
+	dispatchAll(t, outbox)
+
+	outboxEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, outboxEvents, 2)
+	assert.False(t, outboxEvents[0].DispatchedAt.Valid)
+	assert.Equal(t, 1, outboxEvents[0].Attempts)
+	assert.False(t, outboxEvents[1].DispatchedAt.Valid)
+	assert.Equal(t, 0, outboxEvents[1].Attempts)
+}
+
+// recordAggregateEvents returns a sink that records the IDs of the events of
+// one aggregate it is handed, and accepts every event.
+func recordAggregateEvents(aggregateType string, aggregateID int, delivered *[]int64, mu *sync.Mutex) events.Sink {
+	return func(ctx context.Context, event events.Event) error {
+		if event.AggregateType == aggregateType && event.AggregateID == aggregateID {
+			mu.Lock()
+			defer mu.Unlock()
+			*delivered = append(*delivered, event.ID)
+		}
+		return nil
+	}
+}
+
+func Test_DispatchPending_DeliversAggregateInOrder_WithTwoDispatchers(t *testing.T) {
+	// Without sinks the events left by other tests are marked dispatched, so
+	// the events of this test are in the first batch of the slow dispatcher.
+	withoutSinks := &OutboxService{}
+	withoutSinks.InitService(outboxService.db)
+	dispatchAll(t, withoutSinks)
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	_, err = dlService.UpdateDL(testAdmin, &dl.UpdateRequest{ID: dlDto.ID, Code: dlDto.Code, Title: "Test" + generateRandomString(20), Version: dlDto.RowVersion})
+	require.Nil(t, err)
+	recordedEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, recordedEvents, 2)
+
+	var mu sync.Mutex
+	var delivered []int64
+	record := recordAggregateEvents(events.AggregateDL, dlDto.ID, &delivered, &mu)
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	slowDispatcher := &OutboxService{}
+	slowDispatcher.InitService(outboxService.db)
+	slowDispatcher.AddSink("slow", func(ctx context.Context, event events.Event) error {
+		if event.ID == recordedEvents[0].ID {
+			close(entered)
+			<-release
+		}
+		return record(ctx, event)
+	})
+	fastDispatcher := &OutboxService{}
+	fastDispatcher.InitService(outboxService.db)
+	fastDispatcher.AddSink("fast", record)
+
+	slowDone := make(chan error)
+	go func() {
+		_, err := slowDispatcher.DispatchPending(context.Background())
+		slowDone <- err
+	}()
+	<-entered
+	dispatchAll(t, fastDispatcher)
+	mu.Lock()
+	assert.Empty(t, delivered)
+	mu.Unlock()
+	close(release)
+	require.Nil(t, <-slowDone)
+	dispatchAll(t, fastDispatcher)
+
+	assert.Equal(t, []int64{recordedEvents[0].ID, recordedEvents[1].ID}, delivered)
+}
+
+func Test_DispatchPending_HoldsBackLaterEventsOfAggregate_WhileEarlierEventIsBeingClaimed(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	_, err = dlService.UpdateDL(testAdmin, &dl.UpdateRequest{ID: dlDto.ID, Code: dlDto.Code, Title: "Test" + generateRandomString(20), Version: dlDto.RowVersion})
+	require.Nil(t, err)
+	recordedEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, recordedEvents, 2)
+	var mu sync.Mutex
+	var delivered []int64
+	outbox := &OutboxService{}
+	outbox.InitService(outboxService.db)
+	outbox.AddSink("test", recordAggregateEvents(events.AggregateDL, dlDto.ID, &delivered, &mu))
+
+	// The open transaction holds the lock another dispatcher takes on the
+	// earlier event while claiming it, before it moves next_attempt_at.
+	claim := outboxService.db.Begin()
+	var claimed models.OutboxEvent
+	require.Nil(t, claim.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", recordedEvents[0].ID).First(&claimed).Error)
+	dispatchAll(t, outbox)
+	assert.Empty(t, delivered)
+	require.Nil(t, claim.Rollback().Error)
+	dispatchAll(t, outbox)
+
+	assert.Equal(t, []int64{recordedEvents[0].ID, recordedEvents[1].ID}, delivered)
+}
+
+func Test_DispatchPending_MarksEventsDispatched_WithoutSinks(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	outbox := &OutboxService{}
+	outbox.InitService(outboxService.db)
+
+	dispatchedCount, err := outbox.DispatchPending(context.Background())
+
+	require.Nil(t, err)
+	assert.GreaterOrEqual(t, dispatchedCount, 1)
+	outboxEvents := findOutboxEvents(t, events.AggregateDL, dlDto.ID)
+	require.Len(t, outboxEvents, 1)
+	assert.True(t, outboxEvents[0].DispatchedAt.Valid)
+	assert.Equal(t, 0, outboxEvents[0].Attempts)
+}
+
+func Test_PruneDispatched_DeletesEventsDispatchedBeforeRetention(t *testing.T) {
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	pendingDL, err := createRandomDL()
+	require.Nil(t, err)
+	longAgo := time.Now().UTC().Add(-48 * time.Hour)
+	require.Nil(t, outboxService.db.Model(&models.OutboxEvent{}).
+		Where("aggregate_type = ? AND aggregate_id = ?", events.AggregateDL, dlDto.ID).
+		Update("dispatched_at", longAgo).Error)
+
+	prunedCount, err := outboxService.PruneDispatched(context.Background(), 24*time.Hour)
+
+	require.Nil(t, err)
+	assert.GreaterOrEqual(t, prunedCount, int64(1))
+	assert.Empty(t, findOutboxEvents(t, events.AggregateDL, dlDto.ID))
+	assert.Len(t, findOutboxEvents(t, events.AggregateDL, pendingDL.ID), 1)
+}
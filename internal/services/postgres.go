@@ -0,0 +1,31 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATEs of the Postgres errors the services react to.
+const (
+	uniqueViolation        = "23505"
+	numericValueOutOfRange = "22003"
+)
+
+// isUniqueViolation reports whether err was raised by the unique constraint
+// or index with the given name, which a concurrent request won the race for.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == constraint
+}
+
+// amountSQLError returns ErrAmountOverflow when err reports a sum of amounts
+// that does not fit the BIGINT it is cast to, and err unchanged otherwise.
+func amountSQLError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == numericValueOutOfRange {
+		return constants.ErrAmountOverflow
+	}
+	return err
+}
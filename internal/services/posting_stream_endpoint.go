@@ -0,0 +1,68 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/stream"
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostingStreamService follows the outbox for dashboards that want to see
+// vouchers and SL balances change as they happen.
+type PostingStreamService struct {
+	db           *gorm.DB
+	tenantID     int
+	pollInterval time.Duration
+}
+
+func (s *PostingStreamService) InitService(db *gorm.DB) {
+	s.db = db
+	s.pollInterval = time.Second
+}
+
+func (s *PostingStreamService) forTenant(tenantID int) *PostingStreamService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+// StreamPostings hands the voucher events matching the request to send, each
+// followed by the balances of the SLs it touched when it may have changed
+// them, until ctx is done or send fails. Events written before a stream
+// resumed from a cursor was opened come without balances; once they are
+// sent, the current balances of the SLs they touched follow with a VoucherID
+// of 0. The error of send is returned as it is.
+func (s *PostingStreamService) StreamPostings(ctx context.Context, actor auth.Principal, req *stream.PostingsRequest, send func(message *dtos.PostingMessageDto) error) error {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	position, replay, err := s.resolveStreamPosition(ctx, req.Cursor)
+	if err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
+		log.Printf("unexpected error while opening postings stream: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	var sendErr error
+	err = s.applyPostingsStream(ctx, req, position, replay, func(message *dtos.PostingMessageDto) error {
+		sendErr = send(message)
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if ctx.Err() != nil {
+		return contextError(ctx, ctx.Err())
+	}
+	log.Printf("unexpected error while streaming postings: %v", err)
+	return constants.ErrUnexpectedError
+}
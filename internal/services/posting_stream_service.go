@@ -0,0 +1,292 @@
+package services
+
+import (
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/stream"
+	"accountingsystem/internal/requests/voucher"
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const postingStreamBatchSize = 100
+
+// streamPosition is where a stream is in the outbox. Events are read in the
+// order of the transactions that wrote them, and only from transactions older
+// than every running one, so an event with a lower id that commits late is
+// not skipped. The transaction id is kept as text, as the driver does not
+// know the xid8 type.
+type streamPosition struct {
+	XactID string
+	ID     int64
+}
+
+type streamEvent struct {
+	models.OutboxEvent
+	XactID string
+}
+
+// streamReplay tracks the events a stream resumed from a cursor sends although
+// their transactions had finished before it was opened. The balances of today
+// are not the balances at those events, so instead of a balance per event the
+// stream sends the current balance of every SL they touched once it has
+// caught up.
+type streamReplay struct {
+	liveFrom uint64
+	slIDs    []int
+}
+
+// replayed reports whether the event of the transaction had finished before
+// the stream was opened.
+func (r *streamReplay) replayed(xactID string) (bool, error) {
+	id, err := strconv.ParseUint(xactID, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return id < r.liveFrom, nil
+}
+
+func (r *streamReplay) touch(slIDs []int) {
+	for _, slID := range slIDs {
+		if !slices.Contains(r.slIDs, slID) {
+			r.slIDs = append(r.slIDs, slID)
+		}
+	}
+}
+
+// resolveStreamPosition returns the position of the event with the cursor
+// as its id, and the replay of the events after it that were written before
+// the stream was opened. Without a cursor the stream starts with the events
+// of the transactions that have not finished yet, and a cursor whose event is
+// no longer kept starts it with the oldest event left.
+func (s *PostingStreamService) resolveStreamPosition(ctx context.Context, cursor int64) (streamPosition, *streamReplay, error) {
+	var liveFrom string
+	if err := s.db.WithContext(ctx).Raw("SELECT pg_snapshot_xmin(pg_current_snapshot())::text").Scan(&liveFrom).Error; err != nil {
+		return streamPosition{}, nil, err
+	}
+	if cursor == 0 {
+		return streamPosition{XactID: liveFrom}, nil, nil
+	}
+	replay := &streamReplay{}
+	var err error
+	if replay.liveFrom, err = strconv.ParseUint(liveFrom, 10, 64); err != nil {
+		return streamPosition{}, nil, err
+	}
+
+	var xactIDs []string
+	if err := s.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("tenant_id = ? AND id = ?", s.tenantID, cursor).
+		Pluck("xact_id::text", &xactIDs).Error; err != nil {
+		return streamPosition{}, nil, err
+	}
+	if len(xactIDs) == 0 {
+		return streamPosition{XactID: "0"}, replay, nil
+	}
+	return streamPosition{XactID: xactIDs[0], ID: cursor}, replay, nil
+}
+
+// applyPostingsStream polls the outbox for voucher events after position and
+// only returns with an error, which is the one of ctx once it is done.
+func (s *PostingStreamService) applyPostingsStream(ctx context.Context, req *stream.PostingsRequest, position streamPosition, replay *streamReplay, send func(message *dtos.PostingMessageDto) error) error {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		nextPosition, more, err := s.sendPendingPostings(ctx, req, position, replay, send)
+		if err != nil {
+			return err
+		}
+		position = nextPosition
+		if more {
+			continue
+		}
+		if replay != nil {
+			if err := s.sendBalances(ctx, position.ID, 0, replay.slIDs, send); err != nil {
+				return err
+			}
+			replay = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendPendingPostings sends a batch of the events after position and returns
+// the position of the last one and whether more events may be waiting.
+func (s *PostingStreamService) sendPendingPostings(ctx context.Context, req *stream.PostingsRequest, position streamPosition, replay *streamReplay, send func(message *dtos.PostingMessageDto) error) (streamPosition, bool, error) {
+	var pendingEvents []streamEvent
+	if err := s.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Select("id, tenant_id, type, aggregate_type, aggregate_id, payload, created_at, xact_id::text AS xact_id").
+		Where("tenant_id = ? AND aggregate_type = ?", s.tenantID, events.AggregateVoucher).
+		Where("(xact_id, id) > (?::xid8, ?)", position.XactID, position.ID).
+		Where("xact_id < pg_snapshot_xmin(pg_current_snapshot())").
+		Order("xact_id, id").
+		Limit(postingStreamBatchSize).
+		Scan(&pendingEvents).Error; err != nil {
+		return position, false, err
+	}
+
+	for i := range pendingEvents {
+		eventReplay := replay
+		if replay != nil {
+			replayed, err := replay.replayed(pendingEvents[i].XactID)
+			if err != nil {
+				return position, false, err
+			}
+			if !replayed {
+				eventReplay = nil
+			}
+		}
+		if err := s.sendPosting(ctx, req, &pendingEvents[i].OutboxEvent, eventReplay, send); err != nil {
+			return position, false, err
+		}
+		position = streamPosition{XactID: pendingEvents[i].XactID, ID: pendingEvents[i].ID}
+	}
+	return position, len(pendingEvents) == postingStreamBatchSize, nil
+}
+
+// sendPosting sends an event followed by the balances of the SLs it touched.
+// For a replayed event the SLs are left to the replay instead.
+func (s *PostingStreamService) sendPosting(ctx context.Context, req *stream.PostingsRequest, outboxEvent *models.OutboxEvent, replay *streamReplay, send func(message *dtos.PostingMessageDto) error) error {
+	changesBalances := s.changesBalances(outboxEvent.Type)
+	filtered := len(req.SLIDs) > 0 || len(req.DLIDs) > 0
+
+	var slIDs []int
+	if filtered || changesBalances {
+		items, err := s.eventVoucherItems(ctx, outboxEvent)
+		if err != nil {
+			return err
+		}
+		slIDs = s.matchingSLIDs(req, items)
+		if filtered && len(slIDs) == 0 {
+			return nil
+		}
+	}
+
+	message := &dtos.PostingMessageDto{
+		Cursor:    outboxEvent.ID,
+		Type:      outboxEvent.Type,
+		VoucherID: outboxEvent.AggregateID,
+		Payload:   outboxEvent.Payload,
+	}
+	if err := send(message); err != nil {
+		return err
+	}
+	if !changesBalances {
+		return nil
+	}
+	if replay != nil {
+		replay.touch(slIDs)
+		return nil
+	}
+	return s.sendBalances(ctx, outboxEvent.ID, outboxEvent.AggregateID, slIDs, send)
+}
+
+// sendBalances sends the current balance of each SL as an SLBalanceChanged
+// message of the event with the cursor.
+func (s *PostingStreamService) sendBalances(ctx context.Context, cursor int64, voucherID int, slIDs []int, send func(message *dtos.PostingMessageDto) error) error {
+	for _, slID := range slIDs {
+		balance, err := s.loadSLBalance(ctx, slID)
+		if err != nil {
+			return err
+		}
+		balanceMessage := &dtos.PostingMessageDto{
+			Cursor:    cursor,
+			Type:      stream.MessageSLBalanceChanged,
+			VoucherID: voucherID,
+			Balance:   balance,
+		}
+		if err := send(balanceMessage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changesBalances reports whether an event may have changed the balances of
+// the SLs on its voucher. Creating a posted voucher raises VoucherPosted as
+// well, so VoucherCreated is left out.
+func (s *PostingStreamService) changesBalances(eventType string) bool {
+	switch eventType {
+	case events.VoucherUpdated, events.VoucherDeleted, events.VoucherPosted:
+		return true
+	}
+	return false
+}
+
+// eventVoucherItems returns the voucher lines an event is about. They are
+// read from the payload when it carries them, including the lines an update
+// removed or changed, and from the voucher otherwise.
+func (s *PostingStreamService) eventVoucherItems(ctx context.Context, outboxEvent *models.OutboxEvent) ([]dtos.VoucherItemDto, error) {
+	switch outboxEvent.Type {
+	case events.VoucherCreated, events.VoucherDeleted:
+		var payload dtos.VoucherWithItemsDto
+		if err := json.Unmarshal(outboxEvent.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return payload.VoucherItems, nil
+	case events.VoucherUpdated:
+		var payload events.VoucherUpdatedPayload
+		if err := json.Unmarshal(outboxEvent.Payload, &payload); err != nil {
+			return nil, err
+		}
+		items := append(payload.InsertedItems, payload.DeletedItems...)
+		for _, change := range payload.UpdatedItems {
+			items = append(items, change.Before, change.After)
+		}
+		return items, nil
+	}
+
+	var voucherItems []models.VoucherItem
+	if err := s.db.WithContext(ctx).
+		Where("tenant_id = ? AND voucher_id = ?", s.tenantID, outboxEvent.AggregateID).
+		Find(&voucherItems).Error; err != nil {
+		return nil, err
+	}
+	items := make([]dtos.VoucherItemDto, len(voucherItems))
+	for i := range voucherItems {
+		items[i] = *mappers.ToVoucherItemDto(&voucherItems[i])
+	}
+	return items, nil
+}
+
+// matchingSLIDs returns the distinct SLs of the lines that pass the SL and DL
+// filters of the request, in the order they first appear.
+func (s *PostingStreamService) matchingSLIDs(req *stream.PostingsRequest, items []dtos.VoucherItemDto) []int {
+	var slIDs []int
+	for _, item := range items {
+		if len(req.SLIDs) > 0 && !slices.Contains(req.SLIDs, item.SLID) {
+			continue
+		}
+		if len(req.DLIDs) > 0 && !slices.Contains(req.DLIDs, item.DLID) {
+			continue
+		}
+		if !slices.Contains(slIDs, item.SLID) {
+			slIDs = append(slIDs, item.SLID)
+		}
+	}
+	return slIDs
+}
+
+func (s *PostingStreamService) loadSLBalance(ctx context.Context, slID int) (*dtos.SLBalanceDto, error) {
+	balance := dtos.SLBalanceDto{SLID: slID}
+	if err := s.db.WithContext(ctx).Table("voucher_item").
+		Select("COALESCE(SUM(voucher_item.debit), 0)::BIGINT AS total_debit, COALESCE(SUM(voucher_item.credit), 0)::BIGINT AS total_credit").
+		Joins("JOIN voucher ON voucher.id = voucher_item.voucher_id").
+		Where("voucher_item.tenant_id = ? AND voucher_item.sl_id = ?", s.tenantID, slID).
+		Where("voucher.status = ?", voucher.StatusPosted).
+		Scan(&balance).Error; err != nil {
+		return nil, amountSQLError(err)
+	}
+	balance.Balance = balance.TotalDebit - balance.TotalCredit
+	return &balance, nil
+}
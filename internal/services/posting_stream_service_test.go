@@ -0,0 +1,156 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/stream"
+	"accountingsystem/internal/requests/voucher"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStopStream = errors.New("stop stream")
+
+// currentStreamCursor returns the id of the last event of the default
+// tenant, so that a stream resumed from it starts with the next event.
+func currentStreamCursor(t *testing.T) int64 {
+	var cursor int64
+	require.Nil(t, postingStreamService.db.Model(&models.OutboxEvent{}).
+		Where("tenant_id = ?", auth.DefaultTenantID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&cursor).Error)
+	return cursor
+}
+
+// collectPostings streams until count messages arrived or the deadline
+// passed.
+func collectPostings(t *testing.T, req *stream.PostingsRequest, count int, timeout time.Duration) ([]dtos.PostingMessageDto, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var messages []dtos.PostingMessageDto
+	err := postingStreamService.StreamPostings(ctx, testAdmin, req, func(message *dtos.PostingMessageDto) error {
+		messages = append(messages, *message)
+		if len(messages) == count {
+			return errStopStream
+		}
+		return nil
+	})
+	return messages, err
+}
+
+func Test_StreamPostings_SendsVoucherEventsAndBalances_WithSLFilter(t *testing.T) {
+	cursor := currentStreamCursor(t)
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	slID := voucherDto.VoucherItems[0].SLID
+
+	messages, err := collectPostings(t, &stream.PostingsRequest{Cursor: cursor, SLIDs: []int{slID}}, 3, 10*time.Second)
+
+	assert.ErrorIs(t, err, errStopStream)
+	require.Len(t, messages, 3)
+	assert.Equal(t, events.VoucherCreated, messages[0].Type)
+	assert.Equal(t, voucherDto.ID, messages[0].VoucherID)
+	assert.Equal(t, events.VoucherPosted, messages[1].Type)
+	assert.Equal(t, stream.MessageSLBalanceChanged, messages[2].Type)
+	assert.GreaterOrEqual(t, messages[2].Cursor, messages[1].Cursor)
+	require.NotNil(t, messages[2].Balance)
+	assert.Equal(t, slID, messages[2].Balance.SLID)
+	assert.Equal(t, voucherDto.VoucherItems[0].Debit, messages[2].Balance.TotalDebit)
+	assert.Equal(t, voucherDto.VoucherItems[0].Debit-voucherDto.VoucherItems[0].Credit, messages[2].Balance.Balance)
+}
+
+func Test_StreamPostings_SendsCurrentBalancesOnce_AfterReplayedEvents(t *testing.T) {
+	cursor := currentStreamCursor(t)
+	firstVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+	slID := firstVoucher.VoucherItems[0].SLID
+	debitSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	secondVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number: generateRandomString(20),
+		VoucherItems: []voucher.VoucherItemInsertDetail{
+			{SLID: debitSL.ID, Debit: 40},
+			{SLID: slID, DLID: &firstVoucher.VoucherItems[0].DLID, Credit: 40},
+		},
+	})
+	require.Nil(t, err)
+
+	messages, err := collectPostings(t, &stream.PostingsRequest{Cursor: cursor, SLIDs: []int{slID}}, 5, 10*time.Second)
+
+	assert.ErrorIs(t, err, errStopStream)
+	require.Len(t, messages, 5)
+	assert.Equal(t, []string{events.VoucherCreated, events.VoucherPosted, events.VoucherCreated, events.VoucherPosted, stream.MessageSLBalanceChanged},
+		[]string{messages[0].Type, messages[1].Type, messages[2].Type, messages[3].Type, messages[4].Type})
+	assert.Equal(t, secondVoucher.ID, messages[3].VoucherID)
+	assert.Equal(t, 0, messages[4].VoucherID)
+	require.NotNil(t, messages[4].Balance)
+	assert.Equal(t, slID, messages[4].Balance.SLID)
+	assert.Equal(t, firstVoucher.VoucherItems[0].Debit, messages[4].Balance.TotalDebit)
+	assert.Equal(t, money.Amount(40), messages[4].Balance.TotalCredit)
+}
+
+func Test_StreamPostings_ResumesAfterCursor(t *testing.T) {
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	outboxEvents := findOutboxEvents(t, events.AggregateVoucher, voucherDto.ID)
+	require.Equal(t, events.VoucherCreated, outboxEvents[0].Type)
+
+	req := &stream.PostingsRequest{Cursor: outboxEvents[0].ID, SLIDs: []int{voucherDto.VoucherItems[0].SLID}}
+	messages, err := collectPostings(t, req, 1, 10*time.Second)
+
+	assert.ErrorIs(t, err, errStopStream)
+	require.Len(t, messages, 1)
+	assert.Equal(t, events.VoucherPosted, messages[0].Type)
+	assert.Greater(t, messages[0].Cursor, outboxEvents[0].ID)
+}
+
+func Test_StreamPostings_SkipsVouchers_WithoutMatchingDL(t *testing.T) {
+	cursor := currentStreamCursor(t)
+	otherDL, err := createRandomDL()
+	require.Nil(t, err)
+	_, err = createRandomVoucher()
+	require.Nil(t, err)
+
+	messages, err := collectPostings(t, &stream.PostingsRequest{Cursor: cursor, DLIDs: []int{otherDL.ID}}, 1, 2*time.Second)
+
+	assert.ErrorIs(t, err, constants.ErrDeadlineExceeded)
+	assert.Empty(t, messages)
+}
+
+func Test_StreamPostings_HoldsBackLaterEvents_UntilEarlierTransactionCommits(t *testing.T) {
+	cursor := currentStreamCursor(t)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	slID := items[0].SLID
+	payload, err := json.Marshal(dtos.VoucherWithItemsDto{VoucherItems: []dtos.VoucherItemDto{{SLID: slID, Debit: 100}}})
+	require.Nil(t, err)
+	tx := postingStreamService.db.Begin()
+	defer tx.Rollback()
+	earlierEvent := models.OutboxEvent{TenantID: auth.DefaultTenantID, Type: events.VoucherCreated, AggregateType: events.AggregateVoucher, AggregateID: generateRandomInt64(), Payload: payload, NextAttemptAt: time.Now().UTC()}
+	require.Nil(t, tx.Create(&earlierEvent).Error)
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: generateRandomString(20), VoucherItems: items})
+	require.Nil(t, err)
+
+	messages, err := collectPostings(t, &stream.PostingsRequest{Cursor: cursor, SLIDs: []int{slID}}, 1, 2*time.Second)
+
+	assert.ErrorIs(t, err, constants.ErrDeadlineExceeded)
+	assert.Empty(t, messages)
+
+	require.Nil(t, tx.Commit().Error)
+	messages, err = collectPostings(t, &stream.PostingsRequest{Cursor: cursor, SLIDs: []int{slID}}, 2, 10*time.Second)
+
+	assert.ErrorIs(t, err, errStopStream)
+	require.Len(t, messages, 2)
+	assert.Equal(t, earlierEvent.AggregateID, messages[0].VoucherID)
+	assert.Equal(t, events.VoucherCreated, messages[1].Type)
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/requests/report"
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type ReportService struct {
+	db       *gorm.DB
+	tenantID int
+}
+
+func (s *ReportService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+func (s *ReportService) forTenant(tenantID int) *ReportService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+// withContext returns a copy of the service whose queries run under ctx, so
+// they are abandoned once it is canceled or its deadline passes.
+func (s *ReportService) withContext(ctx context.Context) *ReportService {
+	scoped := *s
+	scoped.db = s.db.WithContext(ctx)
+	return &scoped
+}
+
+func (s *ReportService) GetLedgerCard(actor auth.Principal, req *report.LedgerCardRequest) (*dtos.LedgerCardDto, error) {
+	return s.GetLedgerCardContext(context.Background(), actor, req)
+}
+
+func (s *ReportService) GetLedgerCardContext(ctx context.Context, actor auth.Principal, req *report.LedgerCardRequest) (*dtos.LedgerCardDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	ledgerCardDto, err := s.validateLedgerCardRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	if err := s.applyLedgerCardGet(req, ledgerCardDto); err != nil {
+		if errors.Is(err, constants.ErrAmountOverflow) {
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting ledger card: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return ledgerCardDto, nil
+}
+
+func (s *ReportService) ExportLedgerCard(actor auth.Principal, req *report.LedgerCardRequest, format export.Format, w io.Writer) error {
+	return s.ExportLedgerCardContext(context.Background(), actor, req, format, w)
+}
+
+func (s *ReportService) ExportLedgerCardContext(ctx context.Context, actor auth.Principal, req *report.LedgerCardRequest, format export.Format, w io.Writer) error {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	ledgerCardDto, err := s.validateLedgerCardExportRequest(req, format)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+
+	if err := s.applyLedgerCardExport(req, ledgerCardDto, format, w); err != nil {
+		if errors.Is(err, constants.ErrAmountOverflow) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
+		log.Printf("unexpected error while exporting ledger card: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+func (s *ReportService) GetPeriodSummary(actor auth.Principal, req *report.PeriodSummaryRequest) (*dtos.PeriodSummaryDto, error) {
+	return s.GetPeriodSummaryContext(context.Background(), actor, req)
+}
+
+func (s *ReportService) GetPeriodSummaryContext(ctx context.Context, actor auth.Principal, req *report.PeriodSummaryRequest) (*dtos.PeriodSummaryDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	periodSummaryDto, err := s.validatePeriodSummaryRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	if err := s.applyPeriodSummaryGet(req, periodSummaryDto); err != nil {
+		if errors.Is(err, constants.ErrAmountOverflow) {
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting period summary: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return periodSummaryDto, nil
+}
+
+func (s *ReportService) GetTrialBalance(actor auth.Principal, req *report.TrialBalanceRequest) (*dtos.TrialBalanceDto, error) {
+	return s.GetTrialBalanceContext(context.Background(), actor, req)
+}
+
+func (s *ReportService) GetTrialBalanceContext(ctx context.Context, actor auth.Principal, req *report.TrialBalanceRequest) (*dtos.TrialBalanceDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	trialBalanceDto, err := s.applyTrialBalanceGet(req)
+	if errors.Is(err, constants.ErrAmountOverflow) {
+		return nil, err
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting trial balance: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return trialBalanceDto, nil
+}
+
+func (s *ReportService) GetSLBalances(actor auth.Principal, req *report.SLBalancesRequest) ([]dtos.SLBalanceDto, error) {
+	return s.GetSLBalancesContext(context.Background(), actor, req)
+}
+
+func (s *ReportService) GetSLBalancesContext(ctx context.Context, actor auth.Principal, req *report.SLBalancesRequest) ([]dtos.SLBalanceDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	if err := s.validateSLBalancesRequest(req); err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	balances, err := s.applySLBalancesGet(req)
+	if errors.Is(err, constants.ErrAmountOverflow) {
+		return nil, err
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting SL balances: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return balances, nil
+}
+
+func (s *ReportService) ExportTrialBalance(actor auth.Principal, req *report.TrialBalanceRequest, format export.Format, w io.Writer) error {
+	return s.ExportTrialBalanceContext(context.Background(), actor, req, format, w)
+}
+
+func (s *ReportService) ExportTrialBalanceContext(ctx context.Context, actor auth.Principal, req *report.TrialBalanceRequest, format export.Format, w io.Writer) error {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	if err := s.validateExportFormat(format); err != nil {
+		return err
+	}
+
+	if err := s.applyTrialBalanceExport(req, format, w); err != nil {
+		if errors.Is(err, constants.ErrAmountOverflow) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
+		log.Printf("unexpected error while exporting trial balance: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
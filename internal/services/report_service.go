@@ -0,0 +1,423 @@
+package services
+
+import (
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/report"
+	"accountingsystem/internal/requests/voucher"
+	"errors"
+	"io"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (s *ReportService) validateLedgerCardRequest(req *report.LedgerCardRequest) (*dtos.LedgerCardDto, error) {
+	if err := s.validateCalendar(req.Calendar); err != nil {
+		return nil, err
+	}
+	sl, err := s.validateSLExists(req.SLID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerCardDto := &dtos.LedgerCardDto{
+		SLID:    sl.ID,
+		SLCode:  sl.Code,
+		SLTitle: sl.Title,
+	}
+
+	if req.DLID != nil {
+		dl, err := s.validateDLExists(*req.DLID)
+		if err != nil {
+			return nil, err
+		}
+		ledgerCardDto.DLID = dl.ID
+		ledgerCardDto.DLCode = dl.Code
+		ledgerCardDto.DLTitle = dl.Title
+	}
+
+	if req.CurrencyCode != nil {
+		currency, err := s.validateCurrencyExistsByCode(*req.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		ledgerCardDto.CurrencyCode = currency.Code
+	}
+
+	return ledgerCardDto, nil
+}
+
+func (s *ReportService) validateLedgerCardExportRequest(req *report.LedgerCardRequest, format export.Format) (*dtos.LedgerCardDto, error) {
+	if err := s.validateExportFormat(format); err != nil {
+		return nil, err
+	}
+	return s.validateLedgerCardRequest(req)
+}
+
+func (s *ReportService) validateExportFormat(format export.Format) error {
+	if !format.IsValid() {
+		return constants.ErrInvalidExportFormat
+	}
+	return nil
+}
+
+func (s *ReportService) validateCalendar(reportCalendar calendar.Calendar) error {
+	if !calendar.OrDefault(reportCalendar).IsValid() {
+		return constants.ErrInvalidCalendar
+	}
+	return nil
+}
+
+func (s *ReportService) validateSLExists(id int) (*models.SL, error) {
+	var sl models.SL
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&sl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrSLNotFound
+		}
+		return nil, err
+	}
+	return &sl, nil
+}
+
+func (s *ReportService) validateDLExists(id int) (*models.DL, error) {
+	var dl models.DL
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&dl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrDLNotFound
+		}
+		return nil, err
+	}
+	return &dl, nil
+}
+
+func (s *ReportService) validateCurrencyExistsByCode(code string) (*models.Currency, error) {
+	var currency models.Currency
+	if err := s.db.Where("code = ?", code).First(&currency).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrCurrencyNotFound
+		}
+		return nil, err
+	}
+	return &currency, nil
+}
+
+func (s *ReportService) addToTrialBalanceTotals(trialBalanceDto *dtos.TrialBalanceDto, row dtos.TrialBalanceRowDto) error {
+	var err error
+	if trialBalanceDto.TotalDebit, err = trialBalanceDto.TotalDebit.Add(row.TotalDebit); err != nil {
+		return err
+	}
+	if trialBalanceDto.TotalCredit, err = trialBalanceDto.TotalCredit.Add(row.TotalCredit); err != nil {
+		return err
+	}
+	if trialBalanceDto.TotalDebitBalance, err = trialBalanceDto.TotalDebitBalance.Add(row.DebitBalance); err != nil {
+		return err
+	}
+	if trialBalanceDto.TotalCreditBalance, err = trialBalanceDto.TotalCreditBalance.Add(row.CreditBalance); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ReportService) applyLedgerCardGet(req *report.LedgerCardRequest, ledgerCardDto *dtos.LedgerCardDto) error {
+	ledgerCardDto.Rows = []dtos.LedgerCardRowDto{}
+	return s.iterateLedgerCard(req, ledgerCardDto, func(row dtos.LedgerCardRowDto) error {
+		ledgerCardDto.Rows = append(ledgerCardDto.Rows, row)
+		return nil
+	})
+}
+
+func (s *ReportService) applyLedgerCardExport(req *report.LedgerCardRequest, ledgerCardDto *dtos.LedgerCardDto, format export.Format, w io.Writer) error {
+	writer, err := export.NewLedgerCardWriter(w, format, ledgerCardDto)
+	if err != nil {
+		return err
+	}
+	if err := s.iterateLedgerCard(req, ledgerCardDto, writer.WriteRow); err != nil {
+		return err
+	}
+	return writer.Close(ledgerCardDto)
+}
+
+func (s *ReportService) iterateLedgerCard(req *report.LedgerCardRequest, ledgerCardDto *dtos.LedgerCardDto, handle func(row dtos.LedgerCardRowDto) error) error {
+	query := s.db.Table("voucher_item").
+		Select("voucher.id AS voucher_id, voucher.number AS voucher_number, voucher.date AS voucher_date, voucher_item.id AS voucher_item_id, voucher_item.debit, voucher_item.credit, "+
+			"COALESCE(voucher_item.currency_code, '') AS currency_code, voucher_item.foreign_debit, voucher_item.foreign_credit, "+
+			"COALESCE(voucher_item.exchange_rate::text, '') AS exchange_rate").
+		Joins("JOIN voucher ON voucher.id = voucher_item.voucher_id").
+		Where("voucher_item.tenant_id = ? AND voucher_item.sl_id = ?", s.tenantID, req.SLID).
+		Where("voucher.status = ?", voucher.StatusPosted)
+	if req.DLID != nil {
+		query = query.Where("voucher_item.dl_id = ?", *req.DLID)
+	}
+	if req.CurrencyCode != nil {
+		query = query.Where("voucher_item.currency_code = ?", *req.CurrencyCode)
+	}
+
+	cardCalendar := calendar.OrDefault(req.Calendar)
+	rows, err := query.Order("voucher.id, voucher_item.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dtos.LedgerCardRowDto
+		if err := s.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		row.VoucherDateText = cardCalendar.Format(row.VoucherDate)
+		if ledgerCardDto.TotalDebit, err = ledgerCardDto.TotalDebit.Add(row.Debit); err != nil {
+			return err
+		}
+		if ledgerCardDto.TotalCredit, err = ledgerCardDto.TotalCredit.Add(row.Credit); err != nil {
+			return err
+		}
+		// Both totals are non-negative, so their difference cannot overflow.
+		row.Balance = ledgerCardDto.TotalDebit - ledgerCardDto.TotalCredit
+		if req.CurrencyCode != nil {
+			if ledgerCardDto.TotalForeignDebit, err = ledgerCardDto.TotalForeignDebit.Add(row.ForeignDebit); err != nil {
+				return err
+			}
+			if ledgerCardDto.TotalForeignCredit, err = ledgerCardDto.TotalForeignCredit.Add(row.ForeignCredit); err != nil {
+				return err
+			}
+			row.ForeignBalance = ledgerCardDto.TotalForeignDebit - ledgerCardDto.TotalForeignCredit
+		}
+		if err := handle(row); err != nil {
+			return err
+		}
+	}
+	ledgerCardDto.Balance = ledgerCardDto.TotalDebit - ledgerCardDto.TotalCredit
+	ledgerCardDto.ForeignBalance = ledgerCardDto.TotalForeignDebit - ledgerCardDto.TotalForeignCredit
+
+	return rows.Err()
+}
+
+func (s *ReportService) applyTrialBalanceGet(req *report.TrialBalanceRequest) (*dtos.TrialBalanceDto, error) {
+	trialBalanceDto := &dtos.TrialBalanceDto{Rows: []dtos.TrialBalanceRowDto{}}
+	err := s.iterateTrialBalance(req, trialBalanceDto, func(row dtos.TrialBalanceRowDto) error {
+		trialBalanceDto.Rows = append(trialBalanceDto.Rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trialBalanceDto, nil
+}
+
+func (s *ReportService) applyTrialBalanceExport(req *report.TrialBalanceRequest, format export.Format, w io.Writer) error {
+	writer, err := export.NewTrialBalanceWriter(w, format, req.ByCurrency)
+	if err != nil {
+		return err
+	}
+	trialBalanceDto := &dtos.TrialBalanceDto{}
+	if err := s.iterateTrialBalance(req, trialBalanceDto, writer.WriteRow); err != nil {
+		return err
+	}
+	return writer.Close(trialBalanceDto)
+}
+
+func (s *ReportService) iterateTrialBalance(req *report.TrialBalanceRequest, trialBalanceDto *dtos.TrialBalanceDto, handle func(row dtos.TrialBalanceRowDto) error) error {
+	query := s.db.Table("voucher_item").
+		Joins("JOIN sl ON sl.id = voucher_item.sl_id").
+		Joins("JOIN voucher ON voucher.id = voucher_item.voucher_id").
+		Where("voucher_item.tenant_id = ? AND voucher.status = ?", s.tenantID, voucher.StatusPosted)
+	// SUM over BIGINT yields NUMERIC; the cast makes Postgres report a sum
+	// that does not fit an amount instead of handing back a value that does
+	// not scan.
+	if req.ByCurrency {
+		query = query.
+			Select("sl.id AS sl_id, sl.code AS sl_code, sl.title AS sl_title, SUM(voucher_item.debit)::BIGINT AS total_debit, SUM(voucher_item.credit)::BIGINT AS total_credit, " +
+				"COALESCE(voucher_item.currency_code, '') AS currency_code, SUM(voucher_item.foreign_debit)::BIGINT AS total_foreign_debit, SUM(voucher_item.foreign_credit)::BIGINT AS total_foreign_credit").
+			Group("sl.id, sl.code, sl.title, voucher_item.currency_code").
+			Order("sl.code, voucher_item.currency_code NULLS FIRST")
+	} else {
+		query = query.
+			Select("sl.id AS sl_id, sl.code AS sl_code, sl.title AS sl_title, SUM(voucher_item.debit)::BIGINT AS total_debit, SUM(voucher_item.credit)::BIGINT AS total_credit").
+			Group("sl.id, sl.code, sl.title").
+			Order("sl.code")
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return amountSQLError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dtos.TrialBalanceRowDto
+		if err := s.db.ScanRows(rows, &row); err != nil {
+			return amountSQLError(err)
+		}
+		if row.TotalDebit > row.TotalCredit {
+			row.DebitBalance = row.TotalDebit - row.TotalCredit
+		} else {
+			row.CreditBalance = row.TotalCredit - row.TotalDebit
+		}
+		if row.TotalForeignDebit > row.TotalForeignCredit {
+			row.ForeignDebitBalance = row.TotalForeignDebit - row.TotalForeignCredit
+		} else {
+			row.ForeignCreditBalance = row.TotalForeignCredit - row.TotalForeignDebit
+		}
+		if err := s.addToTrialBalanceTotals(trialBalanceDto, row); err != nil {
+			return err
+		}
+		if err := handle(row); err != nil {
+			return err
+		}
+	}
+
+	return amountSQLError(rows.Err())
+}
+
+func (s *ReportService) validateSLBalancesRequest(req *report.SLBalancesRequest) error {
+	if len(req.SLIDs) > constants.MaxLookupIDs {
+		return constants.ErrTooManyIDs
+	}
+	return nil
+}
+
+// applySLBalancesGet sums the posted lines of each SL in one query. SLs
+// without posted lines get a zero balance.
+func (s *ReportService) applySLBalancesGet(req *report.SLBalancesRequest) ([]dtos.SLBalanceDto, error) {
+	balances := []dtos.SLBalanceDto{}
+	if len(req.SLIDs) == 0 {
+		return balances, nil
+	}
+
+	if err := s.db.Table("sl").
+		Select("sl.id AS sl_id, COALESCE(SUM(voucher_item.debit), 0)::BIGINT AS total_debit, COALESCE(SUM(voucher_item.credit), 0)::BIGINT AS total_credit").
+		Joins("LEFT JOIN (voucher_item JOIN voucher ON voucher.id = voucher_item.voucher_id AND voucher.status = ?) ON voucher_item.sl_id = sl.id", voucher.StatusPosted).
+		Where("sl.tenant_id = ? AND sl.id IN ?", s.tenantID, req.SLIDs).
+		Group("sl.id").
+		Order("sl.id").
+		Scan(&balances).Error; err != nil {
+		return nil, amountSQLError(err)
+	}
+	for i := range balances {
+		balances[i].Balance = balances[i].TotalDebit - balances[i].TotalCredit
+	}
+	return balances, nil
+}
+
+func (s *ReportService) validatePeriodSummaryRequest(req *report.PeriodSummaryRequest) (*dtos.PeriodSummaryDto, error) {
+	if err := s.validateCalendar(req.Calendar); err != nil {
+		return nil, err
+	}
+	summaryCalendar := calendar.OrDefault(req.Calendar)
+	startMonth := req.FiscalYearStartMonth
+	if startMonth == 0 {
+		startMonth = 1
+	}
+	fiscalYear := req.FiscalYear
+	if fiscalYear == 0 {
+		fiscalYear = summaryCalendar.FiscalYearOf(time.Now(), startMonth)
+	}
+	rows, err := s.buildPeriodRows(summaryCalendar, fiscalYear, startMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	sl, err := s.validateSLExists(req.SLID)
+	if err != nil {
+		return nil, err
+	}
+	periodSummaryDto := &dtos.PeriodSummaryDto{
+		SLID:       sl.ID,
+		SLCode:     sl.Code,
+		SLTitle:    sl.Title,
+		Calendar:   summaryCalendar,
+		FiscalYear: fiscalYear,
+		Rows:       rows,
+	}
+
+	if req.DLID != nil {
+		dl, err := s.validateDLExists(*req.DLID)
+		if err != nil {
+			return nil, err
+		}
+		periodSummaryDto.DLID = dl.ID
+		periodSummaryDto.DLCode = dl.Code
+		periodSummaryDto.DLTitle = dl.Title
+	}
+
+	return periodSummaryDto, nil
+}
+
+func (s *ReportService) buildPeriodRows(summaryCalendar calendar.Calendar, fiscalYear int, startMonth int) ([]dtos.PeriodSummaryRowDto, error) {
+	starts, err := summaryCalendar.Periods(fiscalYear, startMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dtos.PeriodSummaryRowDto, len(starts)-1)
+	for i := range rows {
+		year, month := summaryCalendar.AddMonths(fiscalYear, startMonth, i)
+		endDate := starts[i+1].AddDate(0, 0, -1)
+		rows[i] = dtos.PeriodSummaryRowDto{
+			Year:          year,
+			Month:         month,
+			Label:         summaryCalendar.PeriodLabel(year, month),
+			StartDate:     starts[i],
+			EndDate:       endDate,
+			StartDateText: summaryCalendar.Format(starts[i]),
+			EndDateText:   summaryCalendar.Format(endDate),
+		}
+	}
+	return rows, nil
+}
+
+func (s *ReportService) applyPeriodSummaryGet(req *report.PeriodSummaryRequest, periodSummaryDto *dtos.PeriodSummaryDto) error {
+	rows := periodSummaryDto.Rows
+	query := s.db.Table("voucher_item").
+		Select("voucher.date AS date, SUM(voucher_item.debit)::BIGINT AS debit, SUM(voucher_item.credit)::BIGINT AS credit").
+		Joins("JOIN voucher ON voucher.id = voucher_item.voucher_id").
+		Where("voucher_item.tenant_id = ? AND voucher_item.sl_id = ?", s.tenantID, req.SLID).
+		Where("voucher.status = ?", voucher.StatusPosted).
+		Where("voucher.date >= ? AND voucher.date <= ?", rows[0].StartDate, rows[len(rows)-1].EndDate)
+	if req.DLID != nil {
+		query = query.Where("voucher_item.dl_id = ?", *req.DLID)
+	}
+
+	var dailyTotals []struct {
+		Date   time.Time
+		Debit  money.Amount
+		Credit money.Amount
+	}
+	if err := query.Group("voucher.date").Order("voucher.date").Scan(&dailyTotals).Error; err != nil {
+		return amountSQLError(err)
+	}
+
+	// Daily totals are ordered by date, so the period they fall in only
+	// moves forward.
+	period := 0
+	var err error
+	for _, total := range dailyTotals {
+		for total.Date.After(rows[period].EndDate) {
+			period++
+		}
+		if rows[period].Debit, err = rows[period].Debit.Add(total.Debit); err != nil {
+			return err
+		}
+		if rows[period].Credit, err = rows[period].Credit.Add(total.Credit); err != nil {
+			return err
+		}
+	}
+
+	for i := range rows {
+		if periodSummaryDto.TotalDebit, err = periodSummaryDto.TotalDebit.Add(rows[i].Debit); err != nil {
+			return err
+		}
+		if periodSummaryDto.TotalCredit, err = periodSummaryDto.TotalCredit.Add(rows[i].Credit); err != nil {
+			return err
+		}
+		rows[i].Balance = periodSummaryDto.TotalDebit - periodSummaryDto.TotalCredit
+	}
+	periodSummaryDto.Balance = periodSummaryDto.TotalDebit - periodSummaryDto.TotalCredit
+
+	return nil
+}
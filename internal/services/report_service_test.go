@@ -0,0 +1,368 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/report"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/voucher"
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createVoucherAgainstSL(slID int, debit money.Amount, credit money.Amount) (*dtos.VoucherWithItemsDto, error) {
+	counterSL, err := createRandomSL(false)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []voucher.VoucherItemInsertDetail{
+		{
+			SLID:   slID,
+			DLID:   nil,
+			Debit:  debit,
+			Credit: credit,
+		},
+		{
+			SLID:   counterSL.ID,
+			DLID:   nil,
+			Debit:  credit,
+			Credit: debit,
+		},
+	}
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+	return voucherService.CreateVoucher(testAdmin, req)
+}
+
+func Test_GetLedgerCard_Succeeds_WithRunningBalance(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(createdSL.ID, 300, 0)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(createdSL.ID, 0, 100)
+	require.Nil(t, err)
+
+	ledgerCard, err := reportService.GetLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: createdSL.ID})
+
+	require.Nil(t, err)
+	require.Len(t, ledgerCard.Rows, 2)
+	assert.Equal(t, money.Amount(300), ledgerCard.Rows[0].Balance)
+	assert.Equal(t, money.Amount(200), ledgerCard.Rows[1].Balance)
+	assert.Equal(t, money.Amount(300), ledgerCard.TotalDebit)
+	assert.Equal(t, money.Amount(100), ledgerCard.TotalCredit)
+	assert.Equal(t, money.Amount(200), ledgerCard.Balance)
+}
+
+func Test_GetLedgerCard_ReturnsErrSLNotFound_WithNonExistingSLID(t *testing.T) {
+	ledgerCard, err := reportService.GetLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: generateRandomInt64()})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLNotFound)
+	assert.Nil(t, ledgerCard)
+}
+
+func Test_GetLedgerCard_ReturnsErrDLNotFound_WithNonExistingDLID(t *testing.T) {
+	createdSL, err := createRandomSL(true)
+	require.Nil(t, err)
+	nonExistingDLID := generateRandomInt64()
+
+	ledgerCard, err := reportService.GetLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: createdSL.ID, DLID: &nonExistingDLID})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDLNotFound)
+	assert.Nil(t, ledgerCard)
+}
+
+func Test_GetTrialBalance_Succeeds_WithSLTotals(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(createdSL.ID, 0, 700)
+	require.Nil(t, err)
+
+	trialBalance, err := reportService.GetTrialBalance(testAdmin, &report.TrialBalanceRequest{})
+
+	require.Nil(t, err)
+	var found *dtos.TrialBalanceRowDto
+	for i := range trialBalance.Rows {
+		if trialBalance.Rows[i].SLID == createdSL.ID {
+			found = &trialBalance.Rows[i]
+		}
+	}
+	require.NotNil(t, found)
+	assert.Equal(t, money.Amount(700), found.TotalCredit)
+	assert.Equal(t, money.Amount(700), found.CreditBalance)
+	assert.Equal(t, trialBalance.TotalDebit, trialBalance.TotalCredit)
+}
+
+func Test_ExportLedgerCard_WritesCSV_WithValidRequest(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(createdSL.ID, 500, 0)
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = reportService.ExportLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: createdSL.ID}, export.FormatCSV, &output)
+
+	require.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "Ledger Card", lines[0])
+	assert.Equal(t, "SL: "+createdSL.Code+" - "+createdSL.Title, lines[1])
+	assert.Equal(t, "", lines[2])
+	assert.Equal(t, "Voucher ID,Voucher Number,Date,Item ID,Debit,Credit,Balance", lines[3])
+	assert.True(t, strings.HasSuffix(lines[4], ",500,0,500"))
+	assert.True(t, strings.HasPrefix(lines[5], "Total,"))
+}
+
+// createOverflowingSL posts two vouchers whose debits on the returned SL
+// add up to more than an amount can hold. The totals overflow in a tenant of
+// their own, so the reports of the other tests still add up.
+func createOverflowingSL() (auth.Principal, *dtos.SLDto, error) {
+	admin, err := createRandomTenantAdmin()
+	if err != nil {
+		return auth.Principal{}, nil, err
+	}
+	createdSL, err := slService.CreateSL(admin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	if err != nil {
+		return auth.Principal{}, nil, err
+	}
+	for i := 0; i < 2; i++ {
+		counterSL, err := slService.CreateSL(admin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+		if err != nil {
+			return auth.Principal{}, nil, err
+		}
+		if _, err := voucherService.CreateVoucher(admin, &voucher.InsertRequest{
+			Number: generateRandomString(20),
+			VoucherItems: []voucher.VoucherItemInsertDetail{
+				{SLID: createdSL.ID, Debit: math.MaxInt64/2 + 1},
+				{SLID: counterSL.ID, Credit: math.MaxInt64/2 + 1},
+			},
+		}); err != nil {
+			return auth.Principal{}, nil, err
+		}
+	}
+	return admin, createdSL, nil
+}
+
+func Test_ExportLedgerCard_ReturnsErrAmountOverflow_WithOverflowingTotals(t *testing.T) {
+	admin, createdSL, err := createOverflowingSL()
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = reportService.ExportLedgerCard(admin, &report.LedgerCardRequest{SLID: createdSL.ID}, export.FormatCSV, &output)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrAmountOverflow)
+}
+
+func Test_GetTrialBalance_ReturnsErrAmountOverflow_WithOverflowingSums(t *testing.T) {
+	admin, _, err := createOverflowingSL()
+	require.Nil(t, err)
+
+	trialBalance, err := reportService.GetTrialBalance(admin, &report.TrialBalanceRequest{})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrAmountOverflow)
+	assert.Nil(t, trialBalance)
+}
+
+func Test_GetSLBalances_ReturnsErrAmountOverflow_WithOverflowingSums(t *testing.T) {
+	admin, createdSL, err := createOverflowingSL()
+	require.Nil(t, err)
+
+	balances, err := reportService.GetSLBalances(admin, &report.SLBalancesRequest{SLIDs: []int{createdSL.ID}})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrAmountOverflow)
+	assert.Nil(t, balances)
+}
+
+func Test_ExportLedgerCard_ReturnsErrInvalidExportFormat_WithUnknownFormat(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = reportService.ExportLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: createdSL.ID}, export.Format("docx"), &output)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidExportFormat)
+	assert.Zero(t, output.Len())
+}
+
+func Test_ExportTrialBalance_WritesXLSX_WithValidRequest(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(createdSL.ID, 700, 0)
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = reportService.ExportTrialBalance(testAdmin, &report.TrialBalanceRequest{}, export.FormatXLSX, &output)
+
+	require.Nil(t, err)
+	archive, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	require.Nil(t, err)
+	sheet, err := archive.Open("xl/worksheets/sheet1.xml")
+	require.Nil(t, err)
+	content, err := io.ReadAll(sheet)
+	require.Nil(t, err)
+	assert.Contains(t, string(content), ">Trial Balance</t>")
+	assert.Contains(t, string(content), ">"+createdSL.Code+"</t>")
+	assert.Contains(t, string(content), "<v>700</v>")
+}
+
+func Test_GetTrialBalance_ShowsForeignBalances_ByCurrency(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	bankSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(5000)
+	require.Nil(t, err)
+	items[0].SLID = bankSL.ID
+	items[0].Debit = 0
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: createdCurrency.Code, ForeignDebit: 50, ExchangeRate: "100"}
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: generateRandomString(20), VoucherItems: items})
+	require.Nil(t, err)
+
+	trialBalance, err := reportService.GetTrialBalance(testAdmin, &report.TrialBalanceRequest{ByCurrency: true})
+
+	require.Nil(t, err)
+	var found *dtos.TrialBalanceRowDto
+	for i := range trialBalance.Rows {
+		if trialBalance.Rows[i].SLID == bankSL.ID {
+			found = &trialBalance.Rows[i]
+		}
+	}
+	require.NotNil(t, found)
+	assert.Equal(t, createdCurrency.Code, found.CurrencyCode)
+	assert.Equal(t, money.Amount(5000), found.DebitBalance)
+	assert.Equal(t, money.Amount(50), found.ForeignDebitBalance)
+}
+
+func Test_GetSLBalances_SumsPostedLines_WithZeroForUnusedSL(t *testing.T) {
+	usedSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	unusedSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(usedSL.ID, 300, 0)
+	require.Nil(t, err)
+	_, err = createVoucherAgainstSL(usedSL.ID, 0, 100)
+	require.Nil(t, err)
+
+	balances, err := reportService.GetSLBalances(testAdmin, &report.SLBalancesRequest{SLIDs: []int{unusedSL.ID, usedSL.ID, -1}})
+
+	require.Nil(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, dtos.SLBalanceDto{SLID: usedSL.ID, TotalDebit: 300, TotalCredit: 100, Balance: 200}, balances[0])
+	assert.Equal(t, dtos.SLBalanceDto{SLID: unusedSL.ID}, balances[1])
+}
+
+func Test_GetLedgerCard_ReturnsErrCurrencyNotFound_WithUnknownCurrency(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	unknownCode := "1AB"
+
+	ledgerCard, err := reportService.GetLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: createdSL.ID, CurrencyCode: &unknownCode})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCurrencyNotFound)
+	assert.Nil(t, ledgerCard)
+}
+
+func createJalaliDatedVoucherAgainstSL(slID int, dateText string, debit money.Amount) error {
+	items, err := createRandomBalancedItems(debit)
+	if err != nil {
+		return err
+	}
+	items[0].SLID = slID
+
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		DateInput:    voucher.DateInput{DateText: dateText, Calendar: calendar.Jalali},
+		VoucherItems: items,
+	})
+	return err
+}
+
+func Test_GetLedgerCard_LabelsVoucherDates_WithJalaliCalendar(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1403/07/01", 100))
+
+	ledgerCard, err := reportService.GetLedgerCard(testAdmin, &report.LedgerCardRequest{SLID: createdSL.ID, Calendar: calendar.Jalali})
+
+	require.Nil(t, err)
+	require.Len(t, ledgerCard.Rows, 1)
+	assert.Equal(t, "1403/07/01", ledgerCard.Rows[0].VoucherDateText)
+}
+
+func Test_GetPeriodSummary_GroupsByJalaliMonth_WithJalaliFiscalYear(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1403/01/01", 100))
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1403/01/31", 200))
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1403/02/01", 400))
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1403/12/30", 800))
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1404/01/01", 1600))
+
+	summary, err := reportService.GetPeriodSummary(testAdmin, &report.PeriodSummaryRequest{
+		SLID:       createdSL.ID,
+		Calendar:   calendar.Jalali,
+		FiscalYear: 1403,
+	})
+
+	require.Nil(t, err)
+	require.Len(t, summary.Rows, 12)
+	assert.Equal(t, "Farvardin 1403", summary.Rows[0].Label)
+	assert.Equal(t, "1403/01/01", summary.Rows[0].StartDateText)
+	assert.Equal(t, "1403/01/31", summary.Rows[0].EndDateText)
+	assert.Equal(t, money.Amount(300), summary.Rows[0].Debit)
+	assert.Equal(t, money.Amount(400), summary.Rows[1].Debit)
+	assert.Equal(t, money.Amount(700), summary.Rows[1].Balance)
+	assert.Equal(t, "1403/12/30", summary.Rows[11].EndDateText)
+	assert.Equal(t, money.Amount(800), summary.Rows[11].Debit)
+	assert.Equal(t, money.Amount(1500), summary.TotalDebit)
+	assert.Equal(t, money.Amount(1500), summary.Balance)
+}
+
+func Test_GetPeriodSummary_StartsAtFiscalStartMonth_WithMehrFiscalYear(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	require.Nil(t, createJalaliDatedVoucherAgainstSL(createdSL.ID, "1404/06/31", 100))
+
+	summary, err := reportService.GetPeriodSummary(testAdmin, &report.PeriodSummaryRequest{
+		SLID:                 createdSL.ID,
+		Calendar:             calendar.Jalali,
+		FiscalYear:           1403,
+		FiscalYearStartMonth: 7,
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, "Mehr 1403", summary.Rows[0].Label)
+	assert.Equal(t, "Shahrivar 1404", summary.Rows[11].Label)
+	assert.Equal(t, money.Amount(100), summary.Rows[11].Debit)
+}
+
+func Test_GetPeriodSummary_ReturnsErrInvalidCalendar_WithUnknownCalendar(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	summary, err := reportService.GetPeriodSummary(testAdmin, &report.PeriodSummaryRequest{SLID: createdSL.ID, Calendar: calendar.Calendar("julian")})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidCalendar)
+	assert.Nil(t, summary)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/revaluation"
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type RevaluationService struct {
+	db              *gorm.DB
+	tenantID        int
+	currencyService *CurrencyService
+	voucherService  *VoucherService
+}
+
+func (s *RevaluationService) InitService(db *gorm.DB) {
+	s.db = db
+	s.currencyService = &CurrencyService{}
+	s.currencyService.InitService(db)
+	s.voucherService = &VoucherService{}
+	s.voucherService.InitService(db)
+}
+
+func (s *RevaluationService) forTenant(tenantID int) *RevaluationService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	scoped.voucherService = s.voucherService.forTenant(tenantID)
+	return &scoped
+}
+
+func (s *RevaluationService) PreviewRevaluation(actor auth.Principal, req *revaluation.PreviewRequest) (*dtos.RevaluationDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	if err := s.validateRevaluationCurrency(req.CurrencyCode); err != nil {
+		return nil, err
+	}
+
+	revaluationDto, err := s.applyRevaluationPreview(req.Date, req.CurrencyCode)
+	if errors.Is(err, constants.ErrExchangeRateNotFound) || errors.Is(err, constants.ErrAmountOverflow) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("unexpected error while previewing revaluation: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return revaluationDto, nil
+}
+
+func (s *RevaluationService) PostRevaluation(actor auth.Principal, req *revaluation.PostRequest) (*dtos.RevaluationDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionReverse); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	revaluationDto, err := s.validateRevaluationPostRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRevaluationPost(actor, req, revaluationDto); err != nil {
+		if errors.Is(err, constants.ErrVoucherNumberExists) {
+			return nil, err
+		}
+		log.Printf("unexpected error while posting revaluation: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return revaluationDto, nil
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/revaluation"
+	"accountingsystem/internal/requests/voucher"
+	"math/big"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (s *RevaluationService) validateRevaluationCurrency(currencyCode *string) error {
+	if currencyCode == nil {
+		return nil
+	}
+	if _, err := s.currencyService.validateCurrencyExistsByCode(*currencyCode); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *RevaluationService) applyRevaluationPreview(date time.Time, currencyCode *string) (*dtos.RevaluationDto, error) {
+	date = s.voucherService.dateOrToday(date)
+	revaluationDto := &dtos.RevaluationDto{
+		Date:         date,
+		ReversalDate: s.nextPeriodStart(date),
+		Lines:        []dtos.RevaluationLineDto{},
+	}
+	revaluationDto.DateText = calendar.Default().Format(revaluationDto.Date)
+	revaluationDto.ReversalDateText = calendar.Default().Format(revaluationDto.ReversalDate)
+
+	positions, err := s.loadForeignCurrencyPositions(date, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	closingRates := map[string]*big.Rat{}
+	for _, line := range positions {
+		rate, ok := closingRates[line.CurrencyCode]
+		if !ok {
+			if rate, err = s.findClosingRate(line.CurrencyCode, date); err != nil {
+				return nil, err
+			}
+			closingRates[line.CurrencyCode] = rate
+		}
+
+		line.ClosingRate = rate.FloatString(10)
+		if line.RevaluedBalance, err = line.ForeignBalance.MulRat(rate); err != nil {
+			return nil, err
+		}
+		if line.Difference, err = line.RevaluedBalance.Sub(line.BookedBalance); err != nil {
+			return nil, err
+		}
+		if line.Difference == 0 {
+			continue
+		}
+
+		if line.Difference > 0 {
+			revaluationDto.TotalGain, err = revaluationDto.TotalGain.Add(line.Difference)
+		} else {
+			revaluationDto.TotalLoss, err = revaluationDto.TotalLoss.Sub(line.Difference)
+		}
+		if err != nil {
+			return nil, err
+		}
+		revaluationDto.Lines = append(revaluationDto.Lines, line)
+	}
+
+	return revaluationDto, nil
+}
+
+// loadForeignCurrencyPositions sums every foreign-currency line up to the
+// revaluation date, including earlier revaluations and their reversals, so
+// the booked balance is what the position is carried at on that date.
+func (s *RevaluationService) loadForeignCurrencyPositions(date time.Time, currencyCode *string) ([]dtos.RevaluationLineDto, error) {
+	query := s.db.Table("voucher_item").
+		Select("voucher_item.sl_id, COALESCE(voucher_item.dl_id, 0) AS dl_id, voucher_item.currency_code, "+
+			"SUM(voucher_item.foreign_debit - voucher_item.foreign_credit)::BIGINT AS foreign_balance, "+
+			"SUM(voucher_item.debit - voucher_item.credit)::BIGINT AS booked_balance").
+		Joins("JOIN voucher ON voucher.id = voucher_item.voucher_id").
+		Where("voucher_item.tenant_id = ? AND voucher_item.currency_code IS NOT NULL AND voucher.date <= ?", s.tenantID, date).
+		Where("voucher.status = ?", voucher.StatusPosted)
+	if currencyCode != nil {
+		query = query.Where("voucher_item.currency_code = ?", *currencyCode)
+	}
+
+	var positions []dtos.RevaluationLineDto
+	if err := query.
+		Group("voucher_item.sl_id, voucher_item.dl_id, voucher_item.currency_code").
+		Order("voucher_item.currency_code, voucher_item.sl_id, dl_id").
+		Scan(&positions).Error; err != nil {
+		return nil, amountSQLError(err)
+	}
+	return positions, nil
+}
+
+func (s *RevaluationService) findClosingRate(currencyCode string, date time.Time) (*big.Rat, error) {
+	targetCurrency, err := s.currencyService.validateCurrencyExistsByCode(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	currencyRate, err := s.currencyService.findExchangeRate(targetCurrency.ID, date)
+	if err != nil {
+		return nil, err
+	}
+	return s.currencyService.parseExchangeRate(currencyRate.Rate)
+}
+
+func (s *RevaluationService) nextPeriodStart(date time.Time) time.Time {
+	return calendar.Default().NextMonthStart(date)
+}
+
+func (s *RevaluationService) validateRevaluationPostRequest(req *revaluation.PostRequest) (*dtos.RevaluationDto, error) {
+	if err := s.validateRevaluationCurrency(req.CurrencyCode); err != nil {
+		return nil, err
+	}
+	if err := s.validateRevaluationNumbers(req); err != nil {
+		return nil, err
+	}
+	if err := s.voucherService.validateSLAndDL(req.GainSLID, req.GainDLID); err != nil {
+		return nil, err
+	}
+	if err := s.voucherService.validateSLAndDL(req.LossSLID, req.LossDLID); err != nil {
+		return nil, err
+	}
+
+	revaluationDto, err := s.applyRevaluationPreview(req.Date, req.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(revaluationDto.Lines) == 0 {
+		return nil, constants.ErrNothingToRevalue
+	}
+	return revaluationDto, nil
+}
+
+func (s *RevaluationService) validateRevaluationNumbers(req *revaluation.PostRequest) error {
+	if err := s.voucherService.validateVoucherNumberOrSequence(&voucher.InsertRequest{Number: req.Number, SequenceCode: req.SequenceCode}); err != nil {
+		return err
+	}
+	if err := s.voucherService.validateVoucherNumberOrSequence(&voucher.InsertRequest{Number: req.ReversalNumber, SequenceCode: req.SequenceCode}); err != nil {
+		return err
+	}
+	if req.Number != "" && req.Number == req.ReversalNumber {
+		return constants.ErrVoucherNumberExists
+	}
+	return nil
+}
+
+// applyRevaluationPost books the revaluation and its reversal in one
+// transaction so a period never ends up with only one half of the pair.
+func (s *RevaluationService) applyRevaluationPost(actor auth.Principal, req *revaluation.PostRequest, revaluationDto *dtos.RevaluationDto) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		txVoucherService := &VoucherService{}
+		txVoucherService.InitService(tx)
+		txVoucherService = txVoucherService.withoutApprovalRules().withRevaluationLines().withArchivedSLs()
+
+		revaluationVoucher, err := txVoucherService.CreateVoucher(actor, &voucher.InsertRequest{
+			Number:       req.Number,
+			SequenceCode: req.SequenceCode,
+			Date:         revaluationDto.Date,
+			VoucherItems: s.buildRevaluationItems(req, revaluationDto),
+		})
+		if err != nil {
+			return err
+		}
+
+		reversalVoucher, err := txVoucherService.ReverseVoucher(actor, &voucher.ReverseRequest{
+			ID:           revaluationVoucher.ID,
+			Version:      revaluationVoucher.RowVersion,
+			Number:       req.ReversalNumber,
+			SequenceCode: req.SequenceCode,
+			Date:         revaluationDto.ReversalDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		revaluationDto.VoucherID = revaluationVoucher.ID
+		revaluationDto.ReversalVoucherID = reversalVoucher.ID
+		return nil
+	})
+}
+
+func (s *RevaluationService) buildRevaluationItems(req *revaluation.PostRequest, revaluationDto *dtos.RevaluationDto) []voucher.VoucherItemInsertDetail {
+	items := make([]voucher.VoucherItemInsertDetail, 0, len(revaluationDto.Lines)+2)
+	for _, line := range revaluationDto.Lines {
+		item := voucher.VoucherItemInsertDetail{
+			SLID: line.SLID,
+			CurrencyDetail: voucher.CurrencyDetail{
+				CurrencyCode: line.CurrencyCode,
+				ExchangeRate: line.ClosingRate,
+			},
+		}
+		if line.DLID != 0 {
+			dlID := line.DLID
+			item.DLID = &dlID
+		}
+		if line.Difference > 0 {
+			item.Debit = line.Difference
+		} else {
+			item.Credit = -line.Difference
+		}
+		items = append(items, item)
+	}
+
+	if revaluationDto.TotalGain > 0 {
+		items = append(items, voucher.VoucherItemInsertDetail{SLID: req.GainSLID, DLID: req.GainDLID, Credit: revaluationDto.TotalGain})
+	}
+	if revaluationDto.TotalLoss > 0 {
+		items = append(items, voucher.VoucherItemInsertDetail{SLID: req.LossSLID, DLID: req.LossDLID, Debit: revaluationDto.TotalLoss})
+	}
+	return items
+}
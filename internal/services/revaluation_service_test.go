@@ -0,0 +1,138 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/currency"
+	"accountingsystem/internal/requests/revaluation"
+	"accountingsystem/internal/requests/voucher"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createForeignCurrencyPosition(currencyCode string, date time.Time, foreignDebit money.Amount, rate string, functionalDebit money.Amount) (*dtos.SLDto, error) {
+	bankSL, err := createRandomSL(false)
+	if err != nil {
+		return nil, err
+	}
+	items, err := createRandomBalancedItems(functionalDebit)
+	if err != nil {
+		return nil, err
+	}
+	items[0].SLID = bankSL.ID
+	items[0].Debit = 0
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: currencyCode, ForeignDebit: foreignDebit, ExchangeRate: rate}
+
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		Date:         date,
+		VoucherItems: items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bankSL, nil
+}
+
+func Test_PreviewRevaluation_ReturnsGain_WithHigherClosingRate(t *testing.T) {
+	closingDate := time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)
+	createdCurrency, err := createRandomCurrencyWithRate(closingDate, "55")
+	require.Nil(t, err)
+	bankSL, err := createForeignCurrencyPosition(createdCurrency.Code, closingDate.AddDate(0, 0, -10), 100, "50", 5000)
+	require.Nil(t, err)
+
+	preview, err := revaluationService.PreviewRevaluation(testAdmin, &revaluation.PreviewRequest{Date: closingDate, CurrencyCode: &createdCurrency.Code})
+
+	require.Nil(t, err)
+	require.Len(t, preview.Lines, 1)
+	assert.Equal(t, bankSL.ID, preview.Lines[0].SLID)
+	assert.Equal(t, money.Amount(100), preview.Lines[0].ForeignBalance)
+	assert.Equal(t, money.Amount(5000), preview.Lines[0].BookedBalance)
+	assert.Equal(t, money.Amount(5500), preview.Lines[0].RevaluedBalance)
+	assert.Equal(t, money.Amount(500), preview.Lines[0].Difference)
+	assert.Equal(t, money.Amount(500), preview.TotalGain)
+	assert.Equal(t, money.Amount(0), preview.TotalLoss)
+	assert.True(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC).Equal(preview.ReversalDate))
+}
+
+func Test_PostRevaluation_PostsVoucherAndReversal(t *testing.T) {
+	closingDate := time.Date(2023, 7, 31, 0, 0, 0, 0, time.UTC)
+	createdCurrency, err := createRandomCurrencyWithRate(closingDate, "45")
+	require.Nil(t, err)
+	bankSL, err := createForeignCurrencyPosition(createdCurrency.Code, closingDate.AddDate(0, 0, -3), 100, "50", 5000)
+	require.Nil(t, err)
+	gainSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	lossSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	posted, err := revaluationService.PostRevaluation(testAdmin, &revaluation.PostRequest{
+		Date:           closingDate,
+		CurrencyCode:   &createdCurrency.Code,
+		Number:         generateRandomString(20),
+		ReversalNumber: generateRandomString(20),
+		GainSLID:       gainSL.ID,
+		LossSLID:       lossSL.ID,
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, money.Amount(500), posted.TotalLoss)
+	revaluationVoucher, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: posted.VoucherID})
+	require.Nil(t, err)
+	require.Len(t, revaluationVoucher.VoucherItems, 2)
+	assert.Equal(t, bankSL.ID, revaluationVoucher.VoucherItems[0].SLID)
+	assert.Equal(t, money.Amount(500), revaluationVoucher.VoucherItems[0].Credit)
+	assert.Equal(t, lossSL.ID, revaluationVoucher.VoucherItems[1].SLID)
+	assert.Equal(t, money.Amount(500), revaluationVoucher.VoucherItems[1].Debit)
+	reversalVoucher, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: posted.ReversalVoucherID})
+	require.Nil(t, err)
+	assert.Equal(t, posted.VoucherID, reversalVoucher.ReversalOfID)
+	assert.Equal(t, "2023-08-01", reversalVoucher.Date.Format("2006-01-02"))
+
+	preview, err := revaluationService.PreviewRevaluation(testAdmin, &revaluation.PreviewRequest{Date: closingDate, CurrencyCode: &createdCurrency.Code})
+	require.Nil(t, err)
+	assert.Empty(t, preview.Lines)
+}
+
+func Test_PostRevaluation_ReturnsErrNothingToRevalue_WithUnchangedRate(t *testing.T) {
+	closingDate := time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC)
+	createdCurrency, err := createRandomCurrencyWithRate(closingDate, "50")
+	require.Nil(t, err)
+	_, err = createForeignCurrencyPosition(createdCurrency.Code, closingDate, 100, "50", 5000)
+	require.Nil(t, err)
+	gainSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	posted, err := revaluationService.PostRevaluation(testAdmin, &revaluation.PostRequest{
+		Date:           closingDate,
+		CurrencyCode:   &createdCurrency.Code,
+		Number:         generateRandomString(20),
+		ReversalNumber: generateRandomString(20),
+		GainSLID:       gainSL.ID,
+		LossSLID:       gainSL.ID,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrNothingToRevalue)
+	assert.Nil(t, posted)
+}
+
+func Test_PreviewRevaluation_ReturnsErrExchangeRateNotFound_WithoutClosingRate(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	positionDate := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+	_, err = createForeignCurrencyPosition(createdCurrency.Code, positionDate, 10, "50", 500)
+	require.Nil(t, err)
+	_, err = currencyService.SetExchangeRate(testAdmin, &currency.SetRateRequest{CurrencyCode: createdCurrency.Code, Date: positionDate.AddDate(0, 1, 0), Rate: "51"})
+	require.Nil(t, err)
+
+	preview, err := revaluationService.PreviewRevaluation(testAdmin, &revaluation.PreviewRequest{Date: positionDate, CurrencyCode: &createdCurrency.Code})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrExchangeRateNotFound)
+	assert.Nil(t, preview)
+}
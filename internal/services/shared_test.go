@@ -3,9 +3,17 @@ package services
 import (
 	"accountingsystem/configs"
 	"accountingsystem/db"
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
+	"accountingsystem/internal/codetemplate"
 	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/idempotency"
+	"accountingsystem/internal/money"
 	"accountingsystem/internal/requests/dl"
 	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/textrule"
+	"accountingsystem/internal/txretry"
 	"log"
 	"math/rand"
 	"os"
@@ -20,6 +28,22 @@ var seededRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
 var dlService *DLService
 var slService *SLService
 var voucherService *VoucherService
+var reportService *ReportService
+var numberSequenceService *NumberSequenceService
+var voucherTemplateService *VoucherTemplateService
+var currencyService *CurrencyService
+var revaluationService *RevaluationService
+var normalizationService *NormalizationService
+var userService *UserService
+var tenantService *TenantService
+var approvalRuleService *ApprovalRuleService
+var unitOfWorkService *UnitOfWorkService
+var outboxService *OutboxService
+var webhookService *WebhookService
+var postingStreamService *PostingStreamService
+var idempotencyService *IdempotencyService
+
+var testAdmin = auth.Principal{Username: "test-admin", Role: auth.Admin, TenantID: auth.DefaultTenantID}
 
 func TestMain(m *testing.M) {
 	err := configs.InitConfig("../../.env.test")
@@ -27,6 +51,25 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Failed to load test configuration: %v", err)
 	}
 
+	if err := money.Init(); err != nil {
+		log.Fatalf("Invalid MONEY_MINOR_UNITS: %v", err)
+	}
+	if err := calendar.Init(); err != nil {
+		log.Fatalf("Invalid CALENDAR: %v", err)
+	}
+	if err := textrule.Init(); err != nil {
+		log.Fatalf("Invalid text rule configuration: %v", err)
+	}
+	if err := codetemplate.Init(); err != nil {
+		log.Fatalf("Invalid code template configuration: %v", err)
+	}
+	if err := txretry.Init(); err != nil {
+		log.Fatalf("Invalid transaction retry configuration: %v", err)
+	}
+	if err := idempotency.Init(); err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_RETENTION: %v", err)
+	}
+
 	theDB, err := db.Init()
 	if err != nil {
 		log.Fatalf("Failed to connect to the test database: %v", err)
@@ -41,10 +84,47 @@ func InitServices(theDB *gorm.DB) {
 	dlService = &DLService{}
 	slService = &SLService{}
 	voucherService = &VoucherService{}
+	reportService = &ReportService{}
+	numberSequenceService = &NumberSequenceService{}
+	voucherTemplateService = &VoucherTemplateService{}
+	currencyService = &CurrencyService{}
+	revaluationService = &RevaluationService{}
+	normalizationService = &NormalizationService{}
+	userService = &UserService{}
+	tenantService = &TenantService{}
+	approvalRuleService = &ApprovalRuleService{}
+	unitOfWorkService = &UnitOfWorkService{}
+	outboxService = &OutboxService{}
+	webhookService = &WebhookService{}
+	postingStreamService = &PostingStreamService{}
+	idempotencyService = &IdempotencyService{}
 
 	dlService.InitService(theDB)
 	slService.InitService(theDB)
 	voucherService.InitService(theDB)
+	reportService.InitService(theDB)
+	numberSequenceService.InitService(theDB)
+	voucherTemplateService.InitService(theDB)
+	currencyService.InitService(theDB)
+	revaluationService.InitService(theDB)
+	normalizationService.InitService(theDB)
+	userService.InitService(theDB)
+	tenantService.InitService(theDB)
+	approvalRuleService.InitService(theDB)
+	unitOfWorkService.InitService(theDB)
+	outboxService.InitService(theDB)
+	webhookService.InitService(theDB)
+	postingStreamService.InitService(theDB)
+	idempotencyService.InitService(theDB)
+}
+
+func generateRandomDigits(length int) string {
+	const charset = "0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = charset[seededRand.Intn(len(charset))]
+	}
+	return string(b)
 }
 
 func generateRandomString(length int) string {
@@ -68,7 +148,7 @@ func createRandomSL(hasDL bool) (*dtos.SLDto, error) {
 		Title: randomTitle,
 		HasDL: hasDL,
 	}
-	return slService.CreateSL(req)
+	return slService.CreateSL(testAdmin, req)
 }
 
 func createRandomDL() (*dtos.DLDto, error) {
@@ -78,5 +158,13 @@ func createRandomDL() (*dtos.DLDto, error) {
 		Code:  randomCode,
 		Title: randomTitle,
 	}
-	return dlService.CreateDL(req)
+	return dlService.CreateDL(testAdmin, req)
+}
+
+func createRandomUser(role auth.Role) (*dtos.UserDto, error) {
+	req := &user.InsertRequest{
+		Username: "user" + generateRandomString(20),
+		Role:     role,
+	}
+	return userService.CreateUser(testAdmin, req)
 }
@@ -1,30 +1,92 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
 	"accountingsystem/internal/mappers"
 	"accountingsystem/internal/requests/sl"
+	"context"
+	"errors"
 	"log"
 
 	"gorm.io/gorm"
 )
 
 type SLService struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID int
 }
 
 func (s *SLService) InitService(db *gorm.DB) {
 	s.db = db
 }
 
-func (s *SLService) CreateSL(req *sl.InsertRequest) (*dtos.SLDto, error) {
-	if err := s.validateSLInsertRequest(req); err != nil {
+func (s *SLService) forTenant(tenantID int) *SLService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+// withContext returns a copy of the service whose queries run under ctx, so
+// they are abandoned once it is canceled or its deadline passes.
+func (s *SLService) withContext(ctx context.Context) *SLService {
+	scoped := *s
+	scoped.db = s.db.WithContext(ctx)
+	return &scoped
+}
+
+func (s *SLService) CreateSL(actor auth.Principal, req *sl.InsertRequest) (*dtos.SLDto, error) {
+	return s.CreateSLContext(context.Background(), actor, req)
+}
+
+func (s *SLService) CreateSLContext(ctx context.Context, actor auth.Principal, req *sl.InsertRequest) (*dtos.SLDto, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionCreate); err != nil {
 		return nil, err
 	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
 
-	slDto, err := s.applySLCreation(req)
+	creation, err := s.validateSLIdempotencyKey(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	replayed, err := s.replaySLCreation(creation)
+	if errors.Is(err, constants.ErrIdempotencyKeyReused) {
+		return nil, err
+	}
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while replaying SL creation: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+	if replayed != nil {
+		return replayed, nil
+	}
+
+	if err := s.validateSLInsertRequest(req); err != nil {
+		// A concurrent request with the same key may have created the SL
+		// since the key was looked up.
+		if errors.Is(err, constants.ErrCodeAlreadyExists) || errors.Is(err, constants.ErrTitleAlreadyExists) {
+			if replayed, replayErr := s.replaySLCreation(creation); replayErr == nil && replayed != nil {
+				return replayed, nil
+			}
+		}
+		return nil, contextError(ctx, err)
+	}
+
+	slDto, err := s.applySLCreation(req, creation)
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		slDto, err = s.replaySLCreation(creation)
+	}
+	if errors.Is(err, constants.ErrIdempotencyKeyReused) {
+		return nil, err
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while creating SL: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
@@ -32,14 +94,26 @@ func (s *SLService) CreateSL(req *sl.InsertRequest) (*dtos.SLDto, error) {
 	return slDto, nil
 }
 
-func (s *SLService) UpdateSL(req *sl.UpdateRequest) (*dtos.SLDto, error) {
+func (s *SLService) UpdateSL(actor auth.Principal, req *sl.UpdateRequest) (*dtos.SLDto, error) {
+	return s.UpdateSLContext(context.Background(), actor, req)
+}
+
+func (s *SLService) UpdateSLContext(ctx context.Context, actor auth.Principal, req *sl.UpdateRequest) (*dtos.SLDto, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetSL, err := s.validateSLUpdateRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 
 	slDto, err := s.applySLUpdate(req, targetSL)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while updating SL: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
@@ -47,13 +121,25 @@ func (s *SLService) UpdateSL(req *sl.UpdateRequest) (*dtos.SLDto, error) {
 	return slDto, nil
 }
 
-func (s *SLService) DeleteSL(req *sl.DeleteRequest) error {
+func (s *SLService) DeleteSL(actor auth.Principal, req *sl.DeleteRequest) error {
+	return s.DeleteSLContext(context.Background(), actor, req)
+}
+
+func (s *SLService) DeleteSLContext(ctx context.Context, actor auth.Principal, req *sl.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetSL, err := s.validateSLDeleteRequest(req)
 	if err != nil {
-		return err
+		return contextError(ctx, err)
 	}
 
 	if err := s.applySLDeletion(targetSL); err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
 		log.Printf("unexpected error while deleting SL: %v", err)
 		return constants.ErrUnexpectedError
 	}
@@ -61,11 +147,121 @@ func (s *SLService) DeleteSL(req *sl.DeleteRequest) error {
 	return nil
 }
 
-func (s *SLService) GetSL(req *sl.GetRequest) (*dtos.SLDto, error) {
+func (s *SLService) ArchiveSL(actor auth.Principal, req *sl.ArchiveRequest) (*dtos.SLDto, error) {
+	return s.ArchiveSLContext(context.Background(), actor, req)
+}
+
+func (s *SLService) ArchiveSLContext(ctx context.Context, actor auth.Principal, req *sl.ArchiveRequest) (*dtos.SLDto, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetSL, err := s.validateSLArchiveRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	slDto, err := s.applySLArchive(targetSL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while archiving SL: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return slDto, nil
+}
+
+func (s *SLService) GetSL(actor auth.Principal, req *sl.GetRequest) (*dtos.SLDto, error) {
+	return s.GetSLContext(context.Background(), actor, req)
+}
+
+func (s *SLService) GetSLContext(ctx context.Context, actor auth.Principal, req *sl.GetRequest) (*dtos.SLDto, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetSL, err := s.validateSLGetRequest(req)
 	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	return mappers.ToSlDto(targetSL), nil
+}
+
+func (s *SLService) GetSLByCode(actor auth.Principal, req *sl.GetByCodeRequest) (*dtos.SLDto, error) {
+	return s.GetSLByCodeContext(context.Background(), actor, req)
+}
+
+func (s *SLService) GetSLByCodeContext(ctx context.Context, actor auth.Principal, req *sl.GetByCodeRequest) (*dtos.SLDto, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionRead); err != nil {
 		return nil, err
 	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetSL, err := s.validateSLGetByCodeRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
 
 	return mappers.ToSlDto(targetSL), nil
 }
+
+func (s *SLService) GetSLs(actor auth.Principal, req *sl.GetManyRequest) ([]dtos.SLDto, error) {
+	return s.GetSLsContext(context.Background(), actor, req)
+}
+
+func (s *SLService) GetSLsContext(ctx context.Context, actor auth.Principal, req *sl.GetManyRequest) ([]dtos.SLDto, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	if err := s.validateSLGetManyRequest(req); err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	slDtos, err := s.applySLsGet(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting SLs: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return slDtos, nil
+}
+
+func (s *SLService) NextSLCode(actor auth.Principal, req *sl.NextCodeRequest) (string, error) {
+	return s.NextSLCodeContext(context.Background(), actor, req)
+}
+
+func (s *SLService) NextSLCodeContext(ctx context.Context, actor auth.Principal, req *sl.NextCodeRequest) (string, error) {
+	if err := auth.Authorize(actor, auth.EntitySL, auth.ActionCreate); err != nil {
+		return "", err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	template, err := s.validateSLNextCodeRequest(req)
+	if err != nil {
+		return "", contextError(ctx, err)
+	}
+
+	code, err := s.applySLNextCode(req, template)
+	if errors.Is(err, constants.ErrNoFreeCode) {
+		return "", contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", contextError(ctx, err)
+		}
+		log.Printf("unexpected error while allocating next SL code: %v", err)
+		return "", constants.ErrUnexpectedError
+	}
+
+	return code, nil
+}
@@ -1,54 +1,99 @@
 package services
 
 import (
+	"accountingsystem/internal/codetemplate"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/idempotency"
 	"accountingsystem/internal/mappers"
 	"accountingsystem/internal/models"
+	"accountingsystem/internal/normalize"
 	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/textrule"
+	"database/sql"
 	"errors"
+	"time"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
 )
 
-func (s *SLService) applySLCreation(req *sl.InsertRequest) (*dtos.SLDto, error) {
+func (s *SLService) applySLCreation(req *sl.InsertRequest, creation *idempotentCreation) (*dtos.SLDto, error) {
 	sl := models.SL{
+		TenantID:   s.tenantID,
 		Code:       req.Code,
 		Title:      req.Title,
 		HasDL:      req.HasDL,
 		RowVersion: 0,
 	}
 
-	if err := s.db.Create(&sl).Error; err != nil {
+	var slDto *dtos.SLDto
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := creation.claim(tx); err != nil {
+			return err
+		}
+		if err := tx.Create(&sl).Error; err != nil {
+			return err
+		}
+		slDto = mappers.ToSlDto(&sl)
+		if err := recordEvent(tx, s.tenantID, events.SLCreated, events.AggregateSL, sl.ID, slDto); err != nil {
+			return err
+		}
+		return creation.record(tx, slDto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return mappers.ToSlDto(&sl), nil
+	return slDto, nil
+}
+
+func (s *SLService) validateSLIdempotencyKey(req *sl.InsertRequest) (*idempotentCreation, error) {
+	payload := *req
+	payload.IdempotencyKey = ""
+	return newIdempotentCreation(s.db, s.tenantID, idempotency.ScopeSL, req.IdempotencyKey, payload)
+}
+
+// replaySLCreation returns the SL recorded for the idempotency key of the
+// request, or nil when there is none.
+func (s *SLService) replaySLCreation(creation *idempotentCreation) (*dtos.SLDto, error) {
+	var slDto dtos.SLDto
+	found, err := creation.replay(&slDto)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &slDto, nil
 }
 
 func (s *SLService) validateSLInsertRequest(req *sl.InsertRequest) error {
-	if err := s.validateCodeAndTitleLength(req.Code, req.Title); err != nil {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := s.validateCodeAndTitleRules(req.Code, req.Title); err != nil {
 		return err
 	}
 	if err := s.validateCodeAndTitleUnique(req.Code, req.Title); err != nil {
 		return err
 	}
+	if err := s.validateCodeTemplate(req.Code); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (s *SLService) validateCodeAndTitleLength(code string, title string) error {
-	if code == "" || len(code) > 64 {
-		return constants.ErrCodeEmptyOrTooLong
+func (s *SLService) validateCodeAndTitleRules(code string, title string) error {
+	if err := textrule.SLCode.Validate(code); err != nil {
+		return err
 	}
-	if title == "" || len(title) > 64 {
-		return constants.ErrTitleEmptyOrTooLong
+	if err := textrule.SLTitle.Validate(title); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (s *SLService) validateCodeAndTitleUnique(code string, title string) error {
 	var existingSL models.SL
-	if err := s.db.Where("code = ? OR title = ?", code, title).First(&existingSL).Error; err == nil {
+	if err := s.db.Where("tenant_id = ? AND (code = ? OR title = ?)", s.tenantID, code, title).First(&existingSL).Error; err == nil {
 		if existingSL.Code == code {
 			return constants.ErrCodeAlreadyExists
 		}
@@ -67,15 +112,24 @@ func (s *SLService) applySLUpdate(req *sl.UpdateRequest, targetSL *models.SL) (*
 	targetSL.HasDL = req.HasDL
 	targetSL.RowVersion++
 
-	if err := s.db.Save(targetSL).Error; err != nil {
+	slDto := mappers.ToSlDto(targetSL)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(targetSL).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, s.tenantID, events.SLUpdated, events.AggregateSL, targetSL.ID, slDto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return mappers.ToSlDto(targetSL), nil
+	return slDto, nil
 }
 
 func (s *SLService) validateSLUpdateRequest(req *sl.UpdateRequest) (*models.SL, error) {
-	if err := s.validateCodeAndTitleLength(req.Code, req.Title); err != nil {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := s.validateCodeAndTitleRules(req.Code, req.Title); err != nil {
 		return nil, err
 	}
 	targetSL, err := s.validateSLExists(req.ID)
@@ -91,12 +145,20 @@ func (s *SLService) validateSLUpdateRequest(req *sl.UpdateRequest) (*models.SL,
 	if err := s.validateCodeAndTitleUniqueWithDifferentId(req.Code, req.Title, req.ID); err != nil {
 		return nil, err
 	}
+	if err := s.validateCodeTemplate(req.Code); err != nil {
+		return nil, err
+	}
+	if req.Code != targetSL.Code {
+		if err := s.validateCodeHasNoChildren(targetSL.Code); err != nil {
+			return nil, err
+		}
+	}
 	return targetSL, nil
 }
 
 func (s *SLService) validateSLExists(id int) (*models.SL, error) {
 	var targetSL models.SL
-	if err := s.db.Where("id = ?", id).First(&targetSL).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetSL).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, constants.ErrSLNotFound
 		}
@@ -117,12 +179,20 @@ func (s *SLService) validateSLHasNoReferences(id int) error {
 	if err := s.db.Where("sl_id = ?", id).First(&VoucherItemRefrencingThisSL).Error; err == nil {
 		return constants.ErrThereIsRefrenceToSL
 	}
+	var TemplateItemRefrencingThisSL models.VoucherTemplateItem
+	if err := s.db.Where("sl_id = ?", id).First(&TemplateItemRefrencingThisSL).Error; err == nil {
+		return constants.ErrThereIsRefrenceToSL
+	}
+	var ApprovalRuleRefrencingThisSL models.ApprovalRule
+	if err := s.db.Where("sl_id = ?", id).First(&ApprovalRuleRefrencingThisSL).Error; err == nil {
+		return constants.ErrThereIsRefrenceToSL
+	}
 	return nil
 }
 
 func (s *SLService) validateCodeAndTitleUniqueWithDifferentId(code string, title string, id int) error {
 	var existingSL models.SL
-	if err := s.db.Where("(code = ? OR title = ?) AND id != ?", code, title, id).First(&existingSL).Error; err == nil {
+	if err := s.db.Where("tenant_id = ? AND (code = ? OR title = ?) AND id != ?", s.tenantID, code, title, id).First(&existingSL).Error; err == nil {
 		if existingSL.Code == code {
 			return constants.ErrCodeAlreadyExists
 		}
@@ -136,10 +206,12 @@ func (s *SLService) validateCodeAndTitleUniqueWithDifferentId(code string, title
 }
 
 func (s *SLService) applySLDeletion(targetSL *models.SL) error {
-	if err := s.db.Delete(&targetSL).Error; err != nil {
-		return err
-	}
-	return nil
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&targetSL).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, s.tenantID, events.SLDeleted, events.AggregateSL, targetSL.ID, mappers.ToSlDto(targetSL))
+	})
 }
 
 func (s *SLService) validateSLDeleteRequest(req *sl.DeleteRequest) (*models.SL, error) {
@@ -153,6 +225,41 @@ func (s *SLService) validateSLDeleteRequest(req *sl.DeleteRequest) (*models.SL,
 	if err := s.validateSLHasNoReferences(req.ID); err != nil {
 		return nil, err
 	}
+	if err := s.validateCodeHasNoChildren(targetSL.Code); err != nil {
+		return nil, err
+	}
+	return targetSL, nil
+}
+
+func (s *SLService) applySLArchive(targetSL *models.SL) (*dtos.SLDto, error) {
+	targetSL.ArchivedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	targetSL.RowVersion++
+
+	slDto := mappers.ToSlDto(targetSL)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(targetSL).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, s.tenantID, events.SLArchived, events.AggregateSL, targetSL.ID, slDto)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slDto, nil
+}
+
+func (s *SLService) validateSLArchiveRequest(req *sl.ArchiveRequest) (*models.SL, error) {
+	targetSL, err := s.validateSLExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetSL.RowVersion); err != nil {
+		return nil, err
+	}
+	if targetSL.ArchivedAt.Valid {
+		return nil, constants.ErrSLArchived
+	}
 	return targetSL, nil
 }
 
@@ -163,3 +270,120 @@ func (s *SLService) validateSLGetRequest(req *sl.GetRequest) (*models.SL, error)
 	}
 	return targetSL, nil
 }
+
+func (s *SLService) validateSLExistsByCode(code string) (*models.SL, error) {
+	var targetSL models.SL
+	if err := s.db.Where("tenant_id = ? AND code = ?", s.tenantID, normalize.Text(code)).First(&targetSL).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrSLNotFound
+		}
+		return nil, err
+	}
+	return &targetSL, nil
+}
+
+func (s *SLService) validateSLGetByCodeRequest(req *sl.GetByCodeRequest) (*models.SL, error) {
+	targetSL, err := s.validateSLExistsByCode(req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return targetSL, nil
+}
+
+func (s *SLService) validateSLGetManyRequest(req *sl.GetManyRequest) error {
+	if len(req.IDs) > constants.MaxLookupIDs {
+		return constants.ErrTooManyIDs
+	}
+	return nil
+}
+
+func (s *SLService) applySLsGet(req *sl.GetManyRequest) ([]dtos.SLDto, error) {
+	slDtos := []dtos.SLDto{}
+	if len(req.IDs) == 0 {
+		return slDtos, nil
+	}
+
+	var sls []models.SL
+	if err := s.db.Where("tenant_id = ? AND id IN ?", s.tenantID, req.IDs).Order("id").Find(&sls).Error; err != nil {
+		return nil, err
+	}
+	for i := range sls {
+		slDtos = append(slDtos, *mappers.ToSlDto(&sls[i]))
+	}
+	return slDtos, nil
+}
+
+func (s *SLService) validateCodeTemplate(code string) error {
+	template := codetemplate.SL.Template()
+	if err := template.Validate(code); err != nil {
+		return err
+	}
+	parentCode := template.ParentOf(code)
+	if !template.RequireParent || parentCode == "" {
+		return nil
+	}
+	if _, err := s.validateSLExistsByCode(parentCode); err != nil {
+		if errors.Is(err, constants.ErrSLNotFound) {
+			return constants.ErrParentCodeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *SLService) validateCodeHasNoChildren(code string) error {
+	if !codetemplate.SL.Template().IsHierarchical() {
+		return nil
+	}
+	var child models.SL
+	length := utf8.RuneCountInString(code)
+	err := s.db.Where("tenant_id = ? AND char_length(code) > ? AND left(code, ?) = ?", s.tenantID, length, length, code).First(&child).Error
+	if err == nil {
+		return constants.ErrCodeHasChildren
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *SLService) validateSLNextCodeRequest(req *sl.NextCodeRequest) (codetemplate.Template, error) {
+	template := codetemplate.SL.Template()
+	if !template.IsHierarchical() {
+		return codetemplate.Template{}, constants.ErrCodeTemplateNotConfigured
+	}
+	req.ParentCode = normalize.Text(req.ParentCode)
+	if req.ParentCode == "" {
+		return template, nil
+	}
+	if err := template.Validate(req.ParentCode); err != nil {
+		return codetemplate.Template{}, err
+	}
+	if _, err := s.validateSLExistsByCode(req.ParentCode); err != nil {
+		if errors.Is(err, constants.ErrSLNotFound) {
+			return codetemplate.Template{}, constants.ErrParentCodeNotFound
+		}
+		return codetemplate.Template{}, err
+	}
+	return template, nil
+}
+
+func (s *SLService) applySLNextCode(req *sl.NextCodeRequest, template codetemplate.Template) (string, error) {
+	level := 1
+	if req.ParentCode != "" {
+		level = template.Level(req.ParentCode) + 1
+	}
+	if level > len(template.Segments) {
+		return "", constants.ErrNoFreeCode
+	}
+
+	var siblings []string
+	parentLength := utf8.RuneCountInString(req.ParentCode)
+	if err := s.db.Model(&models.SL{}).
+		Where("tenant_id = ? AND char_length(code) = ? AND left(code, ?) = ?", s.tenantID, template.LengthAt(level), parentLength, req.ParentCode).
+		Pluck("code", &siblings).Error; err != nil {
+		return "", err
+	}
+
+	return template.NextCode(req.ParentCode, siblings)
+}
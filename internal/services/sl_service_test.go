@@ -1,9 +1,15 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/codetemplate"
 	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
 	"accountingsystem/internal/requests/sl"
 	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/textrule"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,7 +26,7 @@ func Test_CreateSL_Succeeds_WithValidRequest(t *testing.T) {
 		HasDL: true,
 	}
 
-	sl, err := slService.CreateSL(req)
+	sl, err := slService.CreateSL(testAdmin, req)
 
 	require.Nil(t, err)
 	assert.Equal(t, sl.Code, req.Code)
@@ -37,7 +43,7 @@ func Test_CreateSL_ReturnsErrCodeEmptyOrTooLong_WithEmptyCode(t *testing.T) {
 		HasDL: true,
 	}
 
-	sl, err := slService.CreateSL(req)
+	sl, err := slService.CreateSL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -54,7 +60,7 @@ func Test_CreateSL_ReturnsErrCodeEmptyOrTooLong_WithTooLongCode(t *testing.T) {
 		HasDL: false,
 	}
 
-	sl, err := slService.CreateSL(req)
+	sl, err := slService.CreateSL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -70,7 +76,7 @@ func Test_CreateSL_ReturnsErrTitleEmptyOrTooLong_WithEmptyTitle(t *testing.T) {
 		HasDL: false,
 	}
 
-	sl, err := slService.CreateSL(req)
+	sl, err := slService.CreateSL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -87,7 +93,7 @@ func Test_CreateSL_ReturnsErrTitleEmptyOrTooLong_WithTooLongTitle(t *testing.T)
 		HasDL: true,
 	}
 
-	sl, err := slService.CreateSL(req)
+	sl, err := slService.CreateSL(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -104,7 +110,7 @@ func Test_CreateSL_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
 		HasDL: true,
 	}
 
-	sl, err := slService.CreateSL(duplicateReq)
+	sl, err := slService.CreateSL(testAdmin, duplicateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
@@ -121,7 +127,7 @@ func Test_CreateSL_ReturnsErrTitleAlreadyExists_WithExistingTitle(t *testing.T)
 		HasDL: true,
 	}
 
-	sl, err := slService.CreateSL(duplicateReq)
+	sl, err := slService.CreateSL(testAdmin, duplicateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleAlreadyExists)
@@ -142,7 +148,7 @@ func Test_UpdateSL_Succeeds_WithValidRequest(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.Nil(t, err)
 	assert.Equal(t, updatedSL.Code, updateReq.Code)
@@ -163,7 +169,7 @@ func Test_UpdateSL_ReturnsErrCodeEmptyOrTooLong_WithEmptyCode(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -184,7 +190,7 @@ func Test_UpdateSL_ReturnsErrCodeEmptyOrTooLong_WithTooLongCode(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeEmptyOrTooLong)
@@ -204,7 +210,7 @@ func Test_UpdateSL_ReturnsErrTitleEmptyOrTooLong_WithEmptyTitle(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -225,7 +231,7 @@ func Test_UpdateSL_ReturnsErrTitleEmptyOrTooLong_WithTooLongTitle(t *testing.T)
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleEmptyOrTooLong)
@@ -243,7 +249,7 @@ func Test_UpdateSL_ReturnsErrSLNotFound_WithNonExistingID(t *testing.T) {
 		HasDL: true,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
@@ -265,7 +271,7 @@ func Test_UpdateSL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	_, err = slService.UpdateSL(updateReq1)
+	_, err = slService.UpdateSL(testAdmin, updateReq1)
 	require.Nil(t, err)
 
 	newRandomCode2 := "SL" + generateRandomString(20)
@@ -279,7 +285,7 @@ func Test_UpdateSL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq2)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq2)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
@@ -303,7 +309,7 @@ func Test_UpdateSL_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
@@ -327,7 +333,7 @@ func Test_UpdateSL_ReturnsErrTitleAlreadyExists_WithExistingTitle(t *testing.T)
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrTitleAlreadyExists)
@@ -359,7 +365,7 @@ func Test_UpdateSL_ReturnsErrThereIsReferenceToSL_WithReferencedSL(t *testing.T)
 		Number:       generateRandomString(20),
 		VoucherItems: voucherItems,
 	}
-	_, err = voucherService.CreateVoucher(voucherReq)
+	_, err = voucherService.CreateVoucher(testAdmin, voucherReq)
 	require.Nil(t, err)
 
 	updateReq := &sl.UpdateRequest{
@@ -370,7 +376,7 @@ func Test_UpdateSL_ReturnsErrThereIsReferenceToSL_WithReferencedSL(t *testing.T)
 		Version: createdSL.RowVersion,
 	}
 
-	updatedSL, err := slService.UpdateSL(updateReq)
+	updatedSL, err := slService.UpdateSL(testAdmin, updateReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrThereIsRefrenceToSL)
@@ -386,7 +392,7 @@ func Test_DeleteSL_Succeeds_WithValidRequest(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	err = slService.DeleteSL(deleteReq)
+	err = slService.DeleteSL(testAdmin, deleteReq)
 
 	require.Nil(t, err)
 }
@@ -396,7 +402,7 @@ func Test_DeleteSL_ReturnsErrSLNotFound_WithNonExistingID(t *testing.T) {
 		ID: generateRandomInt64(),
 	}
 
-	err := slService.DeleteSL(deleteReq)
+	err := slService.DeleteSL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
@@ -411,10 +417,10 @@ func Test_DeleteSL_ReturnsErrSLNotFound_WithDeletedID(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	err = slService.DeleteSL(deleteReq)
+	err = slService.DeleteSL(testAdmin, deleteReq)
 	require.Nil(t, err)
 
-	err = slService.DeleteSL(deleteReq)
+	err = slService.DeleteSL(testAdmin, deleteReq)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
 }
@@ -434,7 +440,7 @@ func Test_DeleteSL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	_, err = slService.UpdateSL(updateReq)
+	_, err = slService.UpdateSL(testAdmin, updateReq)
 	require.Nil(t, err)
 
 	deleteReq := &sl.DeleteRequest{
@@ -442,7 +448,7 @@ func Test_DeleteSL_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
 		Version: createdSL.RowVersion,
 	}
 
-	err = slService.DeleteSL(deleteReq)
+	err = slService.DeleteSL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
@@ -456,7 +462,7 @@ func Test_GetSL_Succeeds_WithValidID(t *testing.T) {
 		ID: createdSL.ID,
 	}
 
-	sl, err := slService.GetSL(getReq)
+	sl, err := slService.GetSL(testAdmin, getReq)
 
 	require.Nil(t, err)
 	assert.Equal(t, sl.ID, createdSL.ID)
@@ -470,13 +476,28 @@ func Test_GetSL_ReturnsErrSLNotFound_WithNonExistingID(t *testing.T) {
 		ID: generateRandomInt64(),
 	}
 
-	sl, err := slService.GetSL(getReq)
+	sl, err := slService.GetSL(testAdmin, getReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
 	assert.Nil(t, sl)
 }
 
+func Test_GetSLs_ReturnsExistingSLs_WithMixedIDs(t *testing.T) {
+	first, err := createRandomSL(true)
+	require.Nil(t, err)
+	second, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	slDtos, err := slService.GetSLs(testAdmin, &sl.GetManyRequest{IDs: []int{second.ID, -1, first.ID}})
+
+	require.Nil(t, err)
+	require.Len(t, slDtos, 2)
+	assert.Equal(t, first.ID, slDtos[0].ID)
+	assert.True(t, slDtos[0].HasDL)
+	assert.Equal(t, second.ID, slDtos[1].ID)
+}
+
 func Test_DeleteSL_ReturnsErrThereIsReferenceToSL_WithReferencedSL(t *testing.T) {
 	createdSL, err := createRandomSL(true)
 	require.Nil(t, err)
@@ -502,7 +523,7 @@ func Test_DeleteSL_ReturnsErrThereIsReferenceToSL_WithReferencedSL(t *testing.T)
 		Number:       generateRandomString(20),
 		VoucherItems: voucherItems,
 	}
-	_, err = voucherService.CreateVoucher(voucherReq)
+	_, err = voucherService.CreateVoucher(testAdmin, voucherReq)
 	require.Nil(t, err)
 
 	deleteReq := &sl.DeleteRequest{
@@ -510,8 +531,229 @@ func Test_DeleteSL_ReturnsErrThereIsReferenceToSL_WithReferencedSL(t *testing.T)
 		Version: createdSL.RowVersion,
 	}
 
-	err = slService.DeleteSL(deleteReq)
+	err = slService.DeleteSL(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrThereIsRefrenceToSL)
 }
+
+func Test_GetSLByCode_Succeeds_WithExistingCode(t *testing.T) {
+	createdSL, err := createRandomSL(true)
+	require.Nil(t, err)
+
+	foundSL, err := slService.GetSLByCode(testAdmin, &sl.GetByCodeRequest{Code: createdSL.Code})
+
+	require.Nil(t, err)
+	assert.Equal(t, createdSL.ID, foundSL.ID)
+	assert.Equal(t, createdSL.HasDL, foundSL.HasDL)
+}
+
+func Test_GetSLByCode_ReturnsErrSLNotFound_WithNonExistingCode(t *testing.T) {
+	foundSL, err := slService.GetSLByCode(testAdmin, &sl.GetByCodeRequest{Code: "SL" + generateRandomString(30)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLNotFound)
+	assert.Nil(t, foundSL)
+}
+
+func Test_CreateSL_ReturnsErrCodeAlreadyExists_WithPersianDigitVariantOfExistingCode(t *testing.T) {
+	suffix := generateRandomString(20)
+	_, err := slService.CreateSL(testAdmin, &sl.InsertRequest{Code: "SL42" + suffix, Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+
+	createdSL, err := slService.CreateSL(testAdmin, &sl.InsertRequest{Code: "SL۴۲" + suffix, Title: "Test" + generateRandomString(20)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
+	assert.Nil(t, createdSL)
+}
+
+func Test_CreateSL_ReturnsErrCodeFormatInvalid_WithCodeNotMatchingConfiguredPattern(t *testing.T) {
+	defaultRule := textrule.SLCode.Rule()
+	require.Nil(t, textrule.SLCode.SetRule(textrule.Rule{MinLength: 1, MaxLength: 64, Pattern: regexp.MustCompile(`^SL[0-9]+$`)}))
+	defer textrule.SLCode.SetRule(defaultRule)
+
+	createdSL, err := slService.CreateSL(testAdmin, &sl.InsertRequest{Code: "SL12a4", Title: "Test" + generateRandomString(20)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeFormatInvalid)
+	assert.Nil(t, createdSL)
+}
+
+func useHierarchicalSLCodes(t *testing.T) {
+	defaultTemplate := codetemplate.SL.Template()
+	require.Nil(t, codetemplate.SL.SetTemplate(codetemplate.Template{Segments: []int{12, 2, 2}, NumericOnly: true, RequireParent: true}))
+	t.Cleanup(func() {
+		codetemplate.SL.SetTemplate(defaultTemplate)
+	})
+}
+
+func createSLWithCode(code string) (*dtos.SLDto, error) {
+	return slService.CreateSL(testAdmin, &sl.InsertRequest{Code: code, Title: "Test" + generateRandomString(20)})
+}
+
+func Test_NextSLCode_AllocatesNextChildCode_WithExistingSiblings(t *testing.T) {
+	useHierarchicalSLCodes(t)
+	parentCode := generateRandomDigits(12)
+	_, err := createSLWithCode(parentCode)
+	require.Nil(t, err)
+
+	firstCode, err := slService.NextSLCode(testAdmin, &sl.NextCodeRequest{ParentCode: parentCode})
+	require.Nil(t, err)
+	assert.Equal(t, parentCode+"01", firstCode)
+
+	_, err = createSLWithCode(parentCode + "07")
+	require.Nil(t, err)
+	nextCode, err := slService.NextSLCode(testAdmin, &sl.NextCodeRequest{ParentCode: parentCode})
+
+	require.Nil(t, err)
+	assert.Equal(t, parentCode+"08", nextCode)
+}
+
+func Test_NextSLCode_ReturnsErrNoFreeCode_WithParentOnLastLevel(t *testing.T) {
+	useHierarchicalSLCodes(t)
+	parentCode := generateRandomDigits(12)
+	_, err := createSLWithCode(parentCode)
+	require.Nil(t, err)
+	_, err = createSLWithCode(parentCode + "01")
+	require.Nil(t, err)
+	_, err = createSLWithCode(parentCode + "0101")
+	require.Nil(t, err)
+
+	code, err := slService.NextSLCode(testAdmin, &sl.NextCodeRequest{ParentCode: parentCode + "0101"})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrNoFreeCode)
+	assert.Empty(t, code)
+}
+
+func Test_CreateSL_ReturnsErrParentCodeNotFound_WithMissingParent(t *testing.T) {
+	useHierarchicalSLCodes(t)
+
+	createdSL, err := createSLWithCode(generateRandomDigits(12) + "01")
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrParentCodeNotFound)
+	assert.Nil(t, createdSL)
+}
+
+func Test_CreateSL_ReturnsErrCodeTemplateMismatch_WithCodeEndingInsideSegment(t *testing.T) {
+	useHierarchicalSLCodes(t)
+
+	createdSL, err := createSLWithCode(generateRandomDigits(13))
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeTemplateMismatch)
+	assert.Nil(t, createdSL)
+}
+
+func Test_CreateSL_ReturnsErrCodeTemplateMismatch_WithNonNumericCode(t *testing.T) {
+	useHierarchicalSLCodes(t)
+
+	createdSL, err := createSLWithCode(generateRandomDigits(11) + "A")
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeTemplateMismatch)
+	assert.Nil(t, createdSL)
+}
+
+func Test_UpdateSL_ReturnsErrCodeHasChildren_WhenRenamingParent(t *testing.T) {
+	useHierarchicalSLCodes(t)
+	parentCode := generateRandomDigits(12)
+	parentSL, err := createSLWithCode(parentCode)
+	require.Nil(t, err)
+	_, err = createSLWithCode(parentCode + "01")
+	require.Nil(t, err)
+
+	updatedSL, err := slService.UpdateSL(testAdmin, &sl.UpdateRequest{
+		ID:      parentSL.ID,
+		Code:    generateRandomDigits(12),
+		Title:   parentSL.Title,
+		Version: parentSL.RowVersion,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeHasChildren)
+	assert.Nil(t, updatedSL)
+}
+
+func Test_CreateSL_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	req := &sl.InsertRequest{
+		Code:  "SL" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	}
+
+	sl, err := slService.CreateSL(auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, sl)
+}
+
+func Test_GetSL_Succeeds_WithViewer(t *testing.T) {
+	slDto, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	sl, err := slService.GetSL(auth.Principal{Username: "viewer", Role: auth.Viewer, TenantID: auth.DefaultTenantID}, &sl.GetRequest{ID: slDto.ID})
+
+	require.Nil(t, err)
+	assert.Equal(t, slDto.Code, sl.Code)
+}
+
+func Test_ArchiveSL_Succeeds_WithValidRequest(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	archivedSL, err := slService.ArchiveSL(testAdmin, &sl.ArchiveRequest{ID: createdSL.ID, Version: createdSL.RowVersion})
+
+	require.Nil(t, err)
+	assert.True(t, archivedSL.Archived)
+	assert.Equal(t, createdSL.RowVersion+1, archivedSL.RowVersion)
+	outboxEvents := findOutboxEvents(t, events.AggregateSL, createdSL.ID)
+	require.Len(t, outboxEvents, 2)
+	assert.Equal(t, events.SLArchived, outboxEvents[1].Type)
+}
+
+func Test_ArchiveSL_ReturnsErrSLArchived_WithArchivedSL(t *testing.T) {
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	archivedSL, err := slService.ArchiveSL(testAdmin, &sl.ArchiveRequest{ID: createdSL.ID, Version: createdSL.RowVersion})
+	require.Nil(t, err)
+
+	slDto, err := slService.ArchiveSL(testAdmin, &sl.ArchiveRequest{ID: archivedSL.ID, Version: archivedSL.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLArchived)
+	assert.Nil(t, slDto)
+}
+
+func Test_CreateVoucher_ReturnsErrSLArchived_WithLineOnArchivedSL(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	debitSL, err := slService.GetSL(testAdmin, &sl.GetRequest{ID: items[0].SLID})
+	require.Nil(t, err)
+	_, err = slService.ArchiveSL(testAdmin, &sl.ArchiveRequest{ID: debitSL.ID, Version: debitSL.RowVersion})
+	require.Nil(t, err)
+
+	voucherDto, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: generateRandomString(20), VoucherItems: items})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLArchived)
+	assert.Nil(t, voucherDto)
+}
+
+func Test_ReverseVoucher_Succeeds_WithLineOnArchivedSL(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	voucherDto, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: generateRandomString(20), VoucherItems: items})
+	require.Nil(t, err)
+	debitSL, err := slService.GetSL(testAdmin, &sl.GetRequest{ID: items[0].SLID})
+	require.Nil(t, err)
+	_, err = slService.ArchiveSL(testAdmin, &sl.ArchiveRequest{ID: debitSL.ID, Version: debitSL.RowVersion})
+	require.Nil(t, err)
+
+	reversal, err := voucherService.ReverseVoucher(testAdmin, &voucher.ReverseRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion, Number: generateRandomString(20)})
+
+	require.Nil(t, err)
+	assert.Len(t, reversal.VoucherItems, 2)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/requests/tenant"
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// TenantService manages the legal entities books are kept for. Tenants sit
+// above the books of any one tenant, so only admins may see or create them.
+type TenantService struct {
+	db *gorm.DB
+}
+
+func (s *TenantService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+// Every other service keeps the books of one tenant at a time. Endpoints that
+// act for a principal first call forTenant(actor.TenantID), which returns a
+// copy of the service whose queries only see and write the records of that
+// tenant, and background jobs call it with the tenant of the record they work
+// on. Currencies and exchange rates are shared by all tenants.
+
+func (s *TenantService) CreateTenant(actor auth.Principal, req *tenant.InsertRequest) (*dtos.TenantDto, error) {
+	if err := auth.Authorize(actor, auth.EntityTenant, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTenantInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	tenantDto, err := s.applyTenantCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating tenant: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return tenantDto, nil
+}
+
+func (s *TenantService) GetTenantByCode(actor auth.Principal, req *tenant.GetByCodeRequest) (*dtos.TenantDto, error) {
+	if err := auth.Authorize(actor, auth.EntityTenant, auth.ActionRead); err != nil {
+		return nil, err
+	}
+
+	targetTenant, err := s.validateTenantExistsByCode(req.Code)
+	if errors.Is(err, constants.ErrTenantNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("unexpected error while getting tenant: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return mappers.ToTenantDto(targetTenant), nil
+}
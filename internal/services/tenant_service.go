@@ -0,0 +1,55 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/normalize"
+	"accountingsystem/internal/requests/tenant"
+	"accountingsystem/internal/textrule"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (s *TenantService) validateTenantInsertRequest(req *tenant.InsertRequest) error {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := textrule.TenantCode.Validate(req.Code); err != nil {
+		return err
+	}
+	if err := textrule.TenantTitle.Validate(req.Title); err != nil {
+		return err
+	}
+	if _, err := s.validateTenantExistsByCode(req.Code); err == nil {
+		return constants.ErrCodeAlreadyExists
+	} else if !errors.Is(err, constants.ErrTenantNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *TenantService) applyTenantCreation(req *tenant.InsertRequest) (*dtos.TenantDto, error) {
+	newTenant := models.Tenant{
+		Code:       req.Code,
+		Title:      req.Title,
+		RowVersion: 0,
+	}
+	if err := s.db.Create(&newTenant).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToTenantDto(&newTenant), nil
+}
+
+func (s *TenantService) validateTenantExistsByCode(code string) (*models.Tenant, error) {
+	var targetTenant models.Tenant
+	if err := s.db.Where("code = ?", normalize.Text(code)).First(&targetTenant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrTenantNotFound
+		}
+		return nil, err
+	}
+	return &targetTenant, nil
+}
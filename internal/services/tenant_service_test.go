@@ -0,0 +1,182 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/numbersequence"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/tenant"
+	"accountingsystem/internal/requests/user"
+	"accountingsystem/internal/requests/voucher"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTenantAdmin() (auth.Principal, error) {
+	tenantDto, err := tenantService.CreateTenant(testAdmin, &tenant.InsertRequest{
+		Code:  "T" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	})
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	return auth.System(tenantDto.ID), nil
+}
+
+func Test_CreateTenant_Succeeds_WithValidRequest(t *testing.T) {
+	req := &tenant.InsertRequest{
+		Code:  "T" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	}
+
+	tenantDto, err := tenantService.CreateTenant(testAdmin, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, req.Code, tenantDto.Code)
+	found, err := tenantService.GetTenantByCode(testAdmin, &tenant.GetByCodeRequest{Code: req.Code})
+	require.Nil(t, err)
+	assert.Equal(t, tenantDto.ID, found.ID)
+}
+
+func Test_CreateTenant_ReturnsErrCodeAlreadyExists_WithExistingCode(t *testing.T) {
+	req := &tenant.InsertRequest{
+		Code:  "T" + generateRandomString(20),
+		Title: "Test" + generateRandomString(20),
+	}
+	_, err := tenantService.CreateTenant(testAdmin, req)
+	require.Nil(t, err)
+
+	tenantDto, err := tenantService.CreateTenant(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCodeAlreadyExists)
+	assert.Nil(t, tenantDto)
+}
+
+func Test_CreateTenant_ReturnsErrForbidden_WithApprover(t *testing.T) {
+	approver := auth.Principal{Username: "approver", Role: auth.Approver, TenantID: auth.DefaultTenantID}
+
+	tenantDto, err := tenantService.CreateTenant(approver, &tenant.InsertRequest{Code: "T" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, tenantDto)
+}
+
+func Test_GetDL_ReturnsErrDLNotFound_WithDLOfAnotherTenant(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	dl, err := dlService.GetDL(otherTenantAdmin, &dl.GetRequest{ID: dlDto.ID})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDLNotFound)
+	assert.Nil(t, dl)
+}
+
+func Test_CreateDL_Succeeds_WithCodeAndTitleUsedInAnotherTenant(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+
+	dl, err := dlService.CreateDL(otherTenantAdmin, &dl.InsertRequest{Code: dlDto.Code, Title: dlDto.Title})
+
+	require.Nil(t, err)
+	assert.NotEqual(t, dlDto.ID, dl.ID)
+	assert.Equal(t, dlDto.Code, dl.Code)
+}
+
+func Test_CreateVoucher_Succeeds_WithNumberUsedInAnotherTenant(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	existingVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+	debitSL, err := slService.CreateSL(otherTenantAdmin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+	creditSL, err := slService.CreateSL(otherTenantAdmin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+
+	voucherDto, err := voucherService.CreateVoucher(otherTenantAdmin, &voucher.InsertRequest{
+		Number: existingVoucher.Number,
+		VoucherItems: []voucher.VoucherItemInsertDetail{
+			{SLID: debitSL.ID, Debit: 100},
+			{SLID: creditSL.ID, Credit: 100},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, existingVoucher.Number, voucherDto.Number)
+}
+
+func Test_CreateVoucher_ReturnsErrSLNotFound_WithSLOfAnotherTenant(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	voucherDto, err := voucherService.CreateVoucher(otherTenantAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLNotFound)
+	assert.Nil(t, voucherDto)
+}
+
+func Test_CreateVoucher_ReturnsErrNumberSequenceNotFound_WithSequenceOfAnotherTenant(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	sequence, err := createRandomNumberSequence(false)
+	require.Nil(t, err)
+	debitSL, err := slService.CreateSL(otherTenantAdmin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+	creditSL, err := slService.CreateSL(otherTenantAdmin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+
+	voucherDto, err := voucherService.CreateVoucher(otherTenantAdmin, &voucher.InsertRequest{
+		SequenceCode: sequence.Code,
+		VoucherItems: []voucher.VoucherItemInsertDetail{
+			{SLID: debitSL.ID, Debit: 100},
+			{SLID: creditSL.ID, Credit: 100},
+		},
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrNumberSequenceNotFound)
+	assert.Nil(t, voucherDto)
+}
+
+func Test_CreateNumberSequence_Succeeds_WithCodeUsedInAnotherTenant(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	sequence, err := createRandomNumberSequence(true)
+	require.Nil(t, err)
+
+	otherSequence, err := numberSequenceService.CreateNumberSequence(otherTenantAdmin, &numbersequence.InsertRequest{Code: sequence.Code, Prefix: sequence.Prefix, Padding: sequence.Padding, Gapless: true})
+
+	require.Nil(t, err)
+	assert.NotEqual(t, sequence.ID, otherSequence.ID)
+	_, err = numberSequenceService.GetNumberSequence(otherTenantAdmin, &numbersequence.GetRequest{ID: sequence.ID})
+	assert.ErrorIs(t, err, constants.ErrNumberSequenceNotFound)
+}
+
+func Test_Authenticate_ReturnsTenantOfUser(t *testing.T) {
+	otherTenantAdmin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	userDto, err := userService.CreateUser(otherTenantAdmin, &user.InsertRequest{Username: "user" + generateRandomString(20), Role: auth.Viewer})
+	require.Nil(t, err)
+
+	principal, err := userService.Authenticate(&user.AuthenticateRequest{Token: userDto.Token})
+
+	require.Nil(t, err)
+	assert.Equal(t, otherTenantAdmin.TenantID, principal.TenantID)
+	_, err = userService.GetUser(testAdmin, &user.GetRequest{ID: userDto.ID})
+	assert.ErrorIs(t, err, constants.ErrUserNotFound)
+}
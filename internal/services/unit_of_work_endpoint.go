@@ -0,0 +1,74 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWorkService runs several service operations in one transaction.
+type UnitOfWorkService struct {
+	db *gorm.DB
+}
+
+// UnitOfWork holds services bound to one transaction. Everything done
+// through them is committed or rolled back together.
+type UnitOfWork struct {
+	DL      *DLService
+	SL      *SLService
+	Voucher *VoucherService
+	tx      *gorm.DB
+}
+
+func (s *UnitOfWorkService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+func (s *UnitOfWorkService) Run(fn func(uow *UnitOfWork) error) error {
+	return s.RunContext(context.Background(), fn)
+}
+
+// RunContext calls fn with services bound to a new transaction, commits it
+// when fn returns nil and rolls it back otherwise. The error of fn is
+// returned as it is.
+func (s *UnitOfWorkService) RunContext(ctx context.Context, fn func(uow *UnitOfWork) error) error {
+	var fnErr error
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		fnErr = fn(newUnitOfWork(tx))
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
+		log.Printf("unexpected error while committing unit of work: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+// Nested calls fn inside a savepoint of the unit of work. When fn fails only
+// its own work is rolled back, and the caller may carry on with the outer
+// unit of work.
+func (u *UnitOfWork) Nested(fn func(uow *UnitOfWork) error) error {
+	var fnErr error
+	err := u.tx.Transaction(func(tx *gorm.DB) error {
+		fnErr = fn(newUnitOfWork(tx))
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		log.Printf("unexpected error while releasing savepoint: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package services
+
+import "gorm.io/gorm"
+
+func newUnitOfWork(tx *gorm.DB) *UnitOfWork {
+	uow := &UnitOfWork{
+		DL:      &DLService{},
+		SL:      &SLService{},
+		Voucher: &VoucherService{},
+		tx:      tx,
+	}
+	uow.DL.InitService(tx)
+	uow.SL.InitService(tx)
+	uow.Voucher.InitService(tx)
+	return uow
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/requests/dl"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/voucher"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunUnitOfWork_CommitsEverything_WhenAllOperationsSucceed(t *testing.T) {
+	dlReq := &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20)}
+	slReq := &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20), HasDL: true}
+	otherSL, err := createRandomSL(false)
+	require.Nil(t, err)
+	voucherNumber := generateRandomString(20)
+
+	err = unitOfWorkService.Run(func(uow *UnitOfWork) error {
+		dlDto, err := uow.DL.CreateDL(testAdmin, dlReq)
+		if err != nil {
+			return err
+		}
+		slDto, err := uow.SL.CreateSL(testAdmin, slReq)
+		if err != nil {
+			return err
+		}
+		_, err = uow.Voucher.CreateVoucher(testAdmin, &voucher.InsertRequest{
+			Number: voucherNumber,
+			VoucherItems: []voucher.VoucherItemInsertDetail{
+				{SLID: slDto.ID, DLID: &dlDto.ID, Debit: 100},
+				{SLID: otherSL.ID, Credit: 100},
+			},
+		})
+		return err
+	})
+
+	require.Nil(t, err)
+	_, err = dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: dlReq.Code})
+	assert.Nil(t, err)
+	_, err = slService.GetSLByCode(testAdmin, &sl.GetByCodeRequest{Code: slReq.Code})
+	assert.Nil(t, err)
+	_, err = voucherService.GetVoucherByNumber(testAdmin, &voucher.GetByNumberRequest{Number: voucherNumber})
+	assert.Nil(t, err)
+}
+
+func Test_RunUnitOfWork_RollsBackEverything_WhenAnOperationFails(t *testing.T) {
+	dlReq := &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20)}
+	slReq := &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)}
+
+	err := unitOfWorkService.Run(func(uow *UnitOfWork) error {
+		if _, err := uow.DL.CreateDL(testAdmin, dlReq); err != nil {
+			return err
+		}
+		if _, err := uow.SL.CreateSL(testAdmin, slReq); err != nil {
+			return err
+		}
+		_, err := uow.Voucher.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: generateRandomString(20)})
+		return err
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherItemsCountOutOfRange)
+	_, err = dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: dlReq.Code})
+	assert.ErrorIs(t, err, constants.ErrDLNotFound)
+	_, err = slService.GetSLByCode(testAdmin, &sl.GetByCodeRequest{Code: slReq.Code})
+	assert.ErrorIs(t, err, constants.ErrSLNotFound)
+}
+
+func Test_NestedUnitOfWork_RollsBackOnlySavepoint_WhenNestedOperationFails(t *testing.T) {
+	outerReq := &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20)}
+	nestedReq := &dl.InsertRequest{Code: "DL" + generateRandomString(20), Title: "Test" + generateRandomString(20)}
+
+	var nestedErr error
+	err := unitOfWorkService.Run(func(uow *UnitOfWork) error {
+		if _, err := uow.DL.CreateDL(testAdmin, outerReq); err != nil {
+			return err
+		}
+		nestedErr = uow.Nested(func(nested *UnitOfWork) error {
+			if _, err := nested.DL.CreateDL(testAdmin, nestedReq); err != nil {
+				return err
+			}
+			_, err := nested.DL.CreateDL(testAdmin, &dl.InsertRequest{Code: outerReq.Code, Title: "Test" + generateRandomString(20)})
+			return err
+		})
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.ErrorIs(t, nestedErr, constants.ErrCodeAlreadyExists)
+	_, err = dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: outerReq.Code})
+	assert.Nil(t, err)
+	_, err = dlService.GetDLByCode(testAdmin, &dl.GetByCodeRequest{Code: nestedReq.Code})
+	assert.ErrorIs(t, err, constants.ErrDLNotFound)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/requests/user"
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type UserService struct {
+	db       *gorm.DB
+	tenantID int
+}
+
+func (s *UserService) InitService(db *gorm.DB) {
+	s.db = db
+}
+
+func (s *UserService) forTenant(tenantID int) *UserService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+// CreateUser registers a user in the actor's tenant and issues its bearer
+// token, which is only returned here and by RotateUserToken. Usernames are
+// unique across tenants as they identify the user's tenant.
+func (s *UserService) CreateUser(actor auth.Principal, req *user.InsertRequest) (*dtos.UserDto, error) {
+	if err := auth.Authorize(actor, auth.EntityUser, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	if err := s.validateUserInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	userDto, err := s.applyUserCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating user: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return userDto, nil
+}
+
+func (s *UserService) UpdateUser(actor auth.Principal, req *user.UpdateRequest) (*dtos.UserDto, error) {
+	if err := auth.Authorize(actor, auth.EntityUser, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetUser, err := s.validateUserUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	userDto, err := s.applyUserUpdate(req, targetUser)
+	if err != nil {
+		log.Printf("unexpected error while updating user: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return userDto, nil
+}
+
+func (s *UserService) RotateUserToken(actor auth.Principal, req *user.RotateTokenRequest) (*dtos.UserDto, error) {
+	if err := auth.Authorize(actor, auth.EntityUser, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetUser, err := s.validateUserRotateTokenRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	userDto, err := s.applyUserTokenRotation(targetUser)
+	if err != nil {
+		log.Printf("unexpected error while rotating user token: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return userDto, nil
+}
+
+func (s *UserService) GetUser(actor auth.Principal, req *user.GetRequest) (*dtos.UserDto, error) {
+	if err := auth.Authorize(actor, auth.EntityUser, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetUser, err := s.validateUserExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToUserDto(targetUser), nil
+}
+
+// Authenticate resolves a bearer token to the principal of its active user.
+func (s *UserService) Authenticate(req *user.AuthenticateRequest) (auth.Principal, error) {
+	targetUser, err := s.validateAuthenticateRequest(req)
+	if errors.Is(err, constants.ErrUnauthenticated) {
+		return auth.Principal{}, err
+	}
+	if err != nil {
+		log.Printf("unexpected error while authenticating: %v", err)
+		return auth.Principal{}, constants.ErrUnexpectedError
+	}
+
+	return auth.Principal{UserID: targetUser.ID, Username: targetUser.Username, Role: auth.Role(targetUser.Role), TenantID: targetUser.TenantID}, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/normalize"
+	"accountingsystem/internal/requests/user"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const tokenBytes = 32
+
+func (s *UserService) validateUserInsertRequest(req *user.InsertRequest) error {
+	req.Username = normalize.Text(req.Username)
+	if req.Username == "" || utf8.RuneCountInString(req.Username) > 64 {
+		return constants.ErrUsernameEmptyOrTooLong
+	}
+	if !req.Role.IsValid() {
+		return constants.ErrInvalidRole
+	}
+	var existingUser models.User
+	if err := s.db.Where("username = ?", req.Username).First(&existingUser).Error; err == nil {
+		return constants.ErrUsernameAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *UserService) applyUserCreation(req *user.InsertRequest) (*dtos.UserDto, error) {
+	token, tokenHash, err := s.generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	newUser := models.User{
+		TenantID:   s.tenantID,
+		Username:   req.Username,
+		Role:       string(req.Role),
+		TokenHash:  tokenHash,
+		Active:     true,
+		RowVersion: 0,
+	}
+	if err := s.db.Create(&newUser).Error; err != nil {
+		return nil, err
+	}
+
+	userDto := mappers.ToUserDto(&newUser)
+	userDto.Token = token
+	return userDto, nil
+}
+
+func (s *UserService) validateUserUpdateRequest(req *user.UpdateRequest) (*models.User, error) {
+	if !req.Role.IsValid() {
+		return nil, constants.ErrInvalidRole
+	}
+	targetUser, err := s.validateUserExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != targetUser.RowVersion {
+		return nil, constants.ErrVersionOutdated
+	}
+	return targetUser, nil
+}
+
+func (s *UserService) applyUserUpdate(req *user.UpdateRequest, targetUser *models.User) (*dtos.UserDto, error) {
+	targetUser.Role = string(req.Role)
+	targetUser.Active = req.Active
+	targetUser.RowVersion++
+	if err := s.db.Save(targetUser).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToUserDto(targetUser), nil
+}
+
+func (s *UserService) validateUserRotateTokenRequest(req *user.RotateTokenRequest) (*models.User, error) {
+	targetUser, err := s.validateUserExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != targetUser.RowVersion {
+		return nil, constants.ErrVersionOutdated
+	}
+	return targetUser, nil
+}
+
+func (s *UserService) applyUserTokenRotation(targetUser *models.User) (*dtos.UserDto, error) {
+	token, tokenHash, err := s.generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	targetUser.TokenHash = tokenHash
+	targetUser.RowVersion++
+	if err := s.db.Save(targetUser).Error; err != nil {
+		return nil, err
+	}
+
+	userDto := mappers.ToUserDto(targetUser)
+	userDto.Token = token
+	return userDto, nil
+}
+
+func (s *UserService) validateUserExists(id int) (*models.User, error) {
+	var targetUser models.User
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetUser).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &targetUser, nil
+}
+
+func (s *UserService) validateAuthenticateRequest(req *user.AuthenticateRequest) (*models.User, error) {
+	if req.Token == "" {
+		return nil, constants.ErrUnauthenticated
+	}
+	var targetUser models.User
+	if err := s.db.Where("token_hash = ? AND active", s.hashToken(req.Token)).First(&targetUser).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUnauthenticated
+		}
+		return nil, err
+	}
+	return &targetUser, nil
+}
+
+// generateToken returns a new random bearer token and the hash it is stored
+// and looked up by.
+func (s *UserService) generateToken() (string, string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(raw)
+	return token, s.hashToken(token), nil
+}
+
+func (s *UserService) hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/requests/user"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateUser_Succeeds_WithValidRequest(t *testing.T) {
+	req := &user.InsertRequest{
+		Username: "user" + generateRandomString(20),
+		Role:     auth.Accountant,
+	}
+
+	userDto, err := userService.CreateUser(testAdmin, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, req.Username, userDto.Username)
+	assert.Equal(t, auth.Accountant, userDto.Role)
+	assert.True(t, userDto.Active)
+	assert.NotEmpty(t, userDto.Token)
+}
+
+func Test_CreateUser_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	req := &user.InsertRequest{
+		Username: "user" + generateRandomString(20),
+		Role:     auth.Admin,
+	}
+
+	userDto, err := userService.CreateUser(auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, userDto)
+}
+
+func Test_CreateUser_ReturnsErrInvalidRole_WithUnknownRole(t *testing.T) {
+	req := &user.InsertRequest{
+		Username: "user" + generateRandomString(20),
+		Role:     "auditor",
+	}
+
+	userDto, err := userService.CreateUser(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidRole)
+	assert.Nil(t, userDto)
+}
+
+func Test_CreateUser_ReturnsErrUsernameAlreadyExists_WithExistingUsername(t *testing.T) {
+	existingUser, err := createRandomUser(auth.Viewer)
+	require.Nil(t, err)
+
+	userDto, err := userService.CreateUser(testAdmin, &user.InsertRequest{Username: existingUser.Username, Role: auth.Viewer})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrUsernameAlreadyExists)
+	assert.Nil(t, userDto)
+}
+
+func Test_Authenticate_Succeeds_WithIssuedToken(t *testing.T) {
+	userDto, err := createRandomUser(auth.Approver)
+	require.Nil(t, err)
+
+	principal, err := userService.Authenticate(&user.AuthenticateRequest{Token: userDto.Token})
+
+	require.Nil(t, err)
+	assert.Equal(t, userDto.ID, principal.UserID)
+	assert.Equal(t, userDto.Username, principal.Username)
+	assert.Equal(t, auth.Approver, principal.Role)
+}
+
+func Test_Authenticate_ReturnsErrUnauthenticated_WithUnknownToken(t *testing.T) {
+	_, err := userService.Authenticate(&user.AuthenticateRequest{Token: generateRandomString(64)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrUnauthenticated)
+}
+
+func Test_Authenticate_ReturnsErrUnauthenticated_WithDeactivatedUser(t *testing.T) {
+	userDto, err := createRandomUser(auth.Viewer)
+	require.Nil(t, err)
+	_, err = userService.UpdateUser(testAdmin, &user.UpdateRequest{ID: userDto.ID, Role: auth.Viewer, Active: false, Version: userDto.RowVersion})
+	require.Nil(t, err)
+
+	_, err = userService.Authenticate(&user.AuthenticateRequest{Token: userDto.Token})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrUnauthenticated)
+}
+
+func Test_RotateUserToken_InvalidatesPreviousToken(t *testing.T) {
+	userDto, err := createRandomUser(auth.Viewer)
+	require.Nil(t, err)
+
+	rotated, err := userService.RotateUserToken(testAdmin, &user.RotateTokenRequest{ID: userDto.ID, Version: userDto.RowVersion})
+	require.Nil(t, err)
+
+	_, err = userService.Authenticate(&user.AuthenticateRequest{Token: userDto.Token})
+	assert.ErrorIs(t, err, constants.ErrUnauthenticated)
+	principal, err := userService.Authenticate(&user.AuthenticateRequest{Token: rotated.Token})
+	require.Nil(t, err)
+	assert.Equal(t, userDto.ID, principal.UserID)
+}
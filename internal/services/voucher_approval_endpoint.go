@@ -0,0 +1,153 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/voucher"
+	"context"
+	"errors"
+	"log"
+)
+
+func (s *VoucherService) SubmitVoucher(actor auth.Principal, req *voucher.SubmitRequest) (*dtos.VoucherDto, error) {
+	return s.SubmitVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) SubmitVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.SubmitRequest) (*dtos.VoucherDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionSubmit); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetVoucher, err := s.validateSubmitVoucherRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	voucherDto, err := s.applyVoucherSubmission(actor, req, targetVoucher)
+	if errors.Is(err, constants.ErrVersionOutdated) {
+		return nil, contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while submitting voucher: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherDto, nil
+}
+
+func (s *VoucherService) ApproveVoucher(actor auth.Principal, req *voucher.ApproveRequest) (*dtos.VoucherDto, error) {
+	return s.ApproveVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) ApproveVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.ApproveRequest) (*dtos.VoucherDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionApprove); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetVoucher, err := s.validateApproveVoucherRequest(actor, req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	voucherDto, err := s.applyVoucherApproval(actor, req, targetVoucher)
+	if errors.Is(err, constants.ErrVersionOutdated) {
+		return nil, contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while approving voucher: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherDto, nil
+}
+
+func (s *VoucherService) RejectVoucher(actor auth.Principal, req *voucher.RejectRequest) (*dtos.VoucherDto, error) {
+	return s.RejectVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) RejectVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.RejectRequest) (*dtos.VoucherDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionApprove); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetVoucher, err := s.validateRejectVoucherRequest(actor, req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	voucherDto, err := s.applyVoucherRejection(actor, req, targetVoucher)
+	if errors.Is(err, constants.ErrVersionOutdated) {
+		return nil, contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while rejecting voucher: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherDto, nil
+}
+
+// GetApprovalQueue lists the pending vouchers the actor may still approve:
+// those submitted by someone else that they have not approved in the
+// current round.
+func (s *VoucherService) GetApprovalQueue(actor auth.Principal) ([]dtos.ApprovalQueueItemDto, error) {
+	return s.GetApprovalQueueContext(context.Background(), actor)
+}
+
+func (s *VoucherService) GetApprovalQueueContext(ctx context.Context, actor auth.Principal) ([]dtos.ApprovalQueueItemDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionApprove); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	queue, err := s.applyApprovalQueueGet(actor)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting approval queue: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return queue, nil
+}
+
+func (s *VoucherService) GetVoucherApprovals(actor auth.Principal, req *voucher.GetRequest) ([]dtos.VoucherApprovalDto, error) {
+	return s.GetVoucherApprovalsContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) GetVoucherApprovalsContext(ctx context.Context, actor auth.Principal, req *voucher.GetRequest) ([]dtos.VoucherApprovalDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetVoucher, err := s.validateGetVoucherRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	approvals, err := s.applyVoucherApprovalsGet(targetVoucher)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting voucher approvals: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return approvals, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/voucher"
+	"database/sql"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+func (s *VoucherService) validateSubmitVoucherRequest(req *voucher.SubmitRequest) (*models.Voucher, error) {
+	if err := s.validateComment(req.Comment); err != nil {
+		return nil, err
+	}
+	targetVoucher, err := s.validateVoucherExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(targetVoucher.RowVersion, req.Version); err != nil {
+		return nil, err
+	}
+	if err := s.validateVoucherStatus(targetVoucher, voucher.StatusDraft, voucher.StatusRejected); err != nil {
+		return nil, err
+	}
+	return targetVoucher, nil
+}
+
+func (s *VoucherService) applyVoucherSubmission(actor auth.Principal, req *voucher.SubmitRequest, targetVoucher *models.Voucher) (*dtos.VoucherDto, error) {
+	targetVoucher.Status = voucher.StatusPending
+	targetVoucher.ApprovalRound++
+	targetVoucher.SubmittedBy = actor.Username
+	targetVoucher.RowVersion++
+
+	if err := s.saveVoucherDecision(actor, targetVoucher, voucher.DecisionSubmit, req.Comment); err != nil {
+		return nil, err
+	}
+	return mappers.ToVoucherDto(targetVoucher, ""), nil
+}
+
+func (s *VoucherService) validateApproveVoucherRequest(actor auth.Principal, req *voucher.ApproveRequest) (*models.Voucher, error) {
+	targetVoucher, err := s.validateVoucherDecision(actor, req.ID, req.Version, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	approved, err := s.hasApprovedInRound(targetVoucher, actor.Username)
+	if err != nil {
+		return nil, err
+	}
+	if approved {
+		return nil, constants.ErrAlreadyApproved
+	}
+	return targetVoucher, nil
+}
+
+func (s *VoucherService) applyVoucherApproval(actor auth.Principal, req *voucher.ApproveRequest, targetVoucher *models.Voucher) (*dtos.VoucherDto, error) {
+	approvals, err := s.countApprovalsInRound(targetVoucher)
+	if err != nil {
+		return nil, err
+	}
+	if approvals+1 >= targetVoucher.RequiredApprovals {
+		targetVoucher.Status = voucher.StatusPosted
+	}
+	targetVoucher.RowVersion++
+
+	if err := s.saveVoucherDecision(actor, targetVoucher, voucher.DecisionApprove, req.Comment); err != nil {
+		return nil, err
+	}
+	return mappers.ToVoucherDto(targetVoucher, ""), nil
+}
+
+func (s *VoucherService) validateRejectVoucherRequest(actor auth.Principal, req *voucher.RejectRequest) (*models.Voucher, error) {
+	return s.validateVoucherDecision(actor, req.ID, req.Version, req.Comment)
+}
+
+func (s *VoucherService) applyVoucherRejection(actor auth.Principal, req *voucher.RejectRequest, targetVoucher *models.Voucher) (*dtos.VoucherDto, error) {
+	targetVoucher.Status = voucher.StatusRejected
+	targetVoucher.RowVersion++
+
+	if err := s.saveVoucherDecision(actor, targetVoucher, voucher.DecisionReject, req.Comment); err != nil {
+		return nil, err
+	}
+	return mappers.ToVoucherDto(targetVoucher, ""), nil
+}
+
+// validateVoucherDecision holds the checks shared by approving and rejecting:
+// the voucher waits for a decision and the actor did not create, last edit or
+// submit it.
+func (s *VoucherService) validateVoucherDecision(actor auth.Principal, id int, version int, comment string) (*models.Voucher, error) {
+	if err := s.validateComment(comment); err != nil {
+		return nil, err
+	}
+	targetVoucher, err := s.validateVoucherExists(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(targetVoucher.RowVersion, version); err != nil {
+		return nil, err
+	}
+	if err := s.validateVoucherStatus(targetVoucher, voucher.StatusPending); err != nil {
+		return nil, err
+	}
+	if s.isMakerOf(targetVoucher, actor) {
+		return nil, constants.ErrSelfApproval
+	}
+	return targetVoucher, nil
+}
+
+func (s *VoucherService) isMakerOf(targetVoucher *models.Voucher, actor auth.Principal) bool {
+	return targetVoucher.CreatedBy == actor.Username || targetVoucher.UpdatedBy == actor.Username || targetVoucher.SubmittedBy == actor.Username
+}
+
+func (s *VoucherService) validateComment(comment string) error {
+	if utf8.RuneCountInString(comment) > 512 {
+		return constants.ErrCommentTooLong
+	}
+	return nil
+}
+
+func (s *VoucherService) hasApprovedInRound(targetVoucher *models.Voucher, username string) (bool, error) {
+	var count int64
+	if err := s.db.Model(&models.VoucherApproval{}).
+		Where("tenant_id = ? AND voucher_id = ? AND round = ? AND decision = ? AND username = ?", s.tenantID, targetVoucher.ID, targetVoucher.ApprovalRound, voucher.DecisionApprove, username).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *VoucherService) countApprovalsInRound(targetVoucher *models.Voucher) (int, error) {
+	var count int64
+	if err := s.db.Model(&models.VoucherApproval{}).
+		Where("tenant_id = ? AND voucher_id = ? AND round = ? AND decision = ?", s.tenantID, targetVoucher.ID, targetVoucher.ApprovalRound, voucher.DecisionApprove).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// decisionEvents maps the decisions on a voucher to the events they raise.
+var decisionEvents = map[string]string{
+	voucher.DecisionSubmit:  events.VoucherSubmitted,
+	voucher.DecisionApprove: events.VoucherApproved,
+	voucher.DecisionReject:  events.VoucherRejected,
+}
+
+// saveVoucherDecision stores the new state of the voucher together with the
+// history row of the decision. The update is conditioned on the version read
+// before the change so two concurrent decisions cannot both succeed.
+func (s *VoucherService) saveVoucherDecision(actor auth.Principal, targetVoucher *models.Voucher, decision string, comment string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Voucher{}).
+			Where("tenant_id = ? AND id = ? AND row_version = ?", s.tenantID, targetVoucher.ID, targetVoucher.RowVersion-1).
+			Updates(map[string]interface{}{
+				"status":         targetVoucher.Status,
+				"approval_round": targetVoucher.ApprovalRound,
+				"submitted_by":   targetVoucher.SubmittedBy,
+				"row_version":    targetVoucher.RowVersion,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return constants.ErrVersionOutdated
+		}
+
+		approval := models.VoucherApproval{
+			TenantID:  s.tenantID,
+			VoucherID: targetVoucher.ID,
+			Round:     targetVoucher.ApprovalRound,
+			Decision:  decision,
+			UserID:    sql.NullInt64{Int64: int64(actor.UserID), Valid: actor.UserID != 0},
+			Username:  actor.Username,
+			Comment:   comment,
+		}
+		if err := tx.Create(&approval).Error; err != nil {
+			return err
+		}
+		if err := recordEvent(tx, s.tenantID, decisionEvents[decision], events.AggregateVoucher, targetVoucher.ID, mappers.ToVoucherDto(targetVoucher, "")); err != nil {
+			return err
+		}
+		return s.recordVoucherPosted(tx, voucher.StatusPending, targetVoucher)
+	})
+}
+
+func (s *VoucherService) applyApprovalQueueGet(actor auth.Principal) ([]dtos.ApprovalQueueItemDto, error) {
+	var rows []struct {
+		models.Voucher
+		TotalDebit int64
+		Approvals  int
+	}
+	if err := s.db.Table("voucher").
+		Select("voucher.*, "+
+			"(SELECT COALESCE(SUM(debit), 0) FROM voucher_item WHERE voucher_item.tenant_id = voucher.tenant_id AND voucher_item.voucher_id = voucher.id) AS total_debit, "+
+			"(SELECT COUNT(*) FROM voucher_approval WHERE voucher_approval.tenant_id = voucher.tenant_id AND voucher_approval.voucher_id = voucher.id AND voucher_approval.round = voucher.approval_round AND voucher_approval.decision = ?) AS approvals", voucher.DecisionApprove).
+		Where("voucher.tenant_id = ? AND voucher.status = ? AND ? NOT IN (voucher.created_by, voucher.updated_by, voucher.submitted_by)", s.tenantID, voucher.StatusPending, actor.Username).
+		Where("NOT EXISTS (SELECT 1 FROM voucher_approval WHERE voucher_approval.tenant_id = voucher.tenant_id AND voucher_approval.voucher_id = voucher.id AND voucher_approval.round = voucher.approval_round AND voucher_approval.decision = ? AND voucher_approval.username = ?)", voucher.DecisionApprove, actor.Username).
+		Order("voucher.id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	queue := make([]dtos.ApprovalQueueItemDto, 0, len(rows))
+	for _, row := range rows {
+		queue = append(queue, dtos.ApprovalQueueItemDto{
+			Voucher:     *mappers.ToVoucherDto(&row.Voucher, ""),
+			TotalDebit:  money.Amount(row.TotalDebit),
+			SubmittedBy: row.SubmittedBy,
+			Approvals:   row.Approvals,
+		})
+	}
+	return queue, nil
+}
+
+func (s *VoucherService) applyVoucherApprovalsGet(targetVoucher *models.Voucher) ([]dtos.VoucherApprovalDto, error) {
+	var approvals []models.VoucherApproval
+	if err := s.db.Where("tenant_id = ? AND voucher_id = ?", s.tenantID, targetVoucher.ID).Order("id").Find(&approvals).Error; err != nil {
+		return nil, err
+	}
+
+	approvalDtos := make([]dtos.VoucherApprovalDto, 0, len(approvals))
+	for i := range approvals {
+		approvalDtos = append(approvalDtos, *mappers.ToVoucherApprovalDto(&approvals[i]))
+	}
+	return approvalDtos, nil
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/approvalrule"
+	"accountingsystem/internal/requests/report"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/voucher"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createApprovalTenant creates a tenant whose vouchers of 1000 or more need
+// the given number of approvals, so the rule does not affect other tests.
+func createApprovalTenant(requiredApprovals int) (auth.Principal, error) {
+	admin, err := createRandomTenantAdmin()
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	minAmount := money.Amount(1000)
+	if _, err := approvalRuleService.CreateApprovalRule(admin, &approvalrule.InsertRequest{MinAmount: &minAmount, RequiredApprovals: requiredApprovals}); err != nil {
+		return auth.Principal{}, err
+	}
+	return admin, nil
+}
+
+func createTenantVoucher(actor auth.Principal, amount money.Amount) (*dtos.VoucherWithItemsDto, error) {
+	var slIDs []int
+	for i := 0; i < 2; i++ {
+		slDto, err := slService.CreateSL(actor, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+		if err != nil {
+			return nil, err
+		}
+		slIDs = append(slIDs, slDto.ID)
+	}
+	return voucherService.CreateVoucher(actor, &voucher.InsertRequest{
+		Number: generateRandomString(20),
+		VoucherItems: []voucher.VoucherItemInsertDetail{
+			{SLID: slIDs[0], Debit: amount},
+			{SLID: slIDs[1], Credit: amount},
+		},
+	})
+}
+
+func approvalPrincipals(tenantID int) (auth.Principal, auth.Principal, auth.Principal) {
+	maker := auth.Principal{Username: "maker", Role: auth.Accountant, TenantID: tenantID}
+	checker := auth.Principal{Username: "checker", Role: auth.Approver, TenantID: tenantID}
+	secondChecker := auth.Principal{Username: "second-checker", Role: auth.Approver, TenantID: tenantID}
+	return maker, checker, secondChecker
+}
+
+func Test_CreateVoucher_PostsVoucher_BelowApprovalThreshold(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+
+	voucherDto, err := createTenantVoucher(admin, 999)
+
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusPosted, voucherDto.Status)
+	assert.Equal(t, 0, voucherDto.RequiredApprovals)
+}
+
+func Test_CreateVoucher_KeepsDraft_WithVoucherMatchingApprovalRule(t *testing.T) {
+	admin, err := createApprovalTenant(2)
+	require.Nil(t, err)
+
+	voucherDto, err := createTenantVoucher(admin, 1000)
+
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusDraft, voucherDto.Status)
+	assert.Equal(t, 2, voucherDto.RequiredApprovals)
+}
+
+func Test_CreateVoucher_KeepsDraft_WithVoucherUsingSensitiveSL(t *testing.T) {
+	admin, err := createRandomTenantAdmin()
+	require.Nil(t, err)
+	sensitiveSL, err := slService.CreateSL(admin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+	otherSL, err := slService.CreateSL(admin, &sl.InsertRequest{Code: "SL" + generateRandomString(20), Title: "Test" + generateRandomString(20)})
+	require.Nil(t, err)
+	_, err = approvalRuleService.CreateApprovalRule(admin, &approvalrule.InsertRequest{SLID: &sensitiveSL.ID, RequiredApprovals: 1})
+	require.Nil(t, err)
+
+	voucherDto, err := voucherService.CreateVoucher(admin, &voucher.InsertRequest{
+		Number: generateRandomString(20),
+		VoucherItems: []voucher.VoucherItemInsertDetail{
+			{SLID: otherSL.ID, Debit: 1},
+			{SLID: sensitiveSL.ID, Credit: 1},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusDraft, voucherDto.Status)
+}
+
+func Test_ApproveVoucher_PostsVoucher_WhenRequiredApprovalsReached(t *testing.T) {
+	admin, err := createApprovalTenant(2)
+	require.Nil(t, err)
+	maker, checker, secondChecker := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+
+	approved, err := voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: submitted.ID, Version: submitted.RowVersion, Comment: "ok"})
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusPending, approved.Status)
+	approved, err = voucherService.ApproveVoucher(secondChecker, &voucher.ApproveRequest{ID: approved.ID, Version: approved.RowVersion})
+
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusPosted, approved.Status)
+	approvals, err := voucherService.GetVoucherApprovals(admin, &voucher.GetRequest{ID: voucherDto.ID})
+	require.Nil(t, err)
+	require.Len(t, approvals, 3)
+	assert.Equal(t, voucher.DecisionSubmit, approvals[0].Decision)
+	assert.Equal(t, "maker", approvals[0].Username)
+	assert.Equal(t, "ok", approvals[1].Comment)
+	assert.Equal(t, "second-checker", approvals[2].Username)
+}
+
+func Test_ApproveVoucher_ReturnsErrSelfApproval_WithSubmitter(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(admin, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+
+	approved, err := voucherService.ApproveVoucher(admin, &voucher.ApproveRequest{ID: submitted.ID, Version: submitted.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSelfApproval)
+	assert.Nil(t, approved)
+}
+
+func Test_ApproveVoucher_ReturnsErrSelfApproval_WithCreator(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	maker, _, _ := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+
+	approved, err := voucherService.ApproveVoucher(admin, &voucher.ApproveRequest{ID: submitted.ID, Version: submitted.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSelfApproval)
+	assert.Nil(t, approved)
+}
+
+func Test_RejectVoucher_ReturnsErrSelfApproval_WithEditor(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	maker, _, _ := approvalPrincipals(admin.TenantID)
+	editor := auth.Principal{Username: "editor", Role: auth.Admin, TenantID: admin.TenantID}
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	updated, err := voucherService.UpdateVoucher(editor, &voucher.UpdateRequest{ID: voucherDto.ID, Number: generateRandomString(20), Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: updated.ID, Version: updated.RowVersion})
+	require.Nil(t, err)
+
+	rejected, err := voucherService.RejectVoucher(editor, &voucher.RejectRequest{ID: submitted.ID, Version: submitted.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSelfApproval)
+	assert.Nil(t, rejected)
+}
+
+func Test_ApproveVoucher_ReturnsErrAlreadyApproved_WithSameApproverTwice(t *testing.T) {
+	admin, err := createApprovalTenant(2)
+	require.Nil(t, err)
+	maker, checker, _ := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+	approved, err := voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: submitted.ID, Version: submitted.RowVersion})
+	require.Nil(t, err)
+
+	approvedAgain, err := voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: approved.ID, Version: approved.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrAlreadyApproved)
+	assert.Nil(t, approvedAgain)
+}
+
+func Test_ApproveVoucher_ReturnsErrInvalidVoucherStatus_WithDraftVoucher(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	_, checker, _ := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+
+	approved, err := voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidVoucherStatus)
+	assert.Nil(t, approved)
+}
+
+func Test_ApproveVoucher_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	maker, _, _ := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+
+	approved, err := voucherService.ApproveVoucher(maker, &voucher.ApproveRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, approved)
+}
+
+func Test_RejectVoucher_AllowsResubmission_WithNewRound(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	maker, checker, _ := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+
+	rejected, err := voucherService.RejectVoucher(checker, &voucher.RejectRequest{ID: submitted.ID, Version: submitted.RowVersion, Comment: "wrong SL"})
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusRejected, rejected.Status)
+	resubmitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: rejected.ID, Version: rejected.RowVersion})
+	require.Nil(t, err)
+	approved, err := voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: resubmitted.ID, Version: resubmitted.RowVersion})
+
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusPosted, approved.Status)
+}
+
+func Test_SubmitVoucher_ReturnsErrCommentTooLong_WithLongComment(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+
+	submitted, err := voucherService.SubmitVoucher(admin, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion, Comment: generateRandomString(513)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrCommentTooLong)
+	assert.Nil(t, submitted)
+}
+
+func Test_GetApprovalQueue_ListsPendingVouchers_NotSubmittedOrApprovedByActor(t *testing.T) {
+	admin, err := createApprovalTenant(2)
+	require.Nil(t, err)
+	maker, checker, secondChecker := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1500)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+	_, err = voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: submitted.ID, Version: submitted.RowVersion})
+	require.Nil(t, err)
+
+	checkerQueue, err := voucherService.GetApprovalQueue(checker)
+	require.Nil(t, err)
+	secondCheckerQueue, err := voucherService.GetApprovalQueue(secondChecker)
+
+	require.Nil(t, err)
+	assert.Empty(t, checkerQueue)
+	require.Len(t, secondCheckerQueue, 1)
+	assert.Equal(t, voucherDto.ID, secondCheckerQueue[0].Voucher.ID)
+	assert.Equal(t, money.Amount(1500), secondCheckerQueue[0].TotalDebit)
+	assert.Equal(t, "maker", secondCheckerQueue[0].SubmittedBy)
+	assert.Equal(t, 1, secondCheckerQueue[0].Approvals)
+}
+
+func Test_ReverseVoucher_ReturnsErrInvalidVoucherStatus_WithDraftVoucher(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+
+	reversal, err := voucherService.ReverseVoucher(admin, &voucher.ReverseRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion, Number: generateRandomString(20)})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidVoucherStatus)
+	assert.Nil(t, reversal)
+}
+
+func Test_ReverseVoucher_KeepsDraft_WithReversalMatchingApprovalRule(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	maker, checker, _ := approvalPrincipals(admin.TenantID)
+	voucherDto, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	submitted, err := voucherService.SubmitVoucher(maker, &voucher.SubmitRequest{ID: voucherDto.ID, Version: voucherDto.RowVersion})
+	require.Nil(t, err)
+	approved, err := voucherService.ApproveVoucher(checker, &voucher.ApproveRequest{ID: submitted.ID, Version: submitted.RowVersion})
+	require.Nil(t, err)
+
+	reversal, err := voucherService.ReverseVoucher(checker, &voucher.ReverseRequest{ID: approved.ID, Version: approved.RowVersion, Number: generateRandomString(20)})
+
+	require.Nil(t, err)
+	assert.Equal(t, voucher.StatusDraft, reversal.Status)
+	assert.Equal(t, 1, reversal.RequiredApprovals)
+}
+
+func Test_GetTrialBalance_SkipsVouchers_NotYetApproved(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	_, err = createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+
+	trialBalance, err := reportService.GetTrialBalance(admin, &report.TrialBalanceRequest{})
+
+	require.Nil(t, err)
+	assert.Empty(t, trialBalance.Rows)
+}
@@ -1,29 +1,136 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
 	"accountingsystem/internal/requests/voucher"
+	"context"
+	"errors"
+	"io"
 	"log"
 
 	"gorm.io/gorm"
 )
 
 type VoucherService struct {
-	db *gorm.DB
+	db               *gorm.DB
+	tenantID         int
+	approvalExempt   bool
+	revaluationLines bool
+	archivedSLs      bool
+	currencyService  *CurrencyService
 }
 
 func (s *VoucherService) InitService(db *gorm.DB) {
 	s.db = db
+	s.currencyService = &CurrencyService{}
+	s.currencyService.InitService(db)
 }
 
-func (s *VoucherService) CreateVoucher(req *voucher.InsertRequest) (*dtos.VoucherWithItemsDto, error) {
-	if err := s.validateInsertVoucherRequest(req); err != nil {
+// inTransaction returns a copy of the service whose queries run in tx.
+func (s *VoucherService) inTransaction(tx *gorm.DB) *VoucherService {
+	scoped := *s
+	scoped.db = tx
+	return &scoped
+}
+
+func (s *VoucherService) forTenant(tenantID int) *VoucherService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+// withContext returns a copy of the service whose queries run under ctx, so
+// they are abandoned once it is canceled or its deadline passes.
+func (s *VoucherService) withContext(ctx context.Context) *VoucherService {
+	scoped := *s
+	scoped.db = s.db.WithContext(ctx)
+	scoped.currencyService = s.currencyService.withContext(ctx)
+	return &scoped
+}
+
+// withoutApprovalRules returns a copy of the service that posts the vouchers
+// it creates even when they match an approval rule. It is meant for postings
+// computed by the system whose operation is already restricted, such as
+// revaluations.
+func (s *VoucherService) withoutApprovalRules() *VoucherService {
+	scoped := *s
+	scoped.approvalExempt = true
+	return &scoped
+}
+
+// withRevaluationLines returns a copy of the service that accepts lines in a
+// foreign currency without foreign amounts, which only move the functional
+// balance of the account. Revaluations book such lines, and reversals take
+// over the lines of the voucher they undo.
+func (s *VoucherService) withRevaluationLines() *VoucherService {
+	scoped := *s
+	scoped.revaluationLines = true
+	return &scoped
+}
+
+// withArchivedSLs returns a copy of the service that accepts lines on
+// archived SLs. Reversals and revaluations book them, as they only settle
+// what was already posted on the account.
+func (s *VoucherService) withArchivedSLs() *VoucherService {
+	scoped := *s
+	scoped.archivedSLs = true
+	return &scoped
+}
+
+func (s *VoucherService) CreateVoucher(actor auth.Principal, req *voucher.InsertRequest) (*dtos.VoucherWithItemsDto, error) {
+	return s.CreateVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) CreateVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.InsertRequest) (*dtos.VoucherWithItemsDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionCreate); err != nil {
 		return nil, err
 	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
 
-	voucherWithItemsDto, err := s.applyVoucherCreation(req)
+	creation, err := s.validateVoucherIdempotencyKey(req)
 	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	replayed, err := s.replayVoucherCreation(creation)
+	if errors.Is(err, constants.ErrIdempotencyKeyReused) {
+		return nil, err
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while replaying voucher creation: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+	if replayed != nil {
+		return replayed, nil
+	}
+
+	if err := s.validateInsertVoucherRequest(req); err != nil {
+		// A concurrent request with the same key may have created the voucher
+		// since the key was looked up.
+		if errors.Is(err, constants.ErrVoucherNumberExists) {
+			if replayed, replayErr := s.replayVoucherCreation(creation); replayErr == nil && replayed != nil {
+				return replayed, nil
+			}
+		}
+		return nil, contextError(ctx, err)
+	}
+
+	voucherWithItemsDto, err := s.applyVoucherCreation(actor, req, nil, false, creation)
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		voucherWithItemsDto, err = s.replayVoucherCreation(creation)
+	}
+	if errors.Is(err, constants.ErrVoucherNumberExists) || errors.Is(err, constants.ErrTransactionConflict) || errors.Is(err, constants.ErrIdempotencyKeyReused) {
+		return nil, contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while creating voucher: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
@@ -31,14 +138,30 @@ func (s *VoucherService) CreateVoucher(req *voucher.InsertRequest) (*dtos.Vouche
 	return voucherWithItemsDto, nil
 }
 
-func (s *VoucherService) UpdateVoucher(req *voucher.UpdateRequest) (*dtos.VoucherDto, error) {
+func (s *VoucherService) UpdateVoucher(actor auth.Principal, req *voucher.UpdateRequest) (*dtos.VoucherDto, error) {
+	return s.UpdateVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) UpdateVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.UpdateRequest) (*dtos.VoucherDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetVoucher, err := s.validateUpdateVoucherRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 
-	voucherDto, err := s.applyVoucherUpdate(req, targetVoucher)
+	voucherDto, err := s.applyVoucherUpdate(actor, req, targetVoucher)
+	if errors.Is(err, constants.ErrVersionOutdated) || errors.Is(err, constants.ErrVoucherNumberExists) || errors.Is(err, constants.ErrDebitCreditMismatch) ||
+		errors.Is(err, constants.ErrVoucherItemsCountOutOfRange) || errors.Is(err, constants.ErrAmountOverflow) || errors.Is(err, constants.ErrTransactionConflict) {
+		return nil, contextError(ctx, err)
+	}
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while updating voucher: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
@@ -46,13 +169,30 @@ func (s *VoucherService) UpdateVoucher(req *voucher.UpdateRequest) (*dtos.Vouche
 	return voucherDto, nil
 }
 
-func (s *VoucherService) DeleteVoucher(req *voucher.DeleteRequest) error {
+func (s *VoucherService) DeleteVoucher(actor auth.Principal, req *voucher.DeleteRequest) error {
+	return s.DeleteVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) DeleteVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetVoucher, err := s.validateDeleteVoucherRequest(req)
 	if err != nil {
-		return err
+		return contextError(ctx, err)
+	}
+	if targetVoucher.Status == voucher.StatusPosted {
+		if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionDeletePosted); err != nil {
+			return err
+		}
 	}
 
 	if err := s.applyVoucherDeletion(targetVoucher); err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
 		log.Printf("unexpected error while deleting voucher: %v", err)
 		return constants.ErrUnexpectedError
 	}
@@ -60,17 +200,172 @@ func (s *VoucherService) DeleteVoucher(req *voucher.DeleteRequest) error {
 	return nil
 }
 
-func (s *VoucherService) GetVoucher(req *voucher.GetRequest) (*dtos.VoucherWithItemsDto, error) {
+func (s *VoucherService) GetVoucher(actor auth.Principal, req *voucher.GetRequest) (*dtos.VoucherWithItemsDto, error) {
+	return s.GetVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) GetVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.GetRequest) (*dtos.VoucherWithItemsDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
 	targetVoucher, err := s.validateGetVoucherRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 
-	voucherWithItemsDto, err := s.applyVoucherGet(targetVoucher)
+	voucherWithItemsDto, err := s.applyVoucherGet(targetVoucher, req.Calendar)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
 		log.Printf("unexpected error while getting voucher: %v", err)
 		return nil, constants.ErrUnexpectedError
 	}
 
 	return voucherWithItemsDto, nil
 }
+
+func (s *VoucherService) ReverseVoucher(actor auth.Principal, req *voucher.ReverseRequest) (*dtos.VoucherWithItemsDto, error) {
+	return s.ReverseVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) ReverseVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.ReverseRequest) (*dtos.VoucherWithItemsDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionReverse); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	insertReq, err := s.validateReverseVoucherRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	voucherWithItemsDto, err := s.applyVoucherCreation(actor, insertReq, &req.ID, false, nil)
+	if errors.Is(err, constants.ErrVoucherNumberExists) || errors.Is(err, constants.ErrVoucherAlreadyReversed) || errors.Is(err, constants.ErrTransactionConflict) {
+		return nil, contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while reversing voucher: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherWithItemsDto, nil
+}
+
+func (s *VoucherService) CopyVoucher(actor auth.Principal, req *voucher.CopyRequest) (*dtos.VoucherWithItemsDto, error) {
+	return s.CopyVoucherContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) CopyVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.CopyRequest) (*dtos.VoucherWithItemsDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	insertReq, err := s.validateCopyVoucherRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	// A copy is a starting point to be edited, so it is not posted yet.
+	voucherWithItemsDto, err := s.applyVoucherCreation(actor, insertReq, nil, true, nil)
+	if errors.Is(err, constants.ErrVoucherNumberExists) || errors.Is(err, constants.ErrTransactionConflict) {
+		return nil, contextError(ctx, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while copying voucher: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherWithItemsDto, nil
+}
+
+func (s *VoucherService) GetVoucherByNumber(actor auth.Principal, req *voucher.GetByNumberRequest) (*dtos.VoucherWithItemsDto, error) {
+	return s.GetVoucherByNumberContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) GetVoucherByNumberContext(ctx context.Context, actor auth.Principal, req *voucher.GetByNumberRequest) (*dtos.VoucherWithItemsDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetVoucher, err := s.validateGetVoucherByNumberRequest(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	voucherWithItemsDto, err := s.applyVoucherGet(targetVoucher, req.Calendar)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting voucher by number: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherWithItemsDto, nil
+}
+
+func (s *VoucherService) GetVouchers(actor auth.Principal, req *voucher.GetManyRequest) ([]dtos.VoucherWithItemsDto, error) {
+	return s.GetVouchersContext(context.Background(), actor, req)
+}
+
+func (s *VoucherService) GetVouchersContext(ctx context.Context, actor auth.Principal, req *voucher.GetManyRequest) ([]dtos.VoucherWithItemsDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	if err := s.validateGetVouchersRequest(req); err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	voucherDtos, err := s.applyVouchersGet(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while getting vouchers: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return voucherDtos, nil
+}
+
+func (s *VoucherService) ExportVoucher(actor auth.Principal, req *voucher.GetRequest, format export.Format, w io.Writer) error {
+	return s.ExportVoucherContext(context.Background(), actor, req, format, w)
+}
+
+func (s *VoucherService) ExportVoucherContext(ctx context.Context, actor auth.Principal, req *voucher.GetRequest, format export.Format, w io.Writer) error {
+	if err := auth.Authorize(actor, auth.EntityVoucher, auth.ActionRead); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID).withContext(ctx)
+
+	targetVoucher, err := s.validateExportVoucherRequest(req, format)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+
+	if err := s.applyVoucherExport(targetVoucher, req.Calendar, format, w); err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, err)
+		}
+		if errors.Is(err, constants.ErrAmountOverflow) {
+			return err
+		}
+		log.Printf("unexpected error while exporting voucher: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
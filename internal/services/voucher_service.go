@@ -1,51 +1,119 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/idempotency"
 	"accountingsystem/internal/mappers"
 	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/normalize"
 	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/textrule"
+	"accountingsystem/internal/txretry"
 	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-func (s *VoucherService) applyVoucherCreation(req *voucher.InsertRequest) (*dtos.VoucherWithItemsDto, error) {
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
+// applyVoucherCreation writes a voucher and its lines. A voucher created as
+// a draft stays one until it is updated or submitted, whatever the approval
+// rules say.
+func (s *VoucherService) applyVoucherCreation(actor auth.Principal, req *voucher.InsertRequest, reversalOfID *int, draft bool, creation *idempotentCreation) (*dtos.VoucherWithItemsDto, error) {
+	var voucherWithItemsDto *dtos.VoucherWithItemsDto
+	var createdVoucher *models.Voucher
+	var voucherItems []models.VoucherItem
 
-	voucher, err := s.insertVoucher(tx, req.Number)
-	if err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+	// The runner falls back to a savepoint when the service is bound to an
+	// outer transaction, so callers can group several vouchers atomically.
+	err := txretry.Run(s.db, func(tx *gorm.DB) error {
+		if err := creation.claim(tx); err != nil {
+			return err
+		}
+
+		date := s.dateOrToday(req.Date)
+		number, err := s.resolveVoucherNumber(tx, req, date)
+		if err != nil {
+			return err
+		}
+
+		createdVoucher, err = s.insertVoucher(tx, actor, number, date, reversalOfID)
+		if err != nil {
+			return err
+		}
+
+		voucherItems, err = s.insertVoucherItems(tx, createdVoucher.ID, req.VoucherItems)
+		if err != nil {
+			return err
+		}
+
+		if err := s.applyApprovalRules(tx, createdVoucher, draft); err != nil {
+			return err
+		}
 
-	voucherItems, err := s.insertVoucherItems(tx, voucher.ID, req.VoucherItems)
+		voucherWithItemsDto = mappers.ToVoucherWithItemsDto(createdVoucher, voucherItems, req.Calendar)
+		if err := recordEvent(tx, s.tenantID, events.VoucherCreated, events.AggregateVoucher, createdVoucher.ID, voucherWithItemsDto); err != nil {
+			return err
+		}
+		if err := s.recordVoucherPosted(tx, "", createdVoucher); err != nil {
+			return err
+		}
+		return creation.record(tx, voucherWithItemsDto)
+	})
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 
-	if err := tx.Commit().Error; err != nil {
+	return voucherWithItemsDto, nil
+}
+
+func (s *VoucherService) validateVoucherIdempotencyKey(req *voucher.InsertRequest) (*idempotentCreation, error) {
+	payload := *req
+	payload.IdempotencyKey = ""
+	return newIdempotentCreation(s.db, s.tenantID, idempotency.ScopeVoucher, req.IdempotencyKey, payload)
+}
+
+// replayVoucherCreation returns the voucher recorded for the idempotency key
+// of the request, or nil when there is none.
+func (s *VoucherService) replayVoucherCreation(creation *idempotentCreation) (*dtos.VoucherWithItemsDto, error) {
+	var voucherWithItemsDto dtos.VoucherWithItemsDto
+	found, err := creation.replay(&voucherWithItemsDto)
+	if err != nil || !found {
 		return nil, err
 	}
-
-	return mappers.ToVoucherWithItemsDto(voucher, voucherItems), nil
+	return &voucherWithItemsDto, nil
 }
 
-func (s *VoucherService) insertVoucher(tx *gorm.DB, number string) (*models.Voucher, error) {
+func (s *VoucherService) insertVoucher(tx *gorm.DB, actor auth.Principal, number string, date time.Time, reversalOfID *int) (*models.Voucher, error) {
 	voucher := &models.Voucher{
-		Number:     number,
-		RowVersion: 0,
+		TenantID:     s.tenantID,
+		Number:       number,
+		Date:         date,
+		ReversalOfID: s.convertToNullInt64(reversalOfID),
+		Status:       voucher.StatusPosted,
+		CreatedBy:    actor.Username,
+		UpdatedBy:    actor.Username,
+		RowVersion:   0,
 	}
 	if err := tx.Create(voucher).Error; err != nil {
-		log.Printf("Error creating voucher: %v", err)
-		return nil, constants.ErrUnexpectedError
+		// Two reversals of the same voucher may both pass validation; the
+		// unique index lets only the first one in.
+		if isUniqueViolation(err, "voucher_reversal_of_id_key") {
+			return nil, constants.ErrVoucherAlreadyReversed
+		}
+		return nil, err
 	}
 	return voucher, nil
 }
@@ -56,11 +124,16 @@ func (s *VoucherService) insertVoucherItems(tx *gorm.DB, voucherID int, items []
 	for _, item := range items {
 		dlID := s.convertToNullInt64(item.DLID)
 		voucherItems = append(voucherItems, models.VoucherItem{
-			VoucherID: voucherID,
-			SLID:      item.SLID,
-			DLID:      dlID,
-			Debit:     item.Debit,
-			Credit:    item.Credit,
+			TenantID:      s.tenantID,
+			VoucherID:     voucherID,
+			SLID:          item.SLID,
+			DLID:          dlID,
+			Debit:         item.Debit,
+			Credit:        item.Credit,
+			CurrencyCode:  s.convertToNullString(item.CurrencyCode),
+			ForeignDebit:  item.ForeignDebit,
+			ForeignCredit: item.ForeignCredit,
+			ExchangeRate:  s.convertToNullString(item.ExchangeRate),
 		})
 	}
 
@@ -80,14 +153,110 @@ func (s *VoucherService) convertToNullInt64(num *int) sql.NullInt64 {
 	return sql.NullInt64{Int64: int64(*num), Valid: true}
 }
 
+func (s *VoucherService) convertToNullString(text string) sql.NullString {
+	if text == "" {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: text, Valid: true}
+}
+
+func (s *VoucherService) dateOrToday(date time.Time) time.Time {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *VoucherService) resolveRequestDate(date *time.Time, input voucher.DateInput) error {
+	requestCalendar := calendar.OrDefault(input.Calendar)
+	if !requestCalendar.IsValid() {
+		return constants.ErrInvalidCalendar
+	}
+	if input.DateText == "" {
+		return nil
+	}
+	parsed, err := requestCalendar.Parse(input.DateText)
+	if err != nil {
+		return err
+	}
+	*date = parsed
+	return nil
+}
+
+func (s *VoucherService) resolveVoucherNumber(tx *gorm.DB, req *voucher.InsertRequest, date time.Time) (string, error) {
+	if !s.isNumberAllocatedFromSequence(req) {
+		return req.Number, nil
+	}
+	return s.allocateVoucherNumber(tx, req.SequenceCode, date)
+}
+
+func (s *VoucherService) isNumberAllocatedFromSequence(req *voucher.InsertRequest) bool {
+	return req.Number == "" && req.SequenceCode != ""
+}
+
+func (s *VoucherService) allocateVoucherNumber(tx *gorm.DB, sequenceCode string, at time.Time) (string, error) {
+	sequence, err := s.validateNumberSequenceExists(tx, sequenceCode)
+	if err != nil {
+		return "", err
+	}
+
+	fiscalYear := 0
+	if sequence.ResetYearly {
+		fiscalYear = sequence.Calendar.FiscalYearOf(at, sequence.FiscalYearStartMonth)
+	}
+
+	// The counter moves inside the creation transaction, so concurrent
+	// creators of a sequence wait for each other and a rolled back voucher
+	// gives its number back. Numbers already taken by vouchers numbered by
+	// hand are skipped.
+	for {
+		var lastValue int64
+		if err := tx.Raw(`INSERT INTO voucher_number_counter (tenant_id, sequence_id, fiscal_year, last_value) VALUES (?, ?, ?, 1)
+			ON CONFLICT (tenant_id, sequence_id, fiscal_year) DO UPDATE SET last_value = voucher_number_counter.last_value + 1
+			RETURNING last_value`, s.tenantID, sequence.ID, fiscalYear).Scan(&lastValue).Error; err != nil {
+			return "", err
+		}
+
+		number := s.formatVoucherNumber(sequence, at, lastValue)
+		err := s.validateVoucherNumberIsUniqueIn(tx, number)
+		if errors.Is(err, constants.ErrVoucherNumberExists) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return number, nil
+	}
+}
+
+func (s *VoucherService) formatVoucherNumber(sequence *models.NumberSequence, at time.Time, value int64) string {
+	prefix := strings.ReplaceAll(sequence.Prefix, "{year}", strconv.Itoa(sequence.Calendar.FiscalYearOf(at, sequence.FiscalYearStartMonth)))
+	return prefix + fmt.Sprintf("%0*d", sequence.Padding, value)
+}
+
+func (s *VoucherService) validateNumberSequenceExists(db *gorm.DB, code string) (*models.NumberSequence, error) {
+	var sequence models.NumberSequence
+	if err := db.Where("tenant_id = ? AND code = ?", s.tenantID, code).First(&sequence).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrNumberSequenceNotFound
+		}
+		return nil, err
+	}
+	return &sequence, nil
+}
+
 func (s *VoucherService) validateInsertVoucherRequest(req *voucher.InsertRequest) error {
-	if err := s.validateNumber(req.Number); err != nil {
+	req.Number = normalize.Text(req.Number)
+	if err := s.resolveRequestDate(&req.Date, req.DateInput); err != nil {
+		return err
+	}
+	if err := s.validateVoucherNumberOrSequence(req); err != nil {
 		return err
 	}
 	if err := s.validateVoucherItemsCountInInsertRequest(req.VoucherItems); err != nil {
 		return err
 	}
-	if err := s.validateVoucherNumberIsUnique(req.Number); err != nil {
+	if err := s.resolveVoucherItemInsertAmounts(req.VoucherItems, s.dateOrToday(req.Date)); err != nil {
 		return err
 	}
 	if err := s.validateVoucherItemInsertCreditBalance(req.VoucherItems); err != nil {
@@ -99,13 +268,52 @@ func (s *VoucherService) validateInsertVoucherRequest(req *voucher.InsertRequest
 	return nil
 }
 
-func (s *VoucherService) validateNumber(number string) error {
-	if number == "" || len(number) > 64 {
-		return constants.ErrNumberEmptyOrTooLong
+func (s *VoucherService) validateVoucherNumberOrSequence(req *voucher.InsertRequest) error {
+	if s.isNumberAllocatedFromSequence(req) {
+		_, err := s.validateNumberSequenceExists(s.db, req.SequenceCode)
+		return err
+	}
+	if err := s.validateNumber(req.Number); err != nil {
+		return err
+	}
+	if err := s.validateVoucherNumberIsNotReserved(req.Number); err != nil {
+		return err
+	}
+	if err := s.validateVoucherNumberIsUnique(req.Number); err != nil {
+		return err
 	}
 	return nil
 }
 
+// validateVoucherNumberIsNotReserved keeps numbers given by hand out of the
+// format of gapless sequences, which must hand out every number in turn.
+func (s *VoucherService) validateVoucherNumberIsNotReserved(number string) error {
+	var sequences []models.NumberSequence
+	if err := s.db.Where("tenant_id = ? AND gapless = ?", s.tenantID, true).Find(&sequences).Error; err != nil {
+		return err
+	}
+	for _, sequence := range sequences {
+		if s.voucherNumberPattern(&sequence).MatchString(number) {
+			return constants.ErrVoucherNumberReserved
+		}
+	}
+	return nil
+}
+
+// voucherNumberPattern matches the numbers a sequence formats, with any year
+// in place of {year}.
+func (s *VoucherService) voucherNumberPattern(sequence *models.NumberSequence) *regexp.Regexp {
+	parts := strings.Split(sequence.Prefix, "{year}")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile(`^` + strings.Join(parts, `\d+`) + `\d+$`)
+}
+
+func (s *VoucherService) validateNumber(number string) error {
+	return textrule.VoucherNumber.Validate(number)
+}
+
 func (s *VoucherService) validateVoucherItemsCountInInsertRequest(items []voucher.VoucherItemInsertDetail) error {
 	if err := s.validateCorrectVoucherItemNumber(len(items)); err != nil {
 		return err
@@ -121,8 +329,12 @@ func (s *VoucherService) validateCorrectVoucherItemNumber(number int) error {
 }
 
 func (s *VoucherService) validateVoucherNumberIsUnique(number string) error {
+	return s.validateVoucherNumberIsUniqueIn(s.db, number)
+}
+
+func (s *VoucherService) validateVoucherNumberIsUniqueIn(db *gorm.DB, number string) error {
 	var existingVoucher models.Voucher
-	if err := s.db.Where("number = ?", number).First(&existingVoucher).Error; err == nil {
+	if err := db.Where("tenant_id = ? AND number = ?", s.tenantID, number).First(&existingVoucher).Error; err == nil {
 		return constants.ErrVoucherNumberExists
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
@@ -131,7 +343,10 @@ func (s *VoucherService) validateVoucherNumberIsUnique(number string) error {
 }
 
 func (s *VoucherService) validateVoucherItemInsertCreditBalance(items []voucher.VoucherItemInsertDetail) error {
-	totalDebit, totalCredit := s.calculateInsertedBalances(items)
+	totalDebit, totalCredit, err := s.calculateInsertedBalances(items)
+	if err != nil {
+		return err
+	}
 
 	if totalDebit != totalCredit {
 		return constants.ErrDebitCreditMismatch
@@ -140,18 +355,50 @@ func (s *VoucherService) validateVoucherItemInsertCreditBalance(items []voucher.
 	return nil
 }
 
-func (s *VoucherService) calculateInsertedBalances(items []voucher.VoucherItemInsertDetail) (int, int) {
-	totalDebit := 0
-	totalCredit := 0
+func (s *VoucherService) calculateInsertedBalances(items []voucher.VoucherItemInsertDetail) (money.Amount, money.Amount, error) {
+	var totalDebit, totalCredit money.Amount
+	var err error
 	for _, item := range items {
-		totalDebit += item.Debit
-		totalCredit += item.Credit
+		if totalDebit, err = totalDebit.Add(item.Debit); err != nil {
+			return 0, 0, err
+		}
+		if totalCredit, err = totalCredit.Add(item.Credit); err != nil {
+			return 0, 0, err
+		}
+	}
+	return totalDebit, totalCredit, nil
+}
+
+func (s *VoucherService) resolveVoucherItemInsertAmounts(items []voucher.VoucherItemInsertDetail, date time.Time) error {
+	for i := range items {
+		item := &items[i]
+		debit, credit, err := s.resolveFunctionalAmounts(&item.CurrencyDetail, item.Debit, item.Credit, date)
+		if err != nil {
+			return err
+		}
+		item.Debit, item.Credit = debit, credit
+	}
+	return nil
+}
+
+func (s *VoucherService) resolveVoucherItemUpdateAmounts(items []voucher.VoucherItemUpdateDetail, date time.Time) error {
+	for i := range items {
+		item := &items[i]
+		debit, credit, err := s.resolveFunctionalAmounts(&item.CurrencyDetail, item.Debit, item.Credit, date)
+		if err != nil {
+			return err
+		}
+		item.Debit, item.Credit = debit, credit
 	}
-	return totalDebit, totalCredit
+	return nil
 }
 
 func (s *VoucherService) validateVoucherItemInsertDetails(items []voucher.VoucherItemInsertDetail) error {
-	for _, item := range items {
+	for i := range items {
+		item := &items[i]
+		if err := s.resolveVoucherItemReferences(item); err != nil {
+			return err
+		}
 		if err := s.validateDebitCredit(item.Debit, item.Credit); err != nil {
 			return err
 		}
@@ -162,7 +409,112 @@ func (s *VoucherService) validateVoucherItemInsertDetails(items []voucher.Vouche
 	return nil
 }
 
-func (s *VoucherService) validateDebitCredit(debit int, credit int) error {
+func (s *VoucherService) resolveVoucherItemReferences(item *voucher.VoucherItemInsertDetail) error {
+	if item.SLCode != nil {
+		sl, err := s.validateSLExistsByCode(*item.SLCode)
+		if err != nil {
+			return err
+		}
+		if item.SLID != 0 && item.SLID != sl.ID {
+			return constants.ErrSLReferenceMismatch
+		}
+		item.SLID = sl.ID
+	}
+
+	if item.DLCode != nil {
+		dl, err := s.validateDLExistsByCode(*item.DLCode)
+		if err != nil {
+			return err
+		}
+		if item.DLID != nil && *item.DLID != dl.ID {
+			return constants.ErrDLReferenceMismatch
+		}
+		item.DLID = &dl.ID
+	}
+
+	return nil
+}
+
+// resolveFunctionalAmounts derives the functional-currency debit and credit
+// of a foreign-currency line and fixes the exchange rate it was converted at.
+func (s *VoucherService) resolveFunctionalAmounts(detail *voucher.CurrencyDetail, debit money.Amount, credit money.Amount, date time.Time) (money.Amount, money.Amount, error) {
+	if detail.CurrencyCode == "" {
+		if detail.ForeignDebit != 0 || detail.ForeignCredit != 0 || detail.ExchangeRate != "" {
+			return 0, 0, constants.ErrCurrencyDetailsInvalid
+		}
+		return debit, credit, nil
+	}
+
+	rate, err := s.resolveExchangeRate(detail.CurrencyCode, detail.ExchangeRate, date)
+	if err != nil {
+		return 0, 0, err
+	}
+	if detail.ForeignDebit == 0 && detail.ForeignCredit == 0 {
+		if !s.revaluationLines {
+			return 0, 0, constants.ErrForeignAmountRequired
+		}
+		detail.ExchangeRate = rate.FloatString(10)
+		return debit, credit, nil
+	}
+	if err := s.validateDebitCredit(detail.ForeignDebit, detail.ForeignCredit); err != nil {
+		return 0, 0, err
+	}
+
+	functionalDebit, err := detail.ForeignDebit.MulRat(rate)
+	if err != nil {
+		return 0, 0, err
+	}
+	functionalCredit, err := detail.ForeignCredit.MulRat(rate)
+	if err != nil {
+		return 0, 0, err
+	}
+	if (debit != 0 || credit != 0) && (debit != functionalDebit || credit != functionalCredit) {
+		return 0, 0, constants.ErrFunctionalAmountMismatch
+	}
+
+	detail.ExchangeRate = rate.FloatString(10)
+	return functionalDebit, functionalCredit, nil
+}
+
+func (s *VoucherService) resolveExchangeRate(currencyCode string, exchangeRate string, date time.Time) (*big.Rat, error) {
+	targetCurrency, err := s.currencyService.validateCurrencyExistsByCode(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if exchangeRate != "" {
+		return s.currencyService.parseExchangeRate(exchangeRate)
+	}
+
+	currencyRate, err := s.currencyService.findExchangeRate(targetCurrency.ID, date)
+	if err != nil {
+		return nil, err
+	}
+	return s.currencyService.parseExchangeRate(currencyRate.Rate)
+}
+
+func (s *VoucherService) validateSLExistsByCode(code string) (*models.SL, error) {
+	var sl models.SL
+	if err := s.db.Where("tenant_id = ? AND code = ?", s.tenantID, normalize.Text(code)).First(&sl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrSLNotFound
+		}
+		return nil, err
+	}
+	return &sl, nil
+}
+
+func (s *VoucherService) validateDLExistsByCode(code string) (*models.DL, error) {
+	var dl models.DL
+	if err := s.db.Where("tenant_id = ? AND code = ?", s.tenantID, normalize.Text(code)).First(&dl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrDLNotFound
+		}
+		return nil, err
+	}
+	return &dl, nil
+}
+
+func (s *VoucherService) validateDebitCredit(debit money.Amount, credit money.Amount) error {
 	isValidDebitCredit := (debit == 0 && credit > 0) || (debit > 0 && credit == 0)
 	if !isValidDebitCredit {
 		return constants.ErrDebitOrCreditInvalid
@@ -175,6 +527,9 @@ func (s *VoucherService) validateSLAndDL(SLID int, DLID *int) error {
 	if err != nil {
 		return err
 	}
+	if sl.ArchivedAt.Valid && !s.archivedSLs {
+		return constants.ErrSLArchived
+	}
 
 	if err := s.validateDLRequirement(sl.HasDL, DLID); err != nil {
 		return err
@@ -191,7 +546,7 @@ func (s *VoucherService) validateSLAndDL(SLID int, DLID *int) error {
 
 func (s *VoucherService) validateSLExists(SLID int) (*models.SL, error) {
 	var sl models.SL
-	if err := s.db.First(&sl, SLID).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, SLID).First(&sl).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, constants.ErrSLNotFound
 		}
@@ -211,7 +566,7 @@ func (s *VoucherService) validateDLRequirement(SLhasDL bool, DLID *int) error {
 
 func (s *VoucherService) validateDLExists(DLID int) error {
 	var dl models.DL
-	if err := s.db.First(&dl, DLID).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, DLID).First(&dl).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return constants.ErrDLNotFound
 		}
@@ -220,73 +575,163 @@ func (s *VoucherService) validateDLExists(DLID int) error {
 	return nil
 }
 
-func (s *VoucherService) applyVoucherUpdate(req *voucher.UpdateRequest, targetVoucher *models.Voucher) (*dtos.VoucherDto, error) {
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
+// applyVoucherUpdate writes the voucher only while it still has the version
+// it was validated at. The conditional update locks the voucher, so its lines
+// cannot change under the transaction and the balance is checked again on
+// what the transaction sees.
+func (s *VoucherService) applyVoucherUpdate(actor auth.Principal, req *voucher.UpdateRequest, targetVoucher *models.Voucher) (*dtos.VoucherDto, error) {
+	previousStatus := targetVoucher.Status
+	err := txretry.Run(s.db, func(tx *gorm.DB) error {
+		targetVoucher.Number = req.Number
+		targetVoucher.Date = s.updatedVoucherDate(req.Date, targetVoucher)
+		targetVoucher.UpdatedBy = actor.Username
+		targetVoucher.RowVersion = req.Version + 1
+
+		result := tx.Model(&models.Voucher{}).
+			Where("tenant_id = ? AND id = ? AND row_version = ?", s.tenantID, targetVoucher.ID, req.Version).
+			Updates(map[string]interface{}{
+				"number":      targetVoucher.Number,
+				"date":        targetVoucher.Date,
+				"updated_by":  targetVoucher.UpdatedBy,
+				"row_version": targetVoucher.RowVersion,
+			})
+		if isUniqueViolation(result.Error, "voucher_tenant_id_number_key") {
+			return constants.ErrVoucherNumberExists
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return constants.ErrVersionOutdated
+		}
 
-	targetVoucher.Number = req.Number
-	targetVoucher.RowVersion++
+		txService := s.inTransaction(tx)
+		if err := txService.validateVoucherItemsCountInUpdateRequest(req.Items, targetVoucher.ID); err != nil {
+			return err
+		}
+		if err := txService.validateVoucherUpdateDebitCreditBalance(req.Items); err != nil {
+			return err
+		}
 
-	if err := tx.Save(targetVoucher).Error; err != nil {
-		tx.Rollback()
+		itemsBefore, err := s.loadVoucherItems(tx, targetVoucher.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.applyVoucherItemChanges(tx, req, targetVoucher); err != nil {
+			return err
+		}
+		if err := s.applyApprovalRules(tx, targetVoucher, false); err != nil {
+			return err
+		}
+		itemsAfter, err := s.loadVoucherItems(tx, targetVoucher.ID)
+		if err != nil {
+			return err
+		}
+		payload := s.voucherUpdatedPayload(targetVoucher, itemsBefore, itemsAfter)
+		if err := recordEvent(tx, s.tenantID, events.VoucherUpdated, events.AggregateVoucher, targetVoucher.ID, payload); err != nil {
+			return err
+		}
+		return s.recordVoucherPosted(tx, previousStatus, targetVoucher)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.applyVoucherItemChanges(tx, req, targetVoucher); err != nil {
-		tx.Rollback()
-		return nil, err
+	return mappers.ToVoucherDto(targetVoucher, req.Calendar), nil
+}
+
+// recordVoucherPosted raises VoucherPosted when a change moved the voucher
+// from previousStatus into the books.
+func (s *VoucherService) recordVoucherPosted(tx *gorm.DB, previousStatus string, targetVoucher *models.Voucher) error {
+	if previousStatus == voucher.StatusPosted || targetVoucher.Status != voucher.StatusPosted {
+		return nil
 	}
+	return recordEvent(tx, s.tenantID, events.VoucherPosted, events.AggregateVoucher, targetVoucher.ID, mappers.ToVoucherDto(targetVoucher, ""))
+}
 
-	if err := tx.Commit().Error; err != nil {
+func (s *VoucherService) loadVoucherItems(tx *gorm.DB, voucherID int) ([]models.VoucherItem, error) {
+	var items []models.VoucherItem
+	if err := tx.Where("tenant_id = ? AND voucher_id = ?", s.tenantID, voucherID).Order("id").Find(&items).Error; err != nil {
 		return nil, err
 	}
+	return items, nil
+}
+
+// voucherUpdatedPayload compares the lines of a voucher before and after an
+// update by their ID. Lines whose values did not change are left out.
+func (s *VoucherService) voucherUpdatedPayload(targetVoucher *models.Voucher, itemsBefore []models.VoucherItem, itemsAfter []models.VoucherItem) *events.VoucherUpdatedPayload {
+	payload := &events.VoucherUpdatedPayload{Voucher: *mappers.ToVoucherDto(targetVoucher, "")}
 
-	return mappers.ToVoucherDto(targetVoucher), nil
+	before := make(map[int]*dtos.VoucherItemDto, len(itemsBefore))
+	for i := range itemsBefore {
+		before[itemsBefore[i].ID] = mappers.ToVoucherItemDto(&itemsBefore[i])
+	}
+	for i := range itemsAfter {
+		after := mappers.ToVoucherItemDto(&itemsAfter[i])
+		previous, existed := before[after.ID]
+		delete(before, after.ID)
+		switch {
+		case !existed:
+			payload.InsertedItems = append(payload.InsertedItems, *after)
+		case *previous != *after:
+			payload.UpdatedItems = append(payload.UpdatedItems, events.VoucherItemChange{Before: *previous, After: *after})
+		}
+	}
+	for i := range itemsBefore {
+		if deleted, ok := before[itemsBefore[i].ID]; ok {
+			payload.DeletedItems = append(payload.DeletedItems, *deleted)
+		}
+	}
+	return payload
 }
 
 func (s *VoucherService) applyVoucherItemChanges(tx *gorm.DB, req *voucher.UpdateRequest, existingVoucher *models.Voucher) error {
-	if err := s.deleteVoucherItems(tx, req.Items.Deleted); err != nil {
+	if err := s.deleteVoucherItems(tx, existingVoucher.ID, req.Items.Deleted); err != nil {
 		return err
 	}
 	if _, err := s.insertVoucherItems(tx, existingVoucher.ID, req.Items.Inserted); err != nil {
 		return err
 	}
-	if err := s.updateVoucherItems(tx, req.Items.Updated); err != nil {
+	if err := s.updateVoucherItems(tx, existingVoucher.ID, req.Items.Updated); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *VoucherService) deleteVoucherItems(tx *gorm.DB, itemIDs []int) error {
+func (s *VoucherService) deleteVoucherItems(tx *gorm.DB, voucherID int, itemIDs []int) error {
 	if len(itemIDs) == 0 {
 		return nil
 	}
 
-	if err := tx.Where("id IN ?", itemIDs).Delete(&models.VoucherItem{}).Error; err != nil {
+	if err := tx.Where("tenant_id = ? AND voucher_id = ? AND id IN ?", s.tenantID, voucherID, itemIDs).Delete(&models.VoucherItem{}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *VoucherService) updateVoucherItems(tx *gorm.DB, items []voucher.VoucherItemUpdateDetail) error {
+func (s *VoucherService) updateVoucherItems(tx *gorm.DB, voucherID int, items []voucher.VoucherItemUpdateDetail) error {
 	for _, item := range items {
-		if err := s.updateVoucherItem(tx, item); err != nil {
+		if err := s.updateVoucherItem(tx, voucherID, item); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (s *VoucherService) updateVoucherItem(tx *gorm.DB, item voucher.VoucherItemUpdateDetail) error {
+func (s *VoucherService) updateVoucherItem(tx *gorm.DB, voucherID int, item voucher.VoucherItemUpdateDetail) error {
 	var currentItem models.VoucherItem
-	tx.First(&currentItem, item.ID)
+	if err := tx.Where("tenant_id = ? AND voucher_id = ? AND id = ?", s.tenantID, voucherID, item.ID).First(&currentItem).Error; err != nil {
+		return err
+	}
 
 	currentItem.SLID = item.SLID
 	currentItem.DLID = s.convertToNullInt64(item.DLID)
 	currentItem.Debit = item.Debit
 	currentItem.Credit = item.Credit
+	currentItem.CurrencyCode = s.convertToNullString(item.CurrencyCode)
+	currentItem.ForeignDebit = item.ForeignDebit
+	currentItem.ForeignCredit = item.ForeignCredit
+	currentItem.ExchangeRate = s.convertToNullString(item.ExchangeRate)
 
 	if err := tx.Save(&currentItem).Error; err != nil {
 		return err
@@ -295,6 +740,10 @@ func (s *VoucherService) updateVoucherItem(tx *gorm.DB, item voucher.VoucherItem
 }
 
 func (s *VoucherService) validateUpdateVoucherRequest(req *voucher.UpdateRequest) (*models.Voucher, error) {
+	req.Number = normalize.Text(req.Number)
+	if err := s.resolveRequestDate(&req.Date, req.DateInput); err != nil {
+		return nil, err
+	}
 	if err := s.validateNumber(req.Number); err != nil {
 		return nil, err
 	}
@@ -305,18 +754,33 @@ func (s *VoucherService) validateUpdateVoucherRequest(req *voucher.UpdateRequest
 	if err := s.validateVersion(targetVoucher.RowVersion, req.Version); err != nil {
 		return nil, err
 	}
+	if req.Number != targetVoucher.Number {
+		if err := s.validateVoucherNumberIsNotReserved(req.Number); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateVoucherStatus(targetVoucher, voucher.StatusDraft, voucher.StatusRejected, voucher.StatusPosted); err != nil {
+		return nil, err
+	}
 	if err := s.validateVoucherItemsCountInUpdateRequest(req.Items, req.ID); err != nil {
 		return nil, err
 	}
-	if err := s.validateVoucherItemsInUpdateRequest(req.Items); err != nil {
+	if err := s.validateVoucherItemsInUpdateRequest(req.Items, targetVoucher.ID, s.updatedVoucherDate(req.Date, targetVoucher)); err != nil {
 		return nil, err
 	}
 	return targetVoucher, nil
 }
 
+func (s *VoucherService) updatedVoucherDate(date time.Time, targetVoucher *models.Voucher) time.Time {
+	if date.IsZero() {
+		return targetVoucher.Date
+	}
+	return s.dateOrToday(date)
+}
+
 func (s *VoucherService) validateVoucherExists(id int) (*models.Voucher, error) {
 	var existingVoucher models.Voucher
-	if err := s.db.Where("id = ?", id).First(&existingVoucher).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&existingVoucher).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, constants.ErrVoucherNotFound
 		}
@@ -335,7 +799,7 @@ func (s *VoucherService) validateVersion(existingVersion int, requestVersion int
 func (s *VoucherService) validateVoucherItemsCountInUpdateRequest(items voucher.VoucherItemsUpdate, voucherID int) error {
 	newItemsCount := len(items.Inserted) - len(items.Deleted)
 	var existingItemsCount int64 = 0
-	if err := s.db.Model(&models.VoucherItem{}).Where("voucher_id = ?", voucherID).Count(&existingItemsCount).Error; err != nil {
+	if err := s.db.Model(&models.VoucherItem{}).Where("tenant_id = ? AND voucher_id = ?", s.tenantID, voucherID).Count(&existingItemsCount).Error; err != nil {
 		return err
 	}
 
@@ -346,14 +810,20 @@ func (s *VoucherService) validateVoucherItemsCountInUpdateRequest(items voucher.
 	return nil
 }
 
-func (s *VoucherService) validateVoucherItemsInUpdateRequest(items voucher.VoucherItemsUpdate) error {
+func (s *VoucherService) validateVoucherItemsInUpdateRequest(items voucher.VoucherItemsUpdate, voucherID int, date time.Time) error {
+	if err := s.resolveVoucherItemInsertAmounts(items.Inserted, date); err != nil {
+		return err
+	}
+	if err := s.resolveVoucherItemUpdateAmounts(items.Updated, date); err != nil {
+		return err
+	}
 	if err := s.validateVoucherItemInsertDetails(items.Inserted); err != nil {
 		return err
 	}
-	if err := s.validateVoucherItemUpdateDetails(items.Updated); err != nil {
+	if err := s.validateVoucherItemUpdateDetails(items.Updated, voucherID); err != nil {
 		return err
 	}
-	if err := s.validateVoucherItemDeleteDetails(items.Deleted); err != nil {
+	if err := s.validateVoucherItemDeleteDetails(items.Deleted, voucherID); err != nil {
 		return err
 	}
 	if err := s.validateVoucherUpdateDebitCreditBalance(items); err != nil {
@@ -363,9 +833,9 @@ func (s *VoucherService) validateVoucherItemsInUpdateRequest(items voucher.Vouch
 	return nil
 }
 
-func (s *VoucherService) validateVoucherItemUpdateDetails(items []voucher.VoucherItemUpdateDetail) error {
+func (s *VoucherService) validateVoucherItemUpdateDetails(items []voucher.VoucherItemUpdateDetail, voucherID int) error {
 	for _, item := range items {
-		if err := s.validateVoucherItemExists(item.ID); err != nil {
+		if err := s.validateVoucherItemExists(voucherID, item.ID); err != nil {
 			return err
 		}
 		if err := s.validateDebitCredit(item.Debit, item.Credit); err != nil {
@@ -378,9 +848,11 @@ func (s *VoucherService) validateVoucherItemUpdateDetails(items []voucher.Vouche
 	return nil
 }
 
-func (s *VoucherService) validateVoucherItemExists(itemID int) error {
+// validateVoucherItemExists checks that the line belongs to the voucher being
+// updated, so that an update cannot change the lines of another voucher.
+func (s *VoucherService) validateVoucherItemExists(voucherID int, itemID int) error {
 	var existingItem models.VoucherItem
-	if err := s.db.First(&existingItem, itemID).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND voucher_id = ? AND id = ?", s.tenantID, voucherID, itemID).First(&existingItem).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return constants.ErrVoucherItemNotFound
 		}
@@ -389,9 +861,9 @@ func (s *VoucherService) validateVoucherItemExists(itemID int) error {
 	return nil
 }
 
-func (s *VoucherService) validateVoucherItemDeleteDetails(items []int) error {
+func (s *VoucherService) validateVoucherItemDeleteDetails(items []int, voucherID int) error {
 	for _, itemID := range items {
-		if err := s.validateVoucherItemExists(itemID); err != nil {
+		if err := s.validateVoucherItemExists(voucherID, itemID); err != nil {
 			return err
 		}
 	}
@@ -399,12 +871,27 @@ func (s *VoucherService) validateVoucherItemDeleteDetails(items []int) error {
 }
 
 func (s *VoucherService) validateVoucherUpdateDebitCreditBalance(items voucher.VoucherItemsUpdate) error {
-	totalDebitAddedInInsert, totalCreditAddedInInsert := s.calculateInsertedBalances(items.Inserted)
-	totalDebitAddedInUpdate, totalCreditAddedInUpdate := s.calculateUpdatedBalances(items.Updated)
-	totalDebitAddedInDelete, totalCreditAddedInDelete := s.calculateDeletedBalances(items.Deleted)
+	totalDebitAddedInInsert, totalCreditAddedInInsert, err := s.calculateInsertedBalances(items.Inserted)
+	if err != nil {
+		return err
+	}
+	totalDebitAddedInUpdate, totalCreditAddedInUpdate, err := s.calculateUpdatedBalances(items.Updated)
+	if err != nil {
+		return err
+	}
+	totalDebitAddedInDelete, totalCreditAddedInDelete, err := s.calculateDeletedBalances(items.Deleted)
+	if err != nil {
+		return err
+	}
 
-	totalDebitAdded := totalDebitAddedInInsert + totalDebitAddedInUpdate + totalDebitAddedInDelete
-	totalCreditAdded := totalCreditAddedInInsert + totalCreditAddedInUpdate + totalCreditAddedInDelete
+	totalDebitAdded, err := money.Sum(totalDebitAddedInInsert, totalDebitAddedInUpdate, totalDebitAddedInDelete)
+	if err != nil {
+		return err
+	}
+	totalCreditAdded, err := money.Sum(totalCreditAddedInInsert, totalCreditAddedInUpdate, totalCreditAddedInDelete)
+	if err != nil {
+		return err
+	}
 
 	if totalDebitAdded != totalCreditAdded {
 		return constants.ErrDebitCreditMismatch
@@ -413,32 +900,58 @@ func (s *VoucherService) validateVoucherUpdateDebitCreditBalance(items voucher.V
 	return nil
 }
 
-func (s *VoucherService) calculateUpdatedBalances(items []voucher.VoucherItemUpdateDetail) (int, int) {
-	totalDebit := 0
-	totalCredit := 0
+func (s *VoucherService) calculateUpdatedBalances(items []voucher.VoucherItemUpdateDetail) (money.Amount, money.Amount, error) {
+	var totalDebit, totalCredit money.Amount
 	for _, item := range items {
 		var currentItem models.VoucherItem
-		s.db.First(&currentItem, item.ID)
-		totalDebit += item.Debit - currentItem.Debit
-		totalCredit += item.Credit - currentItem.Credit
+		s.db.Where("tenant_id = ? AND id = ?", s.tenantID, item.ID).First(&currentItem)
+
+		debitChange, err := item.Debit.Sub(currentItem.Debit)
+		if err != nil {
+			return 0, 0, err
+		}
+		creditChange, err := item.Credit.Sub(currentItem.Credit)
+		if err != nil {
+			return 0, 0, err
+		}
+		if totalDebit, err = totalDebit.Add(debitChange); err != nil {
+			return 0, 0, err
+		}
+		if totalCredit, err = totalCredit.Add(creditChange); err != nil {
+			return 0, 0, err
+		}
 	}
-	return totalDebit, totalCredit
+	return totalDebit, totalCredit, nil
 }
 
-func (s *VoucherService) calculateDeletedBalances(items []int) (int, int) {
-	totalDebit := 0
-	totalCredit := 0
+func (s *VoucherService) calculateDeletedBalances(items []int) (money.Amount, money.Amount, error) {
+	var totalDebit, totalCredit money.Amount
+	var err error
 	for _, itemID := range items {
 		var currentItem models.VoucherItem
-		s.db.First(&currentItem, itemID)
-		totalDebit -= currentItem.Debit
-		totalCredit -= currentItem.Credit
+		s.db.Where("tenant_id = ? AND id = ?", s.tenantID, itemID).First(&currentItem)
+		if totalDebit, err = totalDebit.Sub(currentItem.Debit); err != nil {
+			return 0, 0, err
+		}
+		if totalCredit, err = totalCredit.Sub(currentItem.Credit); err != nil {
+			return 0, 0, err
+		}
 	}
-	return totalDebit, totalCredit
+	return totalDebit, totalCredit, nil
 }
 
 func (s *VoucherService) applyVoucherDeletion(targetVoucher *models.Voucher) error {
-	if err := s.db.Delete(&targetVoucher).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		voucherItems, err := s.loadVoucherItems(tx, targetVoucher.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&targetVoucher).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, s.tenantID, events.VoucherDeleted, events.AggregateVoucher, targetVoucher.ID, mappers.ToVoucherWithItemsDto(targetVoucher, voucherItems, ""))
+	})
+	if err != nil {
 		return constants.ErrUnexpectedError
 	}
 	return nil
@@ -452,19 +965,32 @@ func (s *VoucherService) validateDeleteVoucherRequest(req *voucher.DeleteRequest
 	if err := s.validateVersion(targetVoucher.RowVersion, req.Version); err != nil {
 		return nil, err
 	}
+	if err := s.validateVoucherStatus(targetVoucher, voucher.StatusDraft, voucher.StatusRejected, voucher.StatusPosted); err != nil {
+		return nil, err
+	}
 	return targetVoucher, nil
 }
 
-func (s *VoucherService) applyVoucherGet(targetVoucher *models.Voucher) (*dtos.VoucherWithItemsDto, error) {
+func (s *VoucherService) applyVoucherGet(targetVoucher *models.Voucher, dateCalendar calendar.Calendar) (*dtos.VoucherWithItemsDto, error) {
 	var voucherItems []models.VoucherItem
-	if err := s.db.Where("voucher_id = ?", targetVoucher.ID).Find(&voucherItems).Error; err != nil {
+	if err := s.db.Where("tenant_id = ? AND voucher_id = ?", s.tenantID, targetVoucher.ID).Find(&voucherItems).Error; err != nil {
 		return nil, err
 	}
 
-	return mappers.ToVoucherWithItemsDto(targetVoucher, voucherItems), nil
+	return mappers.ToVoucherWithItemsDto(targetVoucher, voucherItems, dateCalendar), nil
+}
+
+func (s *VoucherService) validateCalendar(dateCalendar calendar.Calendar) error {
+	if !calendar.OrDefault(dateCalendar).IsValid() {
+		return constants.ErrInvalidCalendar
+	}
+	return nil
 }
 
 func (s *VoucherService) validateGetVoucherRequest(req *voucher.GetRequest) (*models.Voucher, error) {
+	if err := s.validateCalendar(req.Calendar); err != nil {
+		return nil, err
+	}
 	targetVoucher, err := s.validateVoucherExists(req.ID)
 	if err != nil {
 		return nil, err
@@ -472,3 +998,253 @@ func (s *VoucherService) validateGetVoucherRequest(req *voucher.GetRequest) (*mo
 
 	return targetVoucher, nil
 }
+
+func (s *VoucherService) validateGetVoucherByNumberRequest(req *voucher.GetByNumberRequest) (*models.Voucher, error) {
+	if err := s.validateCalendar(req.Calendar); err != nil {
+		return nil, err
+	}
+	var targetVoucher models.Voucher
+	if err := s.db.Where("tenant_id = ? AND number = ?", s.tenantID, normalize.Text(req.Number)).First(&targetVoucher).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+
+	return &targetVoucher, nil
+}
+
+func (s *VoucherService) validateGetVouchersRequest(req *voucher.GetManyRequest) error {
+	if err := s.validateCalendar(req.Calendar); err != nil {
+		return err
+	}
+	if len(req.IDs) > constants.MaxLookupIDs {
+		return constants.ErrTooManyIDs
+	}
+	return nil
+}
+
+// applyVouchersGet loads the vouchers and then the lines of all of them in a
+// second query.
+func (s *VoucherService) applyVouchersGet(req *voucher.GetManyRequest) ([]dtos.VoucherWithItemsDto, error) {
+	voucherDtos := []dtos.VoucherWithItemsDto{}
+	if len(req.IDs) == 0 {
+		return voucherDtos, nil
+	}
+
+	var vouchers []models.Voucher
+	if err := s.db.Where("tenant_id = ? AND id IN ?", s.tenantID, req.IDs).Order("id").Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	if len(vouchers) == 0 {
+		return voucherDtos, nil
+	}
+
+	voucherIDs := make([]int, len(vouchers))
+	for i := range vouchers {
+		voucherIDs[i] = vouchers[i].ID
+	}
+	var voucherItems []models.VoucherItem
+	if err := s.db.Where("tenant_id = ? AND voucher_id IN ?", s.tenantID, voucherIDs).Order("id").Find(&voucherItems).Error; err != nil {
+		return nil, err
+	}
+	itemsByVoucher := make(map[int][]models.VoucherItem, len(vouchers))
+	for _, item := range voucherItems {
+		itemsByVoucher[item.VoucherID] = append(itemsByVoucher[item.VoucherID], item)
+	}
+
+	for i := range vouchers {
+		voucherDtos = append(voucherDtos, *mappers.ToVoucherWithItemsDto(&vouchers[i], itemsByVoucher[vouchers[i].ID], req.Calendar))
+	}
+	return voucherDtos, nil
+}
+
+func (s *VoucherService) validateExportVoucherRequest(req *voucher.GetRequest, format export.Format) (*models.Voucher, error) {
+	if !format.IsValid() {
+		return nil, constants.ErrInvalidExportFormat
+	}
+	return s.validateGetVoucherRequest(req)
+}
+
+func (s *VoucherService) applyVoucherExport(targetVoucher *models.Voucher, dateCalendar calendar.Calendar, format export.Format, w io.Writer) error {
+	voucherWithItemsDto, err := s.applyVoucherGet(targetVoucher, dateCalendar)
+	if err != nil {
+		return err
+	}
+
+	sls, dls, err := s.loadVoucherItemAccounts(voucherWithItemsDto.VoucherItems)
+	if err != nil {
+		return err
+	}
+
+	return export.WriteVoucher(w, format, voucherWithItemsDto, sls, dls)
+}
+
+func (s *VoucherService) loadVoucherItemAccounts(items []dtos.VoucherItemDto) (map[int]dtos.SLDto, map[int]dtos.DLDto, error) {
+	var slIDs, dlIDs []int
+	for _, item := range items {
+		slIDs = append(slIDs, item.SLID)
+		if item.DLID != 0 {
+			dlIDs = append(dlIDs, item.DLID)
+		}
+	}
+
+	var sls []models.SL
+	if err := s.db.Where("tenant_id = ? AND id IN ?", s.tenantID, slIDs).Find(&sls).Error; err != nil {
+		return nil, nil, err
+	}
+	slDtos := make(map[int]dtos.SLDto, len(sls))
+	for i := range sls {
+		slDtos[sls[i].ID] = *mappers.ToSlDto(&sls[i])
+	}
+
+	dlDtos := map[int]dtos.DLDto{}
+	if len(dlIDs) > 0 {
+		var dls []models.DL
+		if err := s.db.Where("tenant_id = ? AND id IN ?", s.tenantID, dlIDs).Find(&dls).Error; err != nil {
+			return nil, nil, err
+		}
+		for i := range dls {
+			dlDtos[dls[i].ID] = *mappers.ToDLDto(&dls[i])
+		}
+	}
+
+	return slDtos, dlDtos, nil
+}
+
+func (s *VoucherService) validateReverseVoucherRequest(req *voucher.ReverseRequest) (*voucher.InsertRequest, error) {
+	if err := s.resolveRequestDate(&req.Date, req.DateInput); err != nil {
+		return nil, err
+	}
+	targetVoucher, err := s.validateVoucherExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(targetVoucher.RowVersion, req.Version); err != nil {
+		return nil, err
+	}
+	if err := s.validateVoucherStatus(targetVoucher, voucher.StatusPosted); err != nil {
+		return nil, err
+	}
+	if err := s.validateVoucherIsNotReversed(targetVoucher.ID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.loadVoucherItemsAsInsertDetails(targetVoucher.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	insertReq := &voucher.InsertRequest{
+		Number:       req.Number,
+		SequenceCode: req.SequenceCode,
+		Date:         req.Date,
+		VoucherItems: items,
+	}
+	if err := s.withRevaluationLines().withArchivedSLs().validateInsertVoucherRequest(insertReq); err != nil {
+		return nil, err
+	}
+	return insertReq, nil
+}
+
+func (s *VoucherService) validateVoucherIsNotReversed(id int) error {
+	var reversal models.Voucher
+	if err := s.db.Where("tenant_id = ? AND reversal_of_id = ?", s.tenantID, id).First(&reversal).Error; err == nil {
+		return constants.ErrVoucherAlreadyReversed
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *VoucherService) validateCopyVoucherRequest(req *voucher.CopyRequest) (*voucher.InsertRequest, error) {
+	if err := s.resolveRequestDate(&req.Date, req.DateInput); err != nil {
+		return nil, err
+	}
+	targetVoucher, err := s.validateVoucherExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.loadVoucherItemsAsInsertDetails(targetVoucher.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	insertReq := &voucher.InsertRequest{
+		Number:       req.Number,
+		SequenceCode: req.SequenceCode,
+		Date:         req.Date,
+		VoucherItems: items,
+	}
+	if err := s.validateInsertVoucherRequest(insertReq); err != nil {
+		return nil, err
+	}
+	return insertReq, nil
+}
+
+func (s *VoucherService) loadVoucherItemsAsInsertDetails(voucherID int, flipDebitCredit bool) ([]voucher.VoucherItemInsertDetail, error) {
+	var voucherItems []models.VoucherItem
+	if err := s.db.Where("tenant_id = ? AND voucher_id = ?", s.tenantID, voucherID).Order("id").Find(&voucherItems).Error; err != nil {
+		return nil, err
+	}
+
+	details := make([]voucher.VoucherItemInsertDetail, len(voucherItems))
+	for i, item := range voucherItems {
+		details[i] = voucher.VoucherItemInsertDetail{
+			SLID:   item.SLID,
+			Debit:  item.Debit,
+			Credit: item.Credit,
+			CurrencyDetail: voucher.CurrencyDetail{
+				CurrencyCode:  item.CurrencyCode.String,
+				ForeignDebit:  item.ForeignDebit,
+				ForeignCredit: item.ForeignCredit,
+				ExchangeRate:  item.ExchangeRate.String,
+			},
+		}
+		if item.DLID.Valid {
+			dlID := int(item.DLID.Int64)
+			details[i].DLID = &dlID
+		}
+		if flipDebitCredit {
+			details[i].Debit, details[i].Credit = item.Credit, item.Debit
+			details[i].ForeignDebit, details[i].ForeignCredit = item.ForeignCredit, item.ForeignDebit
+		}
+	}
+	return details, nil
+}
+
+// applyApprovalRules sets the status of a voucher whose lines were just
+// written: a draft that has to be submitted for approval when it matches an
+// approval rule of its tenant or keepDraft is set, posted otherwise.
+func (s *VoucherService) applyApprovalRules(tx *gorm.DB, targetVoucher *models.Voucher, keepDraft bool) error {
+	requiredApprovals := 0
+	if !s.approvalExempt {
+		if err := tx.Raw(`SELECT COALESCE(MAX(required_approvals), 0) FROM approval_rule
+			WHERE tenant_id = ? AND (
+				min_amount <= (SELECT COALESCE(SUM(debit), 0) FROM voucher_item WHERE voucher_id = ?)
+				OR sl_id IN (SELECT sl_id FROM voucher_item WHERE voucher_id = ?))`,
+			s.tenantID, targetVoucher.ID, targetVoucher.ID).Scan(&requiredApprovals).Error; err != nil {
+			return err
+		}
+	}
+
+	targetVoucher.RequiredApprovals = requiredApprovals
+	targetVoucher.Status = voucher.StatusPosted
+	if requiredApprovals > 0 || keepDraft {
+		targetVoucher.Status = voucher.StatusDraft
+	}
+	return tx.Model(targetVoucher).Updates(map[string]interface{}{
+		"status":             targetVoucher.Status,
+		"required_approvals": targetVoucher.RequiredApprovals,
+	}).Error
+}
+
+func (s *VoucherService) validateVoucherStatus(targetVoucher *models.Voucher, allowedStatuses ...string) error {
+	for _, status := range allowedStatuses {
+		if targetVoucher.Status == status {
+			return nil
+		}
+	}
+	return constants.ErrInvalidVoucherStatus
+}
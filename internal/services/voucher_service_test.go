@@ -1,13 +1,28 @@
 package services
 
 import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/calendar"
 	"accountingsystem/internal/constants"
 	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/export"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/sl"
 	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/textrule"
+	"accountingsystem/internal/txretry"
+	"bytes"
+	"context"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func Test_CreateVoucher_Succeeds_ReferencingDLAndNonReferencingDLVoucherItems(t *testing.T) {
@@ -40,7 +55,7 @@ func Test_CreateVoucher_Succeeds_ReferencingDLAndNonReferencingDLVoucherItems(t
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 
 	require.Nil(t, err)
 	assert.Equal(t, req.Number, voucher.Number)
@@ -67,7 +82,7 @@ func Test_CreateVoucher_ReturnsErrNumberEmptyOrTooLong_WithTooShortNumber(t *tes
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrNumberEmptyOrTooLong)
 	assert.Nil(t, voucher)
@@ -94,7 +109,7 @@ func Test_CreateVoucher_ReturnsErrNumberEmptyOrTooLong_WithTooLongNumber(t *test
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrNumberEmptyOrTooLong)
 	assert.Nil(t, voucher)
@@ -115,7 +130,7 @@ func Test_CreateVoucher_ReturnsErrVoucherItemsCountOutOfRange_WithLessThanTwoVou
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherItemsCountOutOfRange)
 	assert.Nil(t, voucher)
@@ -137,7 +152,7 @@ func Test_CreateVoucher_ReturnsErrVoucherItemsCountOutOfRange_WithMoreThan500Vou
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherItemsCountOutOfRange)
 	assert.Nil(t, voucher)
@@ -164,7 +179,7 @@ func Test_CreateVoucher_ReturnsErrDebitOrCreditInvalid_BothDebitAndCreditNonZero
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDebitOrCreditInvalid)
 	assert.Nil(t, voucher)
@@ -197,7 +212,7 @@ func Test_CreateVoucher_ReturnsErrDebitOrCreditInvalid_BothDebitAndCreditZero(t
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDebitOrCreditInvalid)
 	assert.Nil(t, voucher)
@@ -224,12 +239,63 @@ func Test_CreateVoucher_ReturnsErrDebitCreditMismatch_WithMismatchedDebitCreditS
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDebitCreditMismatch)
 	assert.Nil(t, voucher)
 }
 
+func Test_CreateVoucher_Succeeds_WithAmountBeyond32Bits(t *testing.T) {
+	items, err := createRandomBalancedItems(5000000000)
+	require.Nil(t, err)
+
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, req)
+	require.Nil(t, err)
+	require.NotNil(t, createdVoucher)
+
+	fetchedVoucher, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID})
+	require.Nil(t, err)
+	assert.Equal(t, money.Amount(5000000000), fetchedVoucher.VoucherItems[0].Debit+fetchedVoucher.VoucherItems[1].Debit)
+}
+
+func Test_CreateVoucher_ReturnsErrAmountOverflow_WithOverflowingDebitSum(t *testing.T) {
+	items := []voucher.VoucherItemInsertDetail{
+		{
+			SLID:   1,
+			DLID:   nil,
+			Debit:  math.MaxInt64,
+			Credit: 0,
+		},
+		{
+			SLID:   2,
+			DLID:   nil,
+			Debit:  1,
+			Credit: 0,
+		},
+		{
+			SLID:   3,
+			DLID:   nil,
+			Debit:  0,
+			Credit: 1,
+		},
+	}
+
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrAmountOverflow)
+	assert.Nil(t, voucher)
+}
+
 func Test_CreateVoucher_ReturnsErrVoucherNumberExists_WithExistingVoucherNumber(t *testing.T) {
 	slWithDL, err := createRandomSL(true)
 	require.Nil(t, err)
@@ -260,7 +326,7 @@ func Test_CreateVoucher_ReturnsErrVoucherNumberExists_WithExistingVoucherNumber(
 		VoucherItems: initialItems,
 	}
 
-	_, err = voucherService.CreateVoucher(initialReq)
+	_, err = voucherService.CreateVoucher(testAdmin, initialReq)
 	require.Nil(t, err)
 
 	newItems := []voucher.VoucherItemInsertDetail{
@@ -283,7 +349,7 @@ func Test_CreateVoucher_ReturnsErrVoucherNumberExists_WithExistingVoucherNumber(
 		VoucherItems: newItems,
 	}
 
-	voucher, err := voucherService.CreateVoucher(newReq)
+	voucher, err := voucherService.CreateVoucher(testAdmin, newReq)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherNumberExists)
 	assert.Nil(t, voucher)
@@ -310,7 +376,7 @@ func Test_CreateVoucher_ReturnsErrSLNotFound_WithInvalidSLID(t *testing.T) {
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
 	assert.Nil(t, voucher)
@@ -340,7 +406,7 @@ func Test_CreateVoucher_ReturnsErrDLIDRequired_WhenSLRequiresDLButNoDLProvided(t
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLIDRequired)
 	assert.Nil(t, voucher)
@@ -373,7 +439,7 @@ func Test_CreateVoucher_ReturnsErrDLNotAllowed_WhenSLDoesNotRequireDLButDLProvid
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotAllowed)
 	assert.Nil(t, voucher)
@@ -405,12 +471,37 @@ func Test_CreateVoucher_ReturnsErrDLNotFound_WithInvalidDLID(t *testing.T) {
 		VoucherItems: items,
 	}
 
-	voucher, err := voucherService.CreateVoucher(req)
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotFound)
 	assert.Nil(t, voucher)
 }
 
+func createRandomBalancedItems(amount money.Amount) ([]voucher.VoucherItemInsertDetail, error) {
+	debitSL, err := createRandomSL(false)
+	if err != nil {
+		return nil, err
+	}
+
+	creditSL, err := createRandomSL(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return []voucher.VoucherItemInsertDetail{
+		{
+			SLID:   debitSL.ID,
+			Debit:  amount,
+			Credit: 0,
+		},
+		{
+			SLID:   creditSL.ID,
+			Debit:  0,
+			Credit: amount,
+		},
+	}, nil
+}
+
 func createRandomVoucher() (*dtos.VoucherWithItemsDto, error) {
 	slWithDL, err := createRandomSL(true)
 	if err != nil {
@@ -447,7 +538,7 @@ func createRandomVoucher() (*dtos.VoucherWithItemsDto, error) {
 		VoucherItems: items,
 	}
 
-	voucherDto, err := voucherService.CreateVoucher(req)
+	voucherDto, err := voucherService.CreateVoucher(testAdmin, req)
 	if err != nil {
 		return nil, err
 	}
@@ -504,7 +595,7 @@ func Test_UpdateVoucher_Succeeds_WithValidRequest(t *testing.T) {
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.Nil(t, err)
 	assert.Equal(t, req.Number, voucher.Number)
@@ -540,7 +631,7 @@ func Test_UpdateVoucher_ReturnsErrVoucherNotFound_WithNonExistentVoucherID(t *te
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
@@ -577,7 +668,7 @@ func Test_UpdateVoucher_ReturnsErrNumberEmptyOrTooLong_WithEmptyNumber(t *testin
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrNumberEmptyOrTooLong)
@@ -614,7 +705,7 @@ func Test_UpdateVoucher_ReturnsErrNumberEmptyOrTooLong_WithTooLongNumber(t *test
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrNumberEmptyOrTooLong)
@@ -657,11 +748,11 @@ func Test_UpdateVoucher_ReturnsErrVersionOutdated_WithOutdatedRequest(t *testing
 		Items:   items,
 	}
 
-	_, err = voucherService.UpdateVoucher(req)
+	_, err = voucherService.UpdateVoucher(testAdmin, req)
 
 	require.Nil(t, err)
 
-	updatedVoucher, err := voucherService.UpdateVoucher(req)
+	updatedVoucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
@@ -698,7 +789,7 @@ func Test_UpdateVoucher_ReturnsErrSLNotFound_WithInvalidSLInInserts(t *testing.T
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
@@ -738,7 +829,7 @@ func Test_UpdateVoucher_ReturnsErrDLIDRequired_WhenSLRequiresDLButNoDLProvidedIn
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLIDRequired)
@@ -781,7 +872,7 @@ func Test_UpdateVoucher_ReturnsErrDLNotAllowed_WhenSLDoesNotRequireDLButDLProvid
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotAllowed)
@@ -818,7 +909,7 @@ func Test_UpdateVoucher_ReturnsErrDebitOrCreditInvalid_InvalidValuesInInserts(t
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDebitOrCreditInvalid)
@@ -850,7 +941,7 @@ func Test_UpdateVoucher_ReturnsErrSLNotFound_WithInvalidSLInUpdates(t *testing.T
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrSLNotFound)
@@ -885,7 +976,7 @@ func Test_UpdateVoucher_ReturnsErrDLIDRequired_WhenSLRequiresDLButNoDLProvidedIn
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLIDRequired)
@@ -923,7 +1014,7 @@ func Test_UpdateVoucher_ReturnsErrDLNotAllowed_WhenSLDoesNotRequireDLButDLProvid
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDLNotAllowed)
@@ -955,7 +1046,7 @@ func Test_UpdateVoucher_ReturnsErrDebitOrCreditInvalid_InvalidValuesInUpdates(t
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDebitOrCreditInvalid)
@@ -989,13 +1080,77 @@ func Test_UpdateVoucher_ReturnsErrVoucherItemNotFound_WithInvalidVoucherItemIDIn
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherItemNotFound)
 	assert.Nil(t, voucher)
 }
 
+func Test_UpdateVoucher_ReturnsErrVoucherItemNotFound_WithItemOfAnotherVoucher(t *testing.T) {
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	otherVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	items := voucher.VoucherItemsUpdate{
+		Updated: []voucher.VoucherItemUpdateDetail{
+			{
+				ID:     otherVoucher.VoucherItems[0].ID,
+				SLID:   otherVoucher.VoucherItems[0].SLID,
+				DLID:   &otherVoucher.VoucherItems[0].DLID,
+				Debit:  otherVoucher.VoucherItems[0].Debit,
+				Credit: otherVoucher.VoucherItems[0].Credit,
+			},
+		},
+		Deleted: []int{otherVoucher.VoucherItems[1].ID},
+	}
+
+	req := &voucher.UpdateRequest{
+		ID:      voucherDto.ID,
+		Version: voucherDto.RowVersion,
+		Number:  generateRandomString(20),
+		Items:   items,
+	}
+
+	voucherWithItems, err := voucherService.UpdateVoucher(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherItemNotFound)
+	assert.Nil(t, voucherWithItems)
+	found, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: otherVoucher.ID})
+	require.Nil(t, err)
+	assert.Equal(t, otherVoucher.VoucherItems, found.VoucherItems)
+}
+
+func Test_UpdateVoucher_ReturnsErrVoucherItemNotFound_WithDeletedItemOfAnotherVoucher(t *testing.T) {
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	otherVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	req := &voucher.UpdateRequest{
+		ID:      voucherDto.ID,
+		Version: voucherDto.RowVersion,
+		Number:  generateRandomString(20),
+		Items: voucher.VoucherItemsUpdate{
+			Inserted: []voucher.VoucherItemInsertDetail{
+				{SLID: otherVoucher.VoucherItems[1].SLID, Credit: otherVoucher.VoucherItems[1].Credit},
+			},
+			Deleted: []int{otherVoucher.VoucherItems[1].ID},
+		},
+	}
+
+	voucherWithItems, err := voucherService.UpdateVoucher(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherItemNotFound)
+	assert.Nil(t, voucherWithItems)
+	found, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: otherVoucher.ID})
+	require.Nil(t, err)
+	assert.Len(t, found.VoucherItems, 2)
+}
+
 func Test_UpdateVoucher_ReturnsErrDebitCreditMismatch_WithUnbalancedRequest(t *testing.T) {
 	voucherDto, err := createRandomVoucher()
 	require.Nil(t, err)
@@ -1034,7 +1189,7 @@ func Test_UpdateVoucher_ReturnsErrDebitCreditMismatch_WithUnbalancedRequest(t *t
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrDebitCreditMismatch)
@@ -1060,7 +1215,7 @@ func Test_UpdateVoucher_ReturnsErrVoucherItemsCountOutOfRange_WhenCountGoesBelow
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherItemsCountOutOfRange)
@@ -1100,7 +1255,7 @@ func Test_UpdateVoucher_ReturnsErrVoucherItemsCountOutOfRange_WhenCountExceedsFi
 		Items:   items,
 	}
 
-	voucher, err := voucherService.UpdateVoucher(req)
+	voucher, err := voucherService.UpdateVoucher(testAdmin, req)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherItemsCountOutOfRange)
@@ -1116,11 +1271,11 @@ func Test_DeleteVoucher_Succeeds_WithValidRequest(t *testing.T) {
 		Version: createdVoucher.RowVersion,
 	}
 
-	err = voucherService.DeleteVoucher(deleteReq)
+	err = voucherService.DeleteVoucher(testAdmin, deleteReq)
 
 	require.Nil(t, err)
 
-	_, err = voucherService.GetVoucher(&voucher.GetRequest{ID: createdVoucher.ID})
+	_, err = voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID})
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
 }
@@ -1130,7 +1285,7 @@ func Test_DeleteVoucher_ReturnsErrVoucherNotFound_WithNonExistingVoucherID(t *te
 		ID: generateRandomInt64(),
 	}
 
-	err := voucherService.DeleteVoucher(deleteReq)
+	err := voucherService.DeleteVoucher(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
@@ -1185,7 +1340,7 @@ func Test_DeleteVoucher_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing
 		Items:   items,
 	}
 
-	_, err = voucherService.UpdateVoucher(validUpdateReq)
+	_, err = voucherService.UpdateVoucher(testAdmin, validUpdateReq)
 	require.Nil(t, err)
 
 	deleteReq := &voucher.DeleteRequest{
@@ -1193,7 +1348,7 @@ func Test_DeleteVoucher_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing
 		Version: voucherDto.RowVersion,
 	}
 
-	err = voucherService.DeleteVoucher(deleteReq)
+	err = voucherService.DeleteVoucher(testAdmin, deleteReq)
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
@@ -1207,7 +1362,7 @@ func Test_GetVoucher_Succeeds_WithValidRequest(t *testing.T) {
 		ID: createdVoucher.ID,
 	}
 
-	voucherDto, err := voucherService.GetVoucher(getReq)
+	voucherDto, err := voucherService.GetVoucher(testAdmin, getReq)
 
 	require.Nil(t, err)
 	assert.Equal(t, createdVoucher.ID, voucherDto.ID)
@@ -1221,9 +1376,754 @@ func Test_GetVoucher_ReturnsErrVoucherNotFound_WithNonExistingVoucherID(t *testi
 		ID: newID,
 	}
 
-	voucherDto, err := voucherService.GetVoucher(getReq)
+	voucherDto, err := voucherService.GetVoucher(testAdmin, getReq)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
+	assert.Nil(t, voucherDto)
+}
+
+func Test_ExportVoucher_WritesPDF_WithValidRequest(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = voucherService.ExportVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID}, export.FormatPDF, &output)
+
+	require.Nil(t, err)
+	assert.True(t, bytes.HasPrefix(output.Bytes(), []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(output.Bytes(), []byte("%%EOF\n")))
+}
+
+func Test_ExportVoucher_WritesCSVWithAccountTitles_WithValidRequest(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+	createdSL, err := slService.GetSL(testAdmin, &sl.GetRequest{ID: createdVoucher.VoucherItems[0].SLID})
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = voucherService.ExportVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID}, export.FormatCSV, &output)
+
+	require.Nil(t, err)
+	assert.Contains(t, output.String(), createdSL.Title)
+	assert.Contains(t, output.String(), "Total,,,,,100,100")
+}
+
+func Test_ExportVoucher_ReturnsErrVoucherNotFound_WithNonExistingVoucherID(t *testing.T) {
+	var output bytes.Buffer
+	err := voucherService.ExportVoucher(testAdmin, &voucher.GetRequest{ID: generateRandomInt64()}, export.FormatCSV, &output)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
+}
+
+func Test_ExportVoucher_ReturnsErrInvalidExportFormat_WithUnknownFormat(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	var output bytes.Buffer
+	err = voucherService.ExportVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID}, export.Format("txt"), &output)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidExportFormat)
+}
+
+func Test_GetVoucherByNumber_Succeeds_WithExistingNumber(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	voucherDto, err := voucherService.GetVoucherByNumber(testAdmin, &voucher.GetByNumberRequest{Number: createdVoucher.Number})
+
+	require.Nil(t, err)
+	assert.Equal(t, createdVoucher.ID, voucherDto.ID)
+	assert.Len(t, voucherDto.VoucherItems, len(createdVoucher.VoucherItems))
+}
+
+func Test_GetVoucherByNumber_ReturnsErrVoucherNotFound_WithNonExistingNumber(t *testing.T) {
+	voucherDto, err := voucherService.GetVoucherByNumber(testAdmin, &voucher.GetByNumberRequest{Number: generateRandomString(30)})
 
 	require.NotNil(t, err)
 	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
 	assert.Nil(t, voucherDto)
 }
+
+func Test_GetVouchers_ReturnsVouchersWithTheirItems_WithMixedIDs(t *testing.T) {
+	first, err := createRandomVoucher()
+	require.Nil(t, err)
+	second, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	voucherDtos, err := voucherService.GetVouchers(testAdmin, &voucher.GetManyRequest{IDs: []int{second.ID, first.ID, -1}})
+
+	require.Nil(t, err)
+	require.Len(t, voucherDtos, 2)
+	assert.Equal(t, first.ID, voucherDtos[0].ID)
+	assert.ElementsMatch(t, first.VoucherItems, voucherDtos[0].VoucherItems)
+	assert.Equal(t, second.ID, voucherDtos[1].ID)
+	assert.ElementsMatch(t, second.VoucherItems, voucherDtos[1].VoucherItems)
+}
+
+func Test_CreateVoucher_Succeeds_ReferencingSLAndDLByCode(t *testing.T) {
+	slWithDL, err := createRandomSL(true)
+	require.Nil(t, err)
+
+	slWithoutDL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	dl, err := createRandomDL()
+	require.Nil(t, err)
+
+	items := []voucher.VoucherItemInsertDetail{
+		{
+			SLCode: &slWithDL.Code,
+			DLCode: &dl.Code,
+			Debit:  100,
+			Credit: 0,
+		},
+		{
+			SLCode: &slWithoutDL.Code,
+			Debit:  0,
+			Credit: 100,
+		},
+	}
+
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, slWithDL.ID, voucher.VoucherItems[0].SLID)
+	assert.Equal(t, dl.ID, voucher.VoucherItems[0].DLID)
+	assert.Equal(t, slWithoutDL.ID, voucher.VoucherItems[1].SLID)
+}
+
+func Test_CreateVoucher_ReturnsErrSLNotFound_WithNonExistingSLCode(t *testing.T) {
+	slWithoutDL, err := createRandomSL(false)
+	require.Nil(t, err)
+	nonExistingCode := "SL" + generateRandomString(30)
+
+	items := []voucher.VoucherItemInsertDetail{
+		{
+			SLCode: &nonExistingCode,
+			Debit:  100,
+			Credit: 0,
+		},
+		{
+			SLID:   slWithoutDL.ID,
+			Debit:  0,
+			Credit: 100,
+		},
+	}
+
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLNotFound)
+	assert.Nil(t, voucher)
+}
+
+func Test_CreateVoucher_ReturnsErrSLReferenceMismatch_WithConflictingSLIDAndCode(t *testing.T) {
+	firstSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	secondSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	items := []voucher.VoucherItemInsertDetail{
+		{
+			SLID:   firstSL.ID,
+			SLCode: &secondSL.Code,
+			Debit:  100,
+			Credit: 0,
+		},
+		{
+			SLID:   secondSL.ID,
+			Debit:  0,
+			Credit: 100,
+		},
+	}
+
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+
+	voucher, err := voucherService.CreateVoucher(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrSLReferenceMismatch)
+	assert.Nil(t, voucher)
+}
+
+func Test_ReverseVoucher_Succeeds_WithFlippedDebitAndCredit(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	reverseReq := &voucher.ReverseRequest{
+		ID:      createdVoucher.ID,
+		Version: createdVoucher.RowVersion,
+		Number:  generateRandomString(20),
+	}
+
+	reversal, err := voucherService.ReverseVoucher(testAdmin, reverseReq)
+
+	require.Nil(t, err)
+	assert.Equal(t, reverseReq.Number, reversal.Number)
+	assert.Equal(t, createdVoucher.ID, reversal.ReversalOfID)
+	require.Len(t, reversal.VoucherItems, len(createdVoucher.VoucherItems))
+	for i, item := range reversal.VoucherItems {
+		original := createdVoucher.VoucherItems[i]
+		assert.Equal(t, original.SLID, item.SLID)
+		assert.Equal(t, original.DLID, item.DLID)
+		assert.Equal(t, original.Debit, item.Credit)
+		assert.Equal(t, original.Credit, item.Debit)
+	}
+}
+
+func Test_ReverseVoucher_ReturnsErrVoucherAlreadyReversed_WithReversedVoucher(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	_, err = voucherService.ReverseVoucher(testAdmin, &voucher.ReverseRequest{
+		ID:      createdVoucher.ID,
+		Version: createdVoucher.RowVersion,
+		Number:  generateRandomString(20),
+	})
+	require.Nil(t, err)
+
+	reversal, err := voucherService.ReverseVoucher(testAdmin, &voucher.ReverseRequest{
+		ID:      createdVoucher.ID,
+		Version: createdVoucher.RowVersion,
+		Number:  generateRandomString(20),
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherAlreadyReversed)
+	assert.Nil(t, reversal)
+}
+
+func Test_ReverseVoucher_ReversesOnce_WithConcurrentRequests(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	const count = 4
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = voucherService.ReverseVoucher(testAdmin, &voucher.ReverseRequest{
+				ID:      createdVoucher.ID,
+				Version: createdVoucher.RowVersion,
+				Number:  generateRandomString(20),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	reversed := 0
+	for _, err := range errs {
+		if err == nil {
+			reversed++
+			continue
+		}
+		assert.ErrorIs(t, err, constants.ErrVoucherAlreadyReversed)
+	}
+	assert.Equal(t, 1, reversed)
+}
+
+func Test_ReverseVoucher_ReturnsErrVoucherNotFound_WithNonExistingVoucherID(t *testing.T) {
+	reversal, err := voucherService.ReverseVoucher(testAdmin, &voucher.ReverseRequest{
+		ID:     generateRandomInt64(),
+		Number: generateRandomString(20),
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
+	assert.Nil(t, reversal)
+}
+
+func Test_ReverseVoucher_ReturnsErrVersionOutdated_WithOutdatedVersion(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	reversal, err := voucherService.ReverseVoucher(testAdmin, &voucher.ReverseRequest{
+		ID:      createdVoucher.ID,
+		Version: createdVoucher.RowVersion + 1,
+		Number:  generateRandomString(20),
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
+	assert.Nil(t, reversal)
+}
+
+func Test_CopyVoucher_Succeeds_WithSameItems(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	copyReq := &voucher.CopyRequest{
+		ID:     createdVoucher.ID,
+		Number: generateRandomString(20),
+	}
+
+	copied, err := voucherService.CopyVoucher(testAdmin, copyReq)
+
+	require.Nil(t, err)
+	assert.NotEqual(t, createdVoucher.ID, copied.ID)
+	assert.Equal(t, copyReq.Number, copied.Number)
+	assert.Equal(t, voucher.StatusDraft, copied.Status)
+	assert.Zero(t, copied.ReversalOfID)
+	require.Len(t, copied.VoucherItems, len(createdVoucher.VoucherItems))
+	for i, item := range copied.VoucherItems {
+		original := createdVoucher.VoucherItems[i]
+		assert.NotEqual(t, original.ID, item.ID)
+		assert.Equal(t, original.SLID, item.SLID)
+		assert.Equal(t, original.DLID, item.DLID)
+		assert.Equal(t, original.Debit, item.Debit)
+		assert.Equal(t, original.Credit, item.Credit)
+	}
+}
+
+func Test_CopyVoucher_ReturnsErrVoucherNumberExists_WithExistingNumber(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	copied, err := voucherService.CopyVoucher(testAdmin, &voucher.CopyRequest{
+		ID:     createdVoucher.ID,
+		Number: createdVoucher.Number,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherNumberExists)
+	assert.Nil(t, copied)
+}
+
+func Test_CopyVoucher_ReturnsErrVoucherNotFound_WithNonExistingVoucherID(t *testing.T) {
+	copied, err := voucherService.CopyVoucher(testAdmin, &voucher.CopyRequest{
+		ID:     generateRandomInt64(),
+		Number: generateRandomString(20),
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
+	assert.Nil(t, copied)
+}
+
+func Test_CreateVoucher_Succeeds_WithForeignCurrencyItem(t *testing.T) {
+	voucherDate := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	createdCurrency, err := createRandomCurrencyWithRate(voucherDate.AddDate(0, 0, -5), "42000.5")
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(4200050)
+	require.Nil(t, err)
+	items[0].Debit = 0
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: createdCurrency.Code, ForeignDebit: 100}
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		Date:         voucherDate,
+		VoucherItems: items,
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, money.Amount(4200050), createdVoucher.VoucherItems[0].Debit)
+	assert.Equal(t, money.Amount(100), createdVoucher.VoucherItems[0].ForeignDebit)
+	assert.Equal(t, createdCurrency.Code, createdVoucher.VoucherItems[0].CurrencyCode)
+	assert.Equal(t, "42000.5000000000", createdVoucher.VoucherItems[0].ExchangeRate)
+	assert.Equal(t, "", createdVoucher.VoucherItems[1].CurrencyCode)
+}
+
+func Test_CreateVoucher_ReturnsErrForeignAmountRequired_WithCurrencyLineWithoutForeignAmount(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(1000)
+	require.Nil(t, err)
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: createdCurrency.Code, ExchangeRate: "100"}
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForeignAmountRequired)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrDebitCreditMismatch_WithUnbalancedFunctionalAmounts(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(1000)
+	require.Nil(t, err)
+	items[0].Debit = 0
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: createdCurrency.Code, ForeignDebit: 10, ExchangeRate: "99.9"}
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDebitCreditMismatch)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrExchangeRateNotFound_WithoutRateHistory(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(1000)
+	require.Nil(t, err)
+	items[0].Debit = 0
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: createdCurrency.Code, ForeignDebit: 10}
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrExchangeRateNotFound)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrFunctionalAmountMismatch_WithConflictingDebit(t *testing.T) {
+	createdCurrency, err := createRandomCurrency()
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(1000)
+	require.Nil(t, err)
+	items[0].CurrencyDetail = voucher.CurrencyDetail{CurrencyCode: createdCurrency.Code, ForeignDebit: 10, ExchangeRate: "99"}
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrFunctionalAmountMismatch)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ParsesJalaliDate_WithDateText(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		DateInput:    voucher.DateInput{DateText: "1403/01/15", Calendar: calendar.Jalali},
+		VoucherItems: items,
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC), createdVoucher.Date.UTC())
+	assert.Equal(t, "1403/01/15", createdVoucher.DateText)
+}
+
+func Test_GetVoucher_WritesDateTextInRequestedCalendar(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		Date:         time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+		VoucherItems: items,
+	})
+	require.Nil(t, err)
+
+	jalaliVoucher, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID, Calendar: calendar.Jalali})
+	require.Nil(t, err)
+	gregorianVoucher, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID, Calendar: calendar.Gregorian})
+	require.Nil(t, err)
+
+	assert.Equal(t, "1403/01/15", jalaliVoucher.DateText)
+	assert.Equal(t, calendar.Gregorian.Format(createdVoucher.Date), gregorianVoucher.DateText)
+}
+
+func Test_GetVoucher_ReturnsErrInvalidCalendar_WithUnknownCalendar(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	foundVoucher, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID, Calendar: calendar.Calendar("lunar")})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidCalendar)
+	assert.Nil(t, foundVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrInvalidDate_WithMissingLeapDay(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		DateInput:    voucher.DateInput{DateText: "1402/12/30", Calendar: calendar.Jalali},
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidDate)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrInvalidCalendar_WithUnknownCalendar(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		DateInput:    voucher.DateInput{DateText: "2024-04-03", Calendar: calendar.Calendar("lunar")},
+		VoucherItems: items,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidCalendar)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrVoucherNumberExists_WithPersianDigitVariantOfExistingNumber(t *testing.T) {
+	suffix := generateRandomString(20)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: suffix + "-1403", VoucherItems: items})
+	require.Nil(t, err)
+
+	duplicateItems, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: " " + suffix + "-۱۴۰۳", VoucherItems: duplicateItems})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVoucherNumberExists)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrNumberEmptyOrTooLong_WithNumberLongerThanConfiguredMaximum(t *testing.T) {
+	defaultRule := textrule.VoucherNumber.Rule()
+	require.Nil(t, textrule.VoucherNumber.SetRule(textrule.Rule{MinLength: 1, MaxLength: 10}))
+	defer textrule.VoucherNumber.SetRule(defaultRule)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+
+	createdVoucher, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: "۱۲۳۴۵" + generateRandomString(6), VoucherItems: items})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrNumberEmptyOrTooLong)
+	assert.Nil(t, createdVoucher)
+}
+
+func Test_CreateVoucher_ReturnsErrForbidden_WithApprover(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	req := &voucher.InsertRequest{
+		Number:       generateRandomString(20),
+		VoucherItems: items,
+	}
+
+	voucherDto, err := voucherService.CreateVoucher(auth.Principal{Username: "approver", Role: auth.Approver, TenantID: auth.DefaultTenantID}, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, voucherDto)
+}
+
+func Test_ReverseVoucher_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+	reverseReq := &voucher.ReverseRequest{
+		ID:      createdVoucher.ID,
+		Version: createdVoucher.RowVersion,
+		Number:  generateRandomString(20),
+	}
+
+	reversal, err := voucherService.ReverseVoucher(auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}, reverseReq)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	assert.Nil(t, reversal)
+}
+
+func Test_ReverseVoucher_Succeeds_WithApprover(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+	reverseReq := &voucher.ReverseRequest{
+		ID:      createdVoucher.ID,
+		Version: createdVoucher.RowVersion,
+		Number:  generateRandomString(20),
+	}
+
+	reversal, err := voucherService.ReverseVoucher(auth.Principal{Username: "approver", Role: auth.Approver, TenantID: auth.DefaultTenantID}, reverseReq)
+
+	require.Nil(t, err)
+	assert.Equal(t, createdVoucher.ID, reversal.ReversalOfID)
+}
+
+func Test_DeleteVoucher_ReturnsErrForbidden_WithViewer(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	err = voucherService.DeleteVoucher(auth.Principal{Username: "viewer", Role: auth.Viewer, TenantID: auth.DefaultTenantID}, &voucher.DeleteRequest{ID: createdVoucher.ID, Version: createdVoucher.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+}
+
+func Test_DeleteVoucher_ReturnsErrForbidden_WithAccountantAndPostedVoucher(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+	require.Equal(t, voucher.StatusPosted, createdVoucher.Status)
+
+	err = voucherService.DeleteVoucher(auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}, &voucher.DeleteRequest{ID: createdVoucher.ID, Version: createdVoucher.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+	_, err = voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID})
+	assert.Nil(t, err)
+}
+
+func Test_DeleteVoucher_Succeeds_WithAccountantAndDraftVoucher(t *testing.T) {
+	admin, err := createApprovalTenant(1)
+	require.Nil(t, err)
+	createdVoucher, err := createTenantVoucher(admin, 1000)
+	require.Nil(t, err)
+	require.Equal(t, voucher.StatusDraft, createdVoucher.Status)
+	accountant := auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: admin.TenantID}
+
+	err = voucherService.DeleteVoucher(accountant, &voucher.DeleteRequest{ID: createdVoucher.ID, Version: createdVoucher.RowVersion})
+
+	require.Nil(t, err)
+	_, err = voucherService.GetVoucher(admin, &voucher.GetRequest{ID: createdVoucher.ID})
+	assert.ErrorIs(t, err, constants.ErrVoucherNotFound)
+}
+
+func Test_GetVoucherContext_ReturnsErrDeadlineExceeded_WithExpiredDeadline(t *testing.T) {
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	found, err := voucherService.GetVoucherContext(ctx, testAdmin, &voucher.GetRequest{ID: voucherDto.ID})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDeadlineExceeded)
+	assert.Nil(t, found)
+}
+
+func Test_GetVouchersContext_ReturnsErrDeadlineExceeded_WithExpiredDeadline(t *testing.T) {
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	found, err := voucherService.GetVouchersContext(ctx, testAdmin, &voucher.GetManyRequest{IDs: []int{voucherDto.ID}})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDeadlineExceeded)
+	assert.Nil(t, found)
+}
+
+func Test_CreateVoucherContext_Succeeds_WithLiveContext(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	voucherDto, err := voucherService.CreateVoucherContext(ctx, testAdmin, &voucher.InsertRequest{Number: generateRandomString(20), VoucherItems: items})
+
+	require.Nil(t, err)
+	assert.Len(t, voucherDto.VoucherItems, 2)
+}
+
+func Test_RunTransaction_RetriesAndCommits_AfterSerializationFailure(t *testing.T) {
+	before := txretry.Snapshot()
+	dlCode := "DL" + generateRandomString(20)
+	attempts := 0
+
+	err := txretry.Run(voucherService.db, func(tx *gorm.DB) error {
+		attempts++
+		if err := tx.Create(&models.DL{TenantID: auth.DefaultTenantID, Code: dlCode, Title: "Test" + generateRandomString(20)}).Error; err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	after := txretry.Snapshot()
+	assert.GreaterOrEqual(t, after.Retries-before.Retries, uint64(1))
+	assert.GreaterOrEqual(t, after.Recovered-before.Recovered, uint64(1))
+	var count int64
+	require.Nil(t, voucherService.db.Model(&models.DL{}).Where("code = ?", dlCode).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func Test_RunTransaction_ReturnsErrTransactionConflict_WhenDeadlocksPersist(t *testing.T) {
+	attempts := 0
+
+	err := txretry.Run(voucherService.db, func(tx *gorm.DB) error {
+		attempts++
+		return &pgconn.PgError{Code: "40P01"}
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTransactionConflict)
+	assert.Greater(t, attempts, 1)
+}
+
+func Test_CreateVoucher_ReturnsOriginalVoucher_WithReplayedIdempotencyKey(t *testing.T) {
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	replayItems := append([]voucher.VoucherItemInsertDetail(nil), items...)
+	key := generateRandomString(32)
+	number := generateRandomString(20)
+
+	created, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: number, VoucherItems: items, IdempotencyKey: key})
+	require.Nil(t, err)
+	replayed, err := voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{Number: number, VoucherItems: replayItems, IdempotencyKey: key})
+
+	require.Nil(t, err)
+	assert.Equal(t, created.ID, replayed.ID)
+	assert.Equal(t, created.VoucherItems, replayed.VoucherItems)
+}
+
+func Test_UpdateVoucher_UpdatesOnce_WithConcurrentRequestsOnSameVersion(t *testing.T) {
+	createdVoucher, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	const count = 4
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = voucherService.UpdateVoucher(testAdmin, &voucher.UpdateRequest{
+				ID:      createdVoucher.ID,
+				Version: createdVoucher.RowVersion,
+				Number:  generateRandomString(20),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	updated := 0
+	for _, err := range errs {
+		if err == nil {
+			updated++
+			continue
+		}
+		assert.ErrorIs(t, err, constants.ErrVersionOutdated)
+	}
+	assert.Equal(t, 1, updated)
+	fetched, err := voucherService.GetVoucher(testAdmin, &voucher.GetRequest{ID: createdVoucher.ID})
+	require.Nil(t, err)
+	assert.Equal(t, createdVoucher.RowVersion+1, fetched.RowVersion)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/vouchertemplate"
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type VoucherTemplateService struct {
+	db             *gorm.DB
+	tenantID       int
+	voucherService *VoucherService
+}
+
+func (s *VoucherTemplateService) InitService(db *gorm.DB) {
+	s.db = db
+	s.voucherService = &VoucherService{}
+	s.voucherService.InitService(db)
+}
+
+func (s *VoucherTemplateService) forTenant(tenantID int) *VoucherTemplateService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	scoped.voucherService = s.voucherService.forTenant(tenantID)
+	return &scoped
+}
+
+func (s *VoucherTemplateService) CreateVoucherTemplate(actor auth.Principal, req *vouchertemplate.InsertRequest) (*dtos.VoucherTemplateDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucherTemplate, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	if err := s.validateVoucherTemplateInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	templateDto, err := s.applyVoucherTemplateCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating voucher template: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return templateDto, nil
+}
+
+func (s *VoucherTemplateService) UpdateVoucherTemplate(actor auth.Principal, req *vouchertemplate.UpdateRequest) (*dtos.VoucherTemplateDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucherTemplate, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetTemplate, err := s.validateVoucherTemplateUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	templateDto, err := s.applyVoucherTemplateUpdate(req, targetTemplate)
+	if err != nil {
+		log.Printf("unexpected error while updating voucher template: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return templateDto, nil
+}
+
+func (s *VoucherTemplateService) DeleteVoucherTemplate(actor auth.Principal, req *vouchertemplate.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityVoucherTemplate, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetTemplate, err := s.validateVoucherTemplateDeleteRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyVoucherTemplateDeletion(targetTemplate); err != nil {
+		log.Printf("unexpected error while deleting voucher template: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+func (s *VoucherTemplateService) GetVoucherTemplate(actor auth.Principal, req *vouchertemplate.GetRequest) (*dtos.VoucherTemplateDto, error) {
+	if err := auth.Authorize(actor, auth.EntityVoucherTemplate, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetTemplate, err := s.validateVoucherTemplateExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	templateDto, err := s.applyVoucherTemplateGet(targetTemplate)
+	if err != nil {
+		log.Printf("unexpected error while getting voucher template: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return templateDto, nil
+}
+
+func (s *VoucherTemplateService) RunDueVoucherTemplates(now time.Time) (int, error) {
+	createdCount, err := s.applyDueVoucherTemplatesRun(now.UTC())
+	if err != nil {
+		log.Printf("unexpected error while running due voucher templates: %v", err)
+		return createdCount, constants.ErrUnexpectedError
+	}
+
+	return createdCount, nil
+}
+
+func (s *VoucherTemplateService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunDueVoucherTemplates(time.Now()); err != nil {
+			log.Printf("voucher templates could not be run: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
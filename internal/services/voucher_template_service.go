@@ -0,0 +1,533 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/cron"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/formula"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/normalize"
+	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/requests/vouchertemplate"
+	"accountingsystem/internal/textrule"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxTemplateCatchUpRuns = 100
+
+func (s *VoucherTemplateService) applyVoucherTemplateCreation(req *vouchertemplate.InsertRequest) (*dtos.VoucherTemplateDto, error) {
+	variables, err := json.Marshal(s.variablesOrEmpty(req.Variables))
+	if err != nil {
+		return nil, err
+	}
+
+	template := models.VoucherTemplate{
+		TenantID:       s.tenantID,
+		Code:           req.Code,
+		Title:          req.Title,
+		NumberPrefix:   req.NumberPrefix,
+		Variables:      string(variables),
+		Frequency:      req.Frequency,
+		CronExpression: req.CronExpression,
+		StartsAt:       req.StartsAt.UTC(),
+		Active:         true,
+		RowVersion:     0,
+	}
+	template.NextRunAt = s.firstRunAt(&template)
+
+	var items []models.VoucherTemplateItem
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&template).Error; err != nil {
+			return err
+		}
+		items, err = s.insertVoucherTemplateItems(tx, template.ID, req.Items)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToVoucherTemplateDto(&template, items), nil
+}
+
+func (s *VoucherTemplateService) variablesOrEmpty(variables map[string]money.Amount) map[string]money.Amount {
+	if variables == nil {
+		return map[string]money.Amount{}
+	}
+	return variables
+}
+
+func (s *VoucherTemplateService) insertVoucherTemplateItems(tx *gorm.DB, templateID int, items []vouchertemplate.ItemDetail) ([]models.VoucherTemplateItem, error) {
+	templateItems := make([]models.VoucherTemplateItem, len(items))
+	for i, item := range items {
+		templateItems[i] = models.VoucherTemplateItem{
+			TemplateID: templateID,
+			SLID:       item.SLID,
+			DLID:       s.voucherService.convertToNullInt64(item.DLID),
+			Side:       item.Side,
+			Amount:     item.Amount,
+			Formula:    item.Formula,
+		}
+	}
+
+	if err := tx.Create(&templateItems).Error; err != nil {
+		return nil, err
+	}
+	return templateItems, nil
+}
+
+func (s *VoucherTemplateService) validateVoucherTemplateInsertRequest(req *vouchertemplate.InsertRequest) error {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := s.validateCodeAndTitleLength(req.Code, req.Title); err != nil {
+		return err
+	}
+	if err := s.validateCodeUnique(req.Code, 0); err != nil {
+		return err
+	}
+	if err := s.validateNumberPrefix(req.NumberPrefix); err != nil {
+		return err
+	}
+	if err := s.validateSchedule(req.Frequency, req.CronExpression, req.StartsAt); err != nil {
+		return err
+	}
+	if err := s.validateVoucherTemplateItems(req.Items, s.variablesOrEmpty(req.Variables)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) validateCodeAndTitleLength(code string, title string) error {
+	if err := textrule.VoucherTemplateCode.Validate(code); err != nil {
+		return err
+	}
+	if err := textrule.VoucherTemplateTitle.Validate(title); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) validateCodeUnique(code string, id int) error {
+	var existingTemplate models.VoucherTemplate
+	if err := s.db.Where("tenant_id = ? AND code = ? AND id != ?", s.tenantID, code, id).First(&existingTemplate).Error; err == nil {
+		return constants.ErrCodeAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) validateNumberPrefix(prefix string) error {
+	if utf8.RuneCountInString(prefix) > 32 {
+		return constants.ErrPrefixTooLong
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) validateSchedule(frequency string, cronExpression string, startsAt time.Time) error {
+	if startsAt.IsZero() {
+		return constants.ErrScheduleStartRequired
+	}
+
+	switch frequency {
+	case vouchertemplate.FrequencyMonthly, vouchertemplate.FrequencyQuarterly:
+		return nil
+	case vouchertemplate.FrequencyCron:
+		if len(cronExpression) > 128 {
+			return constants.ErrInvalidCronExpression
+		}
+		schedule, err := cron.Parse(cronExpression)
+		if err != nil {
+			return err
+		}
+		if schedule.Next(startsAt.UTC()).IsZero() {
+			return constants.ErrInvalidCronExpression
+		}
+		return nil
+	}
+	return constants.ErrInvalidFrequency
+}
+
+func (s *VoucherTemplateService) validateVoucherTemplateItems(items []vouchertemplate.ItemDetail, variables map[string]money.Amount) error {
+	if err := s.voucherService.validateCorrectVoucherItemNumber(len(items)); err != nil {
+		return err
+	}
+
+	var totalDebit, totalCredit money.Amount
+	for _, item := range items {
+		amount, err := s.validateVoucherTemplateItem(item, variables)
+		if err != nil {
+			return err
+		}
+		if item.Side == vouchertemplate.SideDebit {
+			totalDebit, err = totalDebit.Add(amount)
+		} else {
+			totalCredit, err = totalCredit.Add(amount)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if totalDebit != totalCredit {
+		return constants.ErrDebitCreditMismatch
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) validateVoucherTemplateItem(item vouchertemplate.ItemDetail, variables map[string]money.Amount) (money.Amount, error) {
+	if item.Side != vouchertemplate.SideDebit && item.Side != vouchertemplate.SideCredit {
+		return 0, constants.ErrInvalidTemplateItemSide
+	}
+	if item.Amount < 0 || (item.Amount > 0) == (item.Formula != "") {
+		return 0, constants.ErrTemplateItemAmountInvalid
+	}
+
+	amount, err := s.evaluateTemplateItemAmount(item.Amount, item.Formula, variables)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.voucherService.validateSLAndDL(item.SLID, item.DLID); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+func (s *VoucherTemplateService) evaluateTemplateItemAmount(amount money.Amount, formulaText string, variables map[string]money.Amount) (money.Amount, error) {
+	if formulaText == "" {
+		return amount, nil
+	}
+	if len(formulaText) > 256 {
+		return 0, constants.ErrInvalidFormula
+	}
+
+	expression, err := formula.Parse(formulaText)
+	if err != nil {
+		return 0, err
+	}
+	formulaVariables := make(map[string]int64, len(variables))
+	for name, variable := range variables {
+		formulaVariables[name] = int64(variable)
+	}
+	value, err := expression.Evaluate(formulaVariables)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		return 0, constants.ErrDebitOrCreditInvalid
+	}
+	return money.Amount(value), nil
+}
+
+func (s *VoucherTemplateService) firstRunAt(template *models.VoucherTemplate) time.Time {
+	return s.nextRunAfter(template, template.StartsAt.Add(-time.Nanosecond))
+}
+
+func (s *VoucherTemplateService) nextRunAfter(template *models.VoucherTemplate, after time.Time) time.Time {
+	if after.Before(template.StartsAt) {
+		after = template.StartsAt.Add(-time.Nanosecond)
+	}
+
+	switch template.Frequency {
+	case vouchertemplate.FrequencyMonthly:
+		return s.nextMonthlyRunAfter(template.StartsAt, 1, after)
+	case vouchertemplate.FrequencyQuarterly:
+		return s.nextMonthlyRunAfter(template.StartsAt, 3, after)
+	}
+
+	schedule, err := cron.Parse(template.CronExpression)
+	if err != nil {
+		return time.Time{}
+	}
+	return schedule.Next(after)
+}
+
+// Runs are counted from the start date so that a schedule starting on the
+// 31st lands on the last day of shorter months without drifting afterwards.
+func (s *VoucherTemplateService) nextMonthlyRunAfter(startsAt time.Time, step int, after time.Time) time.Time {
+	elapsedMonths := (after.Year()-startsAt.Year())*12 + int(after.Month()) - int(startsAt.Month())
+	months := 0
+	if elapsedMonths > step {
+		months = (elapsedMonths/step - 1) * step
+	}
+
+	for {
+		run := s.addMonthsClamped(startsAt, months)
+		if run.After(after) {
+			return run
+		}
+		months += step
+	}
+}
+
+func (s *VoucherTemplateService) addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func (s *VoucherTemplateService) validateVoucherTemplateExists(id int) (*models.VoucherTemplate, error) {
+	var targetTemplate models.VoucherTemplate
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetTemplate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrVoucherTemplateNotFound
+		}
+		return nil, err
+	}
+	return &targetTemplate, nil
+}
+
+func (s *VoucherTemplateService) validateVersion(reqVersion int, targetVersion int) error {
+	if reqVersion != targetVersion {
+		return constants.ErrVersionOutdated
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) applyVoucherTemplateUpdate(req *vouchertemplate.UpdateRequest, targetTemplate *models.VoucherTemplate) (*dtos.VoucherTemplateDto, error) {
+	variables, err := json.Marshal(s.variablesOrEmpty(req.Variables))
+	if err != nil {
+		return nil, err
+	}
+
+	targetTemplate.Code = req.Code
+	targetTemplate.Title = req.Title
+	targetTemplate.NumberPrefix = req.NumberPrefix
+	targetTemplate.Variables = string(variables)
+	targetTemplate.Frequency = req.Frequency
+	targetTemplate.CronExpression = req.CronExpression
+	targetTemplate.StartsAt = req.StartsAt.UTC()
+	targetTemplate.Active = req.Active
+	targetTemplate.RowVersion++
+	if targetTemplate.LastRunAt.Valid {
+		targetTemplate.NextRunAt = s.nextRunAfter(targetTemplate, targetTemplate.LastRunAt.Time)
+	} else {
+		targetTemplate.NextRunAt = s.firstRunAt(targetTemplate)
+	}
+
+	var items []models.VoucherTemplateItem
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(targetTemplate).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", targetTemplate.ID).Delete(&models.VoucherTemplateItem{}).Error; err != nil {
+			return err
+		}
+		items, err = s.insertVoucherTemplateItems(tx, targetTemplate.ID, req.Items)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToVoucherTemplateDto(targetTemplate, items), nil
+}
+
+func (s *VoucherTemplateService) validateVoucherTemplateUpdateRequest(req *vouchertemplate.UpdateRequest) (*models.VoucherTemplate, error) {
+	req.Code = normalize.Text(req.Code)
+	req.Title = normalize.Text(req.Title)
+	if err := s.validateCodeAndTitleLength(req.Code, req.Title); err != nil {
+		return nil, err
+	}
+	targetTemplate, err := s.validateVoucherTemplateExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetTemplate.RowVersion); err != nil {
+		return nil, err
+	}
+	if err := s.validateCodeUnique(req.Code, req.ID); err != nil {
+		return nil, err
+	}
+	if err := s.validateNumberPrefix(req.NumberPrefix); err != nil {
+		return nil, err
+	}
+	if err := s.validateSchedule(req.Frequency, req.CronExpression, req.StartsAt); err != nil {
+		return nil, err
+	}
+	if err := s.validateVoucherTemplateItems(req.Items, s.variablesOrEmpty(req.Variables)); err != nil {
+		return nil, err
+	}
+	return targetTemplate, nil
+}
+
+func (s *VoucherTemplateService) applyVoucherTemplateDeletion(targetTemplate *models.VoucherTemplate) error {
+	if err := s.db.Delete(targetTemplate).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *VoucherTemplateService) validateVoucherTemplateDeleteRequest(req *vouchertemplate.DeleteRequest) (*models.VoucherTemplate, error) {
+	targetTemplate, err := s.validateVoucherTemplateExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetTemplate.RowVersion); err != nil {
+		return nil, err
+	}
+	return targetTemplate, nil
+}
+
+func (s *VoucherTemplateService) applyVoucherTemplateGet(targetTemplate *models.VoucherTemplate) (*dtos.VoucherTemplateDto, error) {
+	var items []models.VoucherTemplateItem
+	if err := s.db.Where("template_id = ?", targetTemplate.ID).Order("id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToVoucherTemplateDto(targetTemplate, items), nil
+}
+
+func (s *VoucherTemplateService) applyDueVoucherTemplatesRun(now time.Time) (int, error) {
+	var dueTemplateIDs []int
+	if err := s.db.Model(&models.VoucherTemplate{}).
+		Where("active AND next_run_at <= ?", now).
+		Order("next_run_at").
+		Pluck("id", &dueTemplateIDs).Error; err != nil {
+		return 0, err
+	}
+
+	createdCount := 0
+	for _, id := range dueTemplateIDs {
+		count, err := s.runVoucherTemplate(id, now)
+		createdCount += count
+		if err != nil {
+			log.Printf("voucher template %d could not be run: %v", id, err)
+		}
+	}
+	return createdCount, nil
+}
+
+// runVoucherTemplate materializes every occurrence of the template that is
+// due by now. The template row is locked so that concurrent schedulers skip
+// it, and the vouchers are created in the same transaction that records the
+// run. Each voucher keeps the template and occurrence it was posted for, so
+// an occurrence that was already posted is skipped instead of being posted
+// twice.
+func (s *VoucherTemplateService) runVoucherTemplate(id int, now time.Time) (int, error) {
+	createdCount := 0
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var template models.VoucherTemplate
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND active AND next_run_at <= ?", id, now).
+			First(&template).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var items []models.VoucherTemplateItem
+		if err := tx.Where("template_id = ?", template.ID).Order("id").Find(&items).Error; err != nil {
+			return err
+		}
+
+		voucherService := &VoucherService{}
+		voucherService.InitService(tx)
+		for runs := 0; runs < maxTemplateCatchUpRuns && !template.NextRunAt.IsZero() && !template.NextRunAt.After(now); runs++ {
+			occurrence := template.NextRunAt
+			created, err := s.runTemplateOccurrence(tx, voucherService, &template, items, occurrence)
+			if err != nil {
+				return err
+			}
+			if created {
+				createdCount++
+			}
+
+			template.LastRunAt = sql.NullTime{Time: occurrence, Valid: true}
+			template.NextRunAt = s.nextRunAfter(&template, occurrence)
+		}
+
+		if template.NextRunAt.IsZero() {
+			template.Active = false
+			template.NextRunAt = template.LastRunAt.Time
+		}
+
+		return tx.Model(&template).Updates(map[string]interface{}{
+			"last_run_at": template.LastRunAt,
+			"next_run_at": template.NextRunAt,
+			"active":      template.Active,
+		}).Error
+	})
+	return createdCount, err
+}
+
+// runTemplateOccurrence posts the voucher of one occurrence on tx unless it
+// was posted already, and reports whether it posted one.
+func (s *VoucherTemplateService) runTemplateOccurrence(tx *gorm.DB, voucherService *VoucherService, template *models.VoucherTemplate, items []models.VoucherTemplateItem, occurrence time.Time) (bool, error) {
+	var posted int64
+	if err := tx.Model(&models.Voucher{}).
+		Where("template_id = ? AND template_run_at = ?", template.ID, occurrence).
+		Count(&posted).Error; err != nil {
+		return false, err
+	}
+	if posted > 0 {
+		return false, nil
+	}
+
+	insertReq, err := s.buildVoucherInsertRequest(template, items, occurrence)
+	if err != nil {
+		return false, err
+	}
+	createdVoucher, err := voucherService.CreateVoucher(auth.System(template.TenantID), insertReq)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Model(&models.Voucher{}).Where("tenant_id = ? AND id = ?", template.TenantID, createdVoucher.ID).Updates(map[string]interface{}{
+		"template_id":     template.ID,
+		"template_run_at": occurrence,
+	}).Error
+}
+
+func (s *VoucherTemplateService) buildVoucherInsertRequest(template *models.VoucherTemplate, items []models.VoucherTemplateItem, occurrence time.Time) (*voucher.InsertRequest, error) {
+	variables := map[string]money.Amount{}
+	if err := json.Unmarshal([]byte(template.Variables), &variables); err != nil {
+		return nil, err
+	}
+
+	voucherItems := make([]voucher.VoucherItemInsertDetail, len(items))
+	for i, item := range items {
+		amount, err := s.evaluateTemplateItemAmount(item.Amount, item.Formula, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		voucherItems[i] = voucher.VoucherItemInsertDetail{SLID: item.SLID}
+		if item.DLID.Valid {
+			dlID := int(item.DLID.Int64)
+			voucherItems[i].DLID = &dlID
+		}
+		if item.Side == vouchertemplate.SideDebit {
+			voucherItems[i].Debit = amount
+		} else {
+			voucherItems[i].Credit = amount
+		}
+	}
+
+	return &voucher.InsertRequest{
+		Number:       s.occurrenceVoucherNumber(template, occurrence),
+		Date:         occurrence,
+		VoucherItems: voucherItems,
+	}, nil
+}
+
+func (s *VoucherTemplateService) occurrenceVoucherNumber(template *models.VoucherTemplate, occurrence time.Time) string {
+	return fmt.Sprintf("%s%d-%s", template.NumberPrefix, template.ID, occurrence.Format("200601021504"))
+}
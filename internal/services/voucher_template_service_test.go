@@ -0,0 +1,263 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/money"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/voucher"
+	"accountingsystem/internal/requests/vouchertemplate"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTemplateItems(debitFormula string) ([]vouchertemplate.ItemDetail, error) {
+	debitSL, err := createRandomSL(false)
+	if err != nil {
+		return nil, err
+	}
+
+	creditSL, err := createRandomSL(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return []vouchertemplate.ItemDetail{
+		{
+			SLID:    debitSL.ID,
+			Side:    vouchertemplate.SideDebit,
+			Formula: debitFormula,
+		},
+		{
+			SLID:   creditSL.ID,
+			Side:   vouchertemplate.SideCredit,
+			Amount: 1200,
+		},
+	}, nil
+}
+
+func createRandomTemplateInsertRequest(startsAt time.Time) (*vouchertemplate.InsertRequest, error) {
+	items, err := createRandomTemplateItems("rent * 2")
+	if err != nil {
+		return nil, err
+	}
+
+	return &vouchertemplate.InsertRequest{
+		Code:         "TPL" + generateRandomString(20),
+		Title:        "Rent " + generateRandomString(10),
+		NumberPrefix: generateRandomString(8) + "-",
+		Variables:    map[string]money.Amount{"rent": 600},
+		Items:        items,
+		Frequency:    vouchertemplate.FrequencyMonthly,
+		StartsAt:     startsAt,
+	}, nil
+}
+
+func Test_CreateVoucherTemplate_Succeeds_WithValidRequest(t *testing.T) {
+	startsAt := time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC)
+	req, err := createRandomTemplateInsertRequest(startsAt)
+	require.Nil(t, err)
+
+	template, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+
+	require.Nil(t, err)
+	assert.Equal(t, req.Code, template.Code)
+	assert.True(t, template.Active)
+	assert.True(t, startsAt.Equal(template.NextRunAt))
+	assert.Nil(t, template.LastRunAt)
+	assert.Len(t, template.Items, 2)
+}
+
+func Test_CreateVoucherTemplate_ReturnsErrInvalidFrequency_WithUnknownFrequency(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	req.Frequency = "weekly"
+
+	template, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidFrequency)
+	assert.Nil(t, template)
+}
+
+func Test_CreateVoucherTemplate_ReturnsErrInvalidCronExpression_WithInvalidCron(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	req.Frequency = vouchertemplate.FrequencyCron
+	req.CronExpression = "0 0 32 * *"
+
+	template, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidCronExpression)
+	assert.Nil(t, template)
+}
+
+func Test_CreateVoucherTemplate_ReturnsErrInvalidFormula_WithUndefinedVariable(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	req.Items[0].Formula = "salary * 2"
+
+	template, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrInvalidFormula)
+	assert.Nil(t, template)
+}
+
+func Test_CreateVoucherTemplate_ReturnsErrDebitCreditMismatch_WithUnbalancedItems(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	req.Variables["rent"] = 601
+
+	template, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrDebitCreditMismatch)
+	assert.Nil(t, template)
+}
+
+func Test_CreateVoucherTemplate_ReturnsErrTemplateItemAmountInvalid_WithAmountAndFormula(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	req.Items[0].Amount = 1200
+
+	template, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrTemplateItemAmountInvalid)
+	assert.Nil(t, template)
+}
+
+func Test_RunDueVoucherTemplates_CreatesEachPastOccurrenceOnce(t *testing.T) {
+	startsAt := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	req, err := createRandomTemplateInsertRequest(startsAt)
+	require.Nil(t, err)
+	createdTemplate, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+	require.Nil(t, err)
+	now := time.Date(2020, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err = voucherTemplateService.RunDueVoucherTemplates(now)
+	require.Nil(t, err)
+
+	for _, occurrence := range []string{"202001310000", "202002290000", "202003310000"} {
+		createdVoucher, err := voucherService.GetVoucherByNumber(testAdmin, &voucher.GetByNumberRequest{
+			Number: req.NumberPrefix + strconv.Itoa(createdTemplate.ID) + "-" + occurrence,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, occurrence[:8], createdVoucher.Date.Format("20060102"))
+		assert.Equal(t, money.Amount(1200), createdVoucher.VoucherItems[0].Debit+createdVoucher.VoucherItems[0].Credit)
+	}
+	template, err := voucherTemplateService.GetVoucherTemplate(testAdmin, &vouchertemplate.GetRequest{ID: createdTemplate.ID})
+	require.Nil(t, err)
+	assert.True(t, time.Date(2020, 4, 30, 0, 0, 0, 0, time.UTC).Equal(template.NextRunAt))
+	require.NotNil(t, template.LastRunAt)
+	assert.True(t, time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC).Equal(*template.LastRunAt))
+}
+
+func Test_RunDueVoucherTemplates_DoesNotDuplicateVouchers_AfterLostProgress(t *testing.T) {
+	startsAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	req, err := createRandomTemplateInsertRequest(startsAt)
+	require.Nil(t, err)
+	req.Frequency = vouchertemplate.FrequencyQuarterly
+	createdTemplate, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+	require.Nil(t, err)
+	now := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	_, err = voucherTemplateService.RunDueVoucherTemplates(now)
+	require.Nil(t, err)
+
+	// Simulate a crash between posting vouchers and recording the run.
+	err = voucherTemplateService.db.Model(&models.VoucherTemplate{}).
+		Where("id = ?", createdTemplate.ID).
+		Update("next_run_at", startsAt).Error
+	require.Nil(t, err)
+	_, err = voucherTemplateService.RunDueVoucherTemplates(now)
+	require.Nil(t, err)
+
+	var count int64
+	err = voucherService.db.Model(&models.Voucher{}).
+		Where("number LIKE ?", req.NumberPrefix+"%").
+		Count(&count).Error
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func Test_RunDueVoucherTemplates_RecordsTemplateRun_OnCreatedVouchers(t *testing.T) {
+	startsAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	req, err := createRandomTemplateInsertRequest(startsAt)
+	require.Nil(t, err)
+	createdTemplate, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+	require.Nil(t, err)
+
+	_, err = voucherTemplateService.RunDueVoucherTemplates(time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.Nil(t, err)
+
+	var createdVoucher models.Voucher
+	err = voucherService.db.Where("number LIKE ?", req.NumberPrefix+"%").First(&createdVoucher).Error
+	require.Nil(t, err)
+	assert.Equal(t, int64(createdTemplate.ID), createdVoucher.TemplateID.Int64)
+	assert.True(t, startsAt.Equal(createdVoucher.TemplateRunAt.Time))
+}
+
+func Test_RunDueVoucherTemplates_KeepsOccurrenceDue_WithNumberTakenByHand(t *testing.T) {
+	startsAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	req, err := createRandomTemplateInsertRequest(startsAt)
+	require.Nil(t, err)
+	createdTemplate, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+	require.Nil(t, err)
+	items, err := createRandomBalancedItems(100)
+	require.Nil(t, err)
+	_, err = voucherService.CreateVoucher(testAdmin, &voucher.InsertRequest{
+		Number:       req.NumberPrefix + strconv.Itoa(createdTemplate.ID) + "-202201010000",
+		VoucherItems: items,
+	})
+	require.Nil(t, err)
+
+	_, err = voucherTemplateService.RunDueVoucherTemplates(time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	require.Nil(t, err)
+	template, err := voucherTemplateService.GetVoucherTemplate(testAdmin, &vouchertemplate.GetRequest{ID: createdTemplate.ID})
+	require.Nil(t, err)
+	assert.True(t, startsAt.Equal(template.NextRunAt))
+}
+
+func Test_UpdateVoucherTemplate_ReturnsErrVersionOutdated_WithOldVersion(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	createdTemplate, err := voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+	require.Nil(t, err)
+
+	template, err := voucherTemplateService.UpdateVoucherTemplate(testAdmin, &vouchertemplate.UpdateRequest{
+		ID:        createdTemplate.ID,
+		Code:      req.Code,
+		Title:     req.Title,
+		Variables: req.Variables,
+		Items:     req.Items,
+		Frequency: req.Frequency,
+		StartsAt:  req.StartsAt,
+		Active:    true,
+		Version:   createdTemplate.RowVersion + 1,
+	})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrVersionOutdated)
+	assert.Nil(t, template)
+}
+
+func Test_DeleteSL_ReturnsErrThereIsRefrenceToSL_WithTemplateReference(t *testing.T) {
+	req, err := createRandomTemplateInsertRequest(time.Now())
+	require.Nil(t, err)
+	_, err = voucherTemplateService.CreateVoucherTemplate(testAdmin, req)
+	require.Nil(t, err)
+	targetSL, err := slService.GetSL(testAdmin, &sl.GetRequest{ID: req.Items[0].SLID})
+	require.Nil(t, err)
+
+	err = slService.DeleteSL(testAdmin, &sl.DeleteRequest{ID: targetSL.ID, Version: targetSL.RowVersion})
+
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, constants.ErrThereIsRefrenceToSL)
+}
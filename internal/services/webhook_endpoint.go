@@ -0,0 +1,186 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/requests/webhook"
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookService manages the webhook subscriptions of a tenant and delivers
+// the events they subscribed to. Deliveries are queued together with the
+// events by the service mutations, posted with a signature over the payload
+// and retried with a growing delay until they succeed or run out of attempts.
+type WebhookService struct {
+	db       *gorm.DB
+	tenantID int
+	client   *http.Client
+}
+
+func (s *WebhookService) InitService(db *gorm.DB) {
+	s.db = db
+	s.client = &http.Client{Timeout: webhookRequestTimeout}
+}
+
+func (s *WebhookService) forTenant(tenantID int) *WebhookService {
+	scoped := *s
+	scoped.tenantID = tenantID
+	return &scoped
+}
+
+func (s *WebhookService) CreateWebhook(actor auth.Principal, req *webhook.InsertRequest) (*dtos.WebhookSubscriptionDto, error) {
+	if err := auth.Authorize(actor, auth.EntityWebhook, auth.ActionCreate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	if err := s.validateWebhookInsertRequest(req); err != nil {
+		return nil, err
+	}
+
+	subscriptionDto, err := s.applyWebhookCreation(req)
+	if err != nil {
+		log.Printf("unexpected error while creating webhook: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return subscriptionDto, nil
+}
+
+func (s *WebhookService) UpdateWebhook(actor auth.Principal, req *webhook.UpdateRequest) (*dtos.WebhookSubscriptionDto, error) {
+	if err := auth.Authorize(actor, auth.EntityWebhook, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSubscription, err := s.validateWebhookUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionDto, err := s.applyWebhookUpdate(req, targetSubscription)
+	if err != nil {
+		log.Printf("unexpected error while updating webhook: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return subscriptionDto, nil
+}
+
+func (s *WebhookService) DeleteWebhook(actor auth.Principal, req *webhook.DeleteRequest) error {
+	if err := auth.Authorize(actor, auth.EntityWebhook, auth.ActionDelete); err != nil {
+		return err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSubscription, err := s.validateWebhookDeleteRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyWebhookDeletion(targetSubscription); err != nil {
+		log.Printf("unexpected error while deleting webhook: %v", err)
+		return constants.ErrUnexpectedError
+	}
+
+	return nil
+}
+
+func (s *WebhookService) GetWebhook(actor auth.Principal, req *webhook.GetRequest) (*dtos.WebhookSubscriptionDto, error) {
+	if err := auth.Authorize(actor, auth.EntityWebhook, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSubscription, err := s.validateWebhookExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionDto, err := s.applyWebhookGet(targetSubscription)
+	if err != nil {
+		log.Printf("unexpected error while getting webhook: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return subscriptionDto, nil
+}
+
+func (s *WebhookService) GetWebhookDeliveries(actor auth.Principal, req *webhook.GetDeliveriesRequest) ([]dtos.WebhookDeliveryDto, error) {
+	if err := auth.Authorize(actor, auth.EntityWebhook, auth.ActionRead); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetSubscription, err := s.validateGetWebhookDeliveriesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryDtos, err := s.applyWebhookDeliveriesGet(targetSubscription, req.Status)
+	if err != nil {
+		log.Printf("unexpected error while getting webhook deliveries: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return deliveryDtos, nil
+}
+
+// ReplayWebhookDelivery queues the event of a delivery again for its
+// subscription. The new delivery refers to the replayed one, which is kept
+// in the log as it is.
+func (s *WebhookService) ReplayWebhookDelivery(actor auth.Principal, req *webhook.ReplayRequest) (*dtos.WebhookDeliveryDto, error) {
+	if err := auth.Authorize(actor, auth.EntityWebhook, auth.ActionUpdate); err != nil {
+		return nil, err
+	}
+	s = s.forTenant(actor.TenantID)
+
+	targetDelivery, err := s.validateWebhookDeliveryExists(req.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryDto, err := s.applyWebhookDeliveryReplay(targetDelivery)
+	if err != nil {
+		log.Printf("unexpected error while replaying webhook delivery: %v", err)
+		return nil, constants.ErrUnexpectedError
+	}
+
+	return deliveryDto, nil
+}
+
+// DeliverPending posts the deliveries that are due and returns how many of
+// them succeeded.
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	succeededCount, err := s.applyPendingDeliveries(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() != nil {
+			return succeededCount, contextError(ctx, err)
+		}
+		log.Printf("unexpected error while delivering webhooks: %v", err)
+		return succeededCount, constants.ErrUnexpectedError
+	}
+
+	return succeededCount, nil
+}
+
+func (s *WebhookService) RunDeliverer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.DeliverPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,485 @@
+package services
+
+import (
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/mappers"
+	"accountingsystem/internal/models"
+	"accountingsystem/internal/requests/webhook"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	webhookRequestTimeout = 10 * time.Second
+	webhookBatchSize      = 50
+	webhookMaxAttempts    = 8
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = time.Hour
+	webhookDeliveryLimit  = 100
+	webhookSecretBytes    = 32
+)
+
+// webhookClaimTimeout outlasts a batch whose every request times out, so a
+// batch is only taken over from a deliverer that stopped.
+const webhookClaimTimeout = webhookBatchSize*webhookRequestTimeout + time.Minute
+
+func (s *WebhookService) applyWebhookCreation(req *webhook.InsertRequest) (*dtos.WebhookSubscriptionDto, error) {
+	secret := req.Secret
+	if secret == "" {
+		generated, err := s.generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := models.WebhookSubscription{
+		TenantID:   s.tenantID,
+		URL:        req.URL,
+		Secret:     secret,
+		Active:     true,
+		RowVersion: 0,
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&subscription).Error; err != nil {
+			return err
+		}
+		return s.saveSubscriptionEvents(tx, subscription.ID, req.EventTypes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionDto := mappers.ToWebhookSubscriptionDto(&subscription, req.EventTypes)
+	subscriptionDto.Secret = secret
+	return subscriptionDto, nil
+}
+
+func (s *WebhookService) generateSecret() (string, error) {
+	raw := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func (s *WebhookService) saveSubscriptionEvents(tx *gorm.DB, subscriptionID int, eventTypes []string) error {
+	if err := tx.Where("subscription_id = ?", subscriptionID).Delete(&models.WebhookSubscriptionEvent{}).Error; err != nil {
+		return err
+	}
+	subscriptionEvents := make([]models.WebhookSubscriptionEvent, len(eventTypes))
+	for i, eventType := range eventTypes {
+		subscriptionEvents[i] = models.WebhookSubscriptionEvent{SubscriptionID: subscriptionID, EventType: eventType}
+	}
+	return tx.Create(&subscriptionEvents).Error
+}
+
+func (s *WebhookService) validateWebhookInsertRequest(req *webhook.InsertRequest) error {
+	if err := s.validateWebhookURL(req.URL); err != nil {
+		return err
+	}
+	eventTypes, err := s.validateEventTypes(req.EventTypes)
+	if err != nil {
+		return err
+	}
+	req.EventTypes = eventTypes
+	if req.Secret != "" {
+		return s.validateSecret(req.Secret)
+	}
+	return nil
+}
+
+func (s *WebhookService) validateWebhookURL(rawURL string) error {
+	if len(rawURL) > 2048 {
+		return constants.ErrInvalidWebhookURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return constants.ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// validateEventTypes returns the event types sorted and without duplicates.
+func (s *WebhookService) validateEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, constants.ErrWebhookEventTypesEmpty
+	}
+	unique := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !events.IsKnownType(eventType) {
+			return nil, constants.ErrUnknownEventType
+		}
+		unique[eventType] = true
+	}
+	deduplicated := make([]string, 0, len(unique))
+	for eventType := range unique {
+		deduplicated = append(deduplicated, eventType)
+	}
+	sort.Strings(deduplicated)
+	return deduplicated, nil
+}
+
+func (s *WebhookService) validateSecret(secret string) error {
+	length := utf8.RuneCountInString(secret)
+	if length < 16 || length > 128 {
+		return constants.ErrInvalidWebhookSecret
+	}
+	return nil
+}
+
+func (s *WebhookService) applyWebhookUpdate(req *webhook.UpdateRequest, targetSubscription *models.WebhookSubscription) (*dtos.WebhookSubscriptionDto, error) {
+	targetSubscription.URL = req.URL
+	targetSubscription.Active = req.Active
+	if req.Secret != "" {
+		targetSubscription.Secret = req.Secret
+	}
+	targetSubscription.RowVersion++
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(targetSubscription).Error; err != nil {
+			return err
+		}
+		return s.saveSubscriptionEvents(tx, targetSubscription.ID, req.EventTypes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.ToWebhookSubscriptionDto(targetSubscription, req.EventTypes), nil
+}
+
+func (s *WebhookService) validateWebhookUpdateRequest(req *webhook.UpdateRequest) (*models.WebhookSubscription, error) {
+	targetSubscription, err := s.validateWebhookExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetSubscription.RowVersion); err != nil {
+		return nil, err
+	}
+	if err := s.validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := s.validateEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	req.EventTypes = eventTypes
+	if req.Secret != "" {
+		if err := s.validateSecret(req.Secret); err != nil {
+			return nil, err
+		}
+	}
+	return targetSubscription, nil
+}
+
+func (s *WebhookService) validateWebhookExists(id int) (*models.WebhookSubscription, error) {
+	var targetSubscription models.WebhookSubscription
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetSubscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &targetSubscription, nil
+}
+
+func (s *WebhookService) validateVersion(reqVersion int, targetVersion int) error {
+	if reqVersion != targetVersion {
+		return constants.ErrVersionOutdated
+	}
+	return nil
+}
+
+func (s *WebhookService) applyWebhookDeletion(targetSubscription *models.WebhookSubscription) error {
+	return s.db.Delete(targetSubscription).Error
+}
+
+func (s *WebhookService) validateWebhookDeleteRequest(req *webhook.DeleteRequest) (*models.WebhookSubscription, error) {
+	targetSubscription, err := s.validateWebhookExists(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVersion(req.Version, targetSubscription.RowVersion); err != nil {
+		return nil, err
+	}
+	return targetSubscription, nil
+}
+
+func (s *WebhookService) applyWebhookGet(targetSubscription *models.WebhookSubscription) (*dtos.WebhookSubscriptionDto, error) {
+	var eventTypes []string
+	if err := s.db.Model(&models.WebhookSubscriptionEvent{}).
+		Where("subscription_id = ?", targetSubscription.ID).
+		Order("event_type").
+		Pluck("event_type", &eventTypes).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToWebhookSubscriptionDto(targetSubscription, eventTypes), nil
+}
+
+func (s *WebhookService) validateGetWebhookDeliveriesRequest(req *webhook.GetDeliveriesRequest) (*models.WebhookSubscription, error) {
+	targetSubscription, err := s.validateWebhookExists(req.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Status {
+	case "", webhook.DeliveryPending, webhook.DeliverySucceeded, webhook.DeliveryFailed:
+	default:
+		return nil, constants.ErrInvalidDeliveryStatus
+	}
+	return targetSubscription, nil
+}
+
+// applyWebhookDeliveriesGet returns the latest deliveries first.
+func (s *WebhookService) applyWebhookDeliveriesGet(targetSubscription *models.WebhookSubscription, status string) ([]dtos.WebhookDeliveryDto, error) {
+	var rows []struct {
+		models.WebhookDelivery
+		EventType string
+	}
+	query := s.db.Table("webhook_delivery").
+		Select("webhook_delivery.*, outbox_event.type AS event_type").
+		Joins("JOIN outbox_event ON outbox_event.id = webhook_delivery.event_id").
+		Where("webhook_delivery.tenant_id = ? AND webhook_delivery.subscription_id = ?", s.tenantID, targetSubscription.ID)
+	if status != "" {
+		query = query.Where("webhook_delivery.status = ?", status)
+	}
+	if err := query.Order("webhook_delivery.id DESC").Limit(webhookDeliveryLimit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	deliveryDtos := make([]dtos.WebhookDeliveryDto, len(rows))
+	for i := range rows {
+		deliveryDtos[i] = *mappers.ToWebhookDeliveryDto(&rows[i].WebhookDelivery, rows[i].EventType)
+	}
+	return deliveryDtos, nil
+}
+
+func (s *WebhookService) validateWebhookDeliveryExists(id int64) (*models.WebhookDelivery, error) {
+	var targetDelivery models.WebhookDelivery
+	if err := s.db.Where("tenant_id = ? AND id = ?", s.tenantID, id).First(&targetDelivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &targetDelivery, nil
+}
+
+func (s *WebhookService) applyWebhookDeliveryReplay(targetDelivery *models.WebhookDelivery) (*dtos.WebhookDeliveryDto, error) {
+	replay := models.WebhookDelivery{
+		TenantID:       s.tenantID,
+		SubscriptionID: targetDelivery.SubscriptionID,
+		EventID:        targetDelivery.EventID,
+		ReplayOfID:     sql.NullInt64{Int64: targetDelivery.ID, Valid: true},
+		Status:         webhook.DeliveryPending,
+		NextAttemptAt:  time.Now().UTC(),
+	}
+	if err := s.db.Create(&replay).Error; err != nil {
+		return nil, err
+	}
+
+	var event models.OutboxEvent
+	if err := s.db.Select("type").Where("id = ?", replay.EventID).First(&event).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToWebhookDeliveryDto(&replay, event.Type), nil
+}
+
+// applyPendingDeliveries posts a batch of due deliveries of active
+// subscriptions. The batch is claimed in a short transaction that moves
+// next_attempt_at past the claim timeout, so concurrent deliverers skip it
+// while the requests are sent without holding any lock. Deliveries of an
+// inactive subscription stay pending until it is activated again.
+func (s *WebhookService) applyPendingDeliveries(ctx context.Context, now time.Time) (int, error) {
+	claimedDeliveries, subscriptions, outboxEvents, err := s.claimDueDeliveries(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	succeededCount := 0
+	for i := range claimedDeliveries {
+		delivery := &claimedDeliveries[i]
+		subscription := subscriptions[delivery.SubscriptionID]
+		if ctx.Err() != nil || subscription == nil || !subscription.Active {
+			if err := s.releaseClaim(delivery, now); err != nil {
+				return succeededCount, err
+			}
+			continue
+		}
+
+		responseStatus, deliveryErr := s.postDelivery(ctx, subscription, outboxEvents[delivery.EventID], delivery)
+		if deliveryErr != nil && ctx.Err() != nil {
+			if err := s.releaseClaim(delivery, now); err != nil {
+				return succeededCount, err
+			}
+			continue
+		}
+		if err := s.saveDeliveryOutcome(delivery, responseStatus, deliveryErr, now); err != nil {
+			return succeededCount, err
+		}
+		if deliveryErr == nil {
+			succeededCount++
+		}
+	}
+	if ctx.Err() != nil {
+		return succeededCount, ctx.Err()
+	}
+	return succeededCount, nil
+}
+
+func (s *WebhookService) claimDueDeliveries(ctx context.Context, now time.Time) ([]models.WebhookDelivery, map[int]*models.WebhookSubscription, map[int64]*models.OutboxEvent, error) {
+	var claimedDeliveries []models.WebhookDelivery
+	var subscriptions map[int]*models.WebhookSubscription
+	var outboxEvents map[int64]*models.OutboxEvent
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", webhook.DeliveryPending, now).
+			Where("EXISTS (SELECT 1 FROM webhook_subscription WHERE webhook_subscription.id = webhook_delivery.subscription_id AND webhook_subscription.active)").
+			Order("id").
+			Limit(webhookBatchSize).
+			Find(&claimedDeliveries).Error; err != nil {
+			return err
+		}
+		if len(claimedDeliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(claimedDeliveries))
+		for i := range claimedDeliveries {
+			ids[i] = claimedDeliveries[i].ID
+		}
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(webhookClaimTimeout)).Error; err != nil {
+			return err
+		}
+
+		var err error
+		subscriptions, outboxEvents, err = s.loadDeliveryTargets(tx, claimedDeliveries)
+		return err
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return claimedDeliveries, subscriptions, outboxEvents, nil
+}
+
+// releaseClaim makes a delivery that was claimed but not attempted due again.
+func (s *WebhookService) releaseClaim(delivery *models.WebhookDelivery, now time.Time) error {
+	return s.db.Model(delivery).Update("next_attempt_at", now).Error
+}
+
+func (s *WebhookService) loadDeliveryTargets(tx *gorm.DB, deliveries []models.WebhookDelivery) (map[int]*models.WebhookSubscription, map[int64]*models.OutboxEvent, error) {
+	subscriptionIDs := make([]int, len(deliveries))
+	eventIDs := make([]int64, len(deliveries))
+	for i, delivery := range deliveries {
+		subscriptionIDs[i] = delivery.SubscriptionID
+		eventIDs[i] = delivery.EventID
+	}
+
+	var subscriptionList []models.WebhookSubscription
+	if err := tx.Where("id IN ?", subscriptionIDs).Find(&subscriptionList).Error; err != nil {
+		return nil, nil, err
+	}
+	var eventList []models.OutboxEvent
+	if err := tx.Where("id IN ?", eventIDs).Find(&eventList).Error; err != nil {
+		return nil, nil, err
+	}
+
+	subscriptions := make(map[int]*models.WebhookSubscription, len(subscriptionList))
+	for i := range subscriptionList {
+		subscriptions[subscriptionList[i].ID] = &subscriptionList[i]
+	}
+	outboxEvents := make(map[int64]*models.OutboxEvent, len(eventList))
+	for i := range eventList {
+		outboxEvents[eventList[i].ID] = &eventList[i]
+	}
+	return subscriptions, outboxEvents, nil
+}
+
+// postDelivery sends the event to the subscription and returns the status it
+// answered with. Any status other than 2xx is a failed attempt.
+func (s *WebhookService) postDelivery(ctx context.Context, subscription *models.WebhookSubscription, outboxEvent *models.OutboxEvent, delivery *models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(mappers.ToEvent(outboxEvent))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(events.SignatureHeader, events.Sign(subscription.Secret, timestamp, body))
+	req.Header.Set(events.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(events.EventTypeHeader, outboxEvent.Type)
+	req.Header.Set(events.DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// saveDeliveryOutcome is not bound to the delivery context, so the outcome of
+// a request is kept when the deliverer is stopped right after it.
+func (s *WebhookService) saveDeliveryOutcome(delivery *models.WebhookDelivery, responseStatus int, deliveryErr error, now time.Time) error {
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	switch {
+	case deliveryErr == nil:
+		delivery.Status = webhook.DeliverySucceeded
+		delivery.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+		delivery.LastError = ""
+	case delivery.Attempts >= webhookMaxAttempts:
+		delivery.Status = webhook.DeliveryFailed
+		delivery.LastError = deliveryErr.Error()
+	default:
+		delivery.NextAttemptAt = now.Add(s.retryDelay(delivery.Attempts))
+		delivery.LastError = deliveryErr.Error()
+	}
+
+	return s.db.Model(delivery).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"response_status": delivery.ResponseStatus,
+		"last_error":      delivery.LastError,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Error
+}
+
+// retryDelay doubles the wait after each failed attempt, from 30 seconds up
+// to an hour.
+func (s *WebhookService) retryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookRetryMaxDelay)
+}
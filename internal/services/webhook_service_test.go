@@ -0,0 +1,224 @@
+package services
+
+import (
+	"accountingsystem/internal/auth"
+	"accountingsystem/internal/constants"
+	"accountingsystem/internal/dtos"
+	"accountingsystem/internal/events"
+	"accountingsystem/internal/requests/sl"
+	"accountingsystem/internal/requests/webhook"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+	event  events.Event
+}
+
+// startWebhookReceiver serves webhooks answering with status and records the
+// requests it got.
+func startWebhookReceiver(t *testing.T, status int) (*httptest.Server, func() []receivedWebhook) {
+	var mu sync.Mutex
+	var received []receivedWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event events.Event
+		json.Unmarshal(body, &event)
+		mu.Lock()
+		received = append(received, receivedWebhook{header: r.Header.Clone(), body: body, event: event})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []receivedWebhook {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedWebhook(nil), received...)
+	}
+}
+
+func createTestWebhook(t *testing.T, url string, eventTypes ...string) *dtos.WebhookSubscriptionDto {
+	subscriptionDto, err := webhookService.CreateWebhook(testAdmin, &webhook.InsertRequest{URL: url, EventTypes: eventTypes})
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		webhookService.DeleteWebhook(testAdmin, &webhook.DeleteRequest{ID: subscriptionDto.ID, Version: subscriptionDto.RowVersion})
+	})
+	return subscriptionDto
+}
+
+func deliverUntil(t *testing.T, done func() bool) {
+	for i := 0; i < 20 && !done(); i++ {
+		_, err := webhookService.DeliverPending(context.Background())
+		require.Nil(t, err)
+	}
+}
+
+func Test_CreateWebhook_Succeeds_WithGeneratedSecret(t *testing.T) {
+	subscriptionDto := createTestWebhook(t, "https://erp.example.com/hooks", events.VoucherPosted, events.SLDeleted, events.VoucherPosted)
+
+	assert.Len(t, subscriptionDto.Secret, 64)
+	assert.True(t, subscriptionDto.Active)
+	assert.Equal(t, []string{events.SLDeleted, events.VoucherPosted}, subscriptionDto.EventTypes)
+
+	fetched, err := webhookService.GetWebhook(testAdmin, &webhook.GetRequest{ID: subscriptionDto.ID})
+	require.Nil(t, err)
+	assert.Empty(t, fetched.Secret)
+	assert.Equal(t, subscriptionDto.EventTypes, fetched.EventTypes)
+}
+
+func Test_CreateWebhook_ReturnsErrInvalidWebhookURL_WithRelativeURL(t *testing.T) {
+	_, err := webhookService.CreateWebhook(testAdmin, &webhook.InsertRequest{URL: "/hooks", EventTypes: []string{events.DLCreated}})
+
+	assert.ErrorIs(t, err, constants.ErrInvalidWebhookURL)
+}
+
+func Test_CreateWebhook_ReturnsErrUnknownEventType_WithUnknownType(t *testing.T) {
+	_, err := webhookService.CreateWebhook(testAdmin, &webhook.InsertRequest{URL: "https://erp.example.com/hooks", EventTypes: []string{"AccountArchived"}})
+
+	assert.ErrorIs(t, err, constants.ErrUnknownEventType)
+}
+
+func Test_CreateWebhook_ReturnsErrInvalidWebhookSecret_WithShortSecret(t *testing.T) {
+	_, err := webhookService.CreateWebhook(testAdmin, &webhook.InsertRequest{URL: "https://erp.example.com/hooks", EventTypes: []string{events.DLCreated}, Secret: "short"})
+
+	assert.ErrorIs(t, err, constants.ErrInvalidWebhookSecret)
+}
+
+func Test_GetWebhook_ReturnsErrForbidden_WithAccountant(t *testing.T) {
+	subscriptionDto := createTestWebhook(t, "https://erp.example.com/hooks", events.DLCreated)
+	accountant := auth.Principal{Username: "accountant", Role: auth.Accountant, TenantID: auth.DefaultTenantID}
+
+	_, err := webhookService.GetWebhook(accountant, &webhook.GetRequest{ID: subscriptionDto.ID})
+
+	assert.ErrorIs(t, err, constants.ErrForbidden)
+}
+
+func Test_DeliverPending_PostsSignedEvent_WhenDLIsCreated(t *testing.T) {
+	server, received := startWebhookReceiver(t, http.StatusOK)
+	subscriptionDto := createTestWebhook(t, server.URL, events.DLCreated)
+
+	dlDto, err := createRandomDL()
+	require.Nil(t, err)
+	deliverUntil(t, func() bool { return len(received()) > 0 })
+
+	require.Len(t, received(), 1)
+	request := received()[0]
+	assert.Equal(t, events.DLCreated, request.event.Type)
+	assert.Equal(t, dlDto.ID, request.event.AggregateID)
+	assert.Equal(t, events.DLCreated, request.header.Get(events.EventTypeHeader))
+	timestamp, err := strconv.ParseInt(request.header.Get(events.TimestampHeader), 10, 64)
+	require.Nil(t, err)
+	assert.True(t, events.VerifySignature(subscriptionDto.Secret, timestamp, request.body, request.header.Get(events.SignatureHeader)))
+
+	deliveries, err := webhookService.GetWebhookDeliveries(testAdmin, &webhook.GetDeliveriesRequest{SubscriptionID: subscriptionDto.ID})
+	require.Nil(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.DeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, deliveries[0].ResponseStatus)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+}
+
+func Test_DeliverPending_SchedulesRetry_WhenReceiverFails(t *testing.T) {
+	server, received := startWebhookReceiver(t, http.StatusInternalServerError)
+	subscriptionDto := createTestWebhook(t, server.URL, events.DLCreated)
+
+	_, err := createRandomDL()
+	require.Nil(t, err)
+	deliverUntil(t, func() bool { return len(received()) > 0 })
+
+	deliveries, err := webhookService.GetWebhookDeliveries(testAdmin, &webhook.GetDeliveriesRequest{SubscriptionID: subscriptionDto.ID})
+	require.Nil(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].ResponseStatus)
+	assert.NotEmpty(t, deliveries[0].LastError)
+	assert.Len(t, received(), 1)
+}
+
+func Test_ReplayWebhookDelivery_QueuesNewDelivery(t *testing.T) {
+	server, received := startWebhookReceiver(t, http.StatusOK)
+	subscriptionDto := createTestWebhook(t, server.URL, events.DLCreated)
+	_, err := createRandomDL()
+	require.Nil(t, err)
+	deliverUntil(t, func() bool { return len(received()) > 0 })
+	deliveries, err := webhookService.GetWebhookDeliveries(testAdmin, &webhook.GetDeliveriesRequest{SubscriptionID: subscriptionDto.ID})
+	require.Nil(t, err)
+	require.Len(t, deliveries, 1)
+
+	replay, err := webhookService.ReplayWebhookDelivery(testAdmin, &webhook.ReplayRequest{DeliveryID: deliveries[0].ID})
+	require.Nil(t, err)
+	deliverUntil(t, func() bool { return len(received()) > 1 })
+
+	assert.Equal(t, deliveries[0].ID, replay.ReplayOfID)
+	assert.Equal(t, deliveries[0].EventID, replay.EventID)
+	require.Len(t, received(), 2)
+	assert.Equal(t, received()[0].event.ID, received()[1].event.ID)
+	assert.NotEqual(t, received()[0].header.Get(events.DeliveryHeader), received()[1].header.Get(events.DeliveryHeader))
+}
+
+func Test_ReplayWebhookDelivery_ReturnsErrWebhookDeliveryNotFound_WithUnknownID(t *testing.T) {
+	_, err := webhookService.ReplayWebhookDelivery(testAdmin, &webhook.ReplayRequest{DeliveryID: int64(generateRandomInt64())})
+
+	assert.ErrorIs(t, err, constants.ErrWebhookDeliveryNotFound)
+}
+
+func Test_CreateVoucher_QueuesVoucherPostedDelivery(t *testing.T) {
+	subscriptionDto := createTestWebhook(t, "https://erp.example.com/hooks", events.VoucherPosted)
+
+	voucherDto, err := createRandomVoucher()
+	require.Nil(t, err)
+
+	deliveries, err := webhookService.GetWebhookDeliveries(testAdmin, &webhook.GetDeliveriesRequest{SubscriptionID: subscriptionDto.ID})
+	require.Nil(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, events.VoucherPosted, deliveries[0].EventType)
+	outboxEvents := findOutboxEvents(t, events.AggregateVoucher, voucherDto.ID)
+	assert.Equal(t, outboxEvents[len(outboxEvents)-1].ID, deliveries[0].EventID)
+}
+
+func Test_DeliverPending_KeepsDeliveryPending_WhenWebhookIsInactive(t *testing.T) {
+	server, received := startWebhookReceiver(t, http.StatusOK)
+	subscriptionDto := createTestWebhook(t, server.URL, events.DLCreated)
+	_, err := createRandomDL()
+	require.Nil(t, err)
+	inactive, err := webhookService.UpdateWebhook(testAdmin, &webhook.UpdateRequest{ID: subscriptionDto.ID, URL: subscriptionDto.URL, EventTypes: subscriptionDto.EventTypes, Active: false, Version: subscriptionDto.RowVersion})
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		webhookService.DeleteWebhook(testAdmin, &webhook.DeleteRequest{ID: inactive.ID, Version: inactive.RowVersion})
+	})
+
+	deliverUntil(t, func() bool { return len(received()) > 0 })
+
+	assert.Empty(t, received())
+	deliveries, err := webhookService.GetWebhookDeliveries(testAdmin, &webhook.GetDeliveriesRequest{SubscriptionID: subscriptionDto.ID})
+	require.Nil(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, 0, deliveries[0].Attempts)
+}
+
+func Test_ArchiveSL_QueuesSLArchivedDelivery(t *testing.T) {
+	subscriptionDto := createTestWebhook(t, "https://erp.example.com/hooks", events.SLArchived)
+	createdSL, err := createRandomSL(false)
+	require.Nil(t, err)
+
+	_, err = slService.ArchiveSL(testAdmin, &sl.ArchiveRequest{ID: createdSL.ID, Version: createdSL.RowVersion})
+	require.Nil(t, err)
+
+	deliveries, err := webhookService.GetWebhookDeliveries(testAdmin, &webhook.GetDeliveriesRequest{SubscriptionID: subscriptionDto.ID})
+	require.Nil(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, events.SLArchived, deliveries[0].EventType)
+}
@@ -0,0 +1,201 @@
+package textrule
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/internal/constants"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Class is a group of characters a field may be restricted to.
+type Class string
+
+const (
+	Letters     Class = "letters"
+	Digits      Class = "digits"
+	Spaces      Class = "spaces"
+	Punctuation Class = "punctuation"
+	Symbols     Class = "symbols"
+)
+
+// zeroWidthNonJoiner separates the parts of many Persian words and counts as
+// a letter.
+const zeroWidthNonJoiner = '\u200c'
+
+// columnLength is the VARCHAR length of every code, title and number column.
+const columnLength = 64
+
+// Rule constrains a normalized text value. Lengths count characters, as
+// VARCHAR does in Postgres. No classes means any character is allowed, and a
+// nil pattern means no format is enforced.
+type Rule struct {
+	MinLength int
+	MaxLength int
+	Classes   []Class
+	Pattern   *regexp.Regexp
+}
+
+// Field is a validated text column of an entity. Its rule can be tuned with
+// the environment variables <Name>_MIN_LENGTH, <Name>_MAX_LENGTH,
+// <Name>_CHARACTERS (a comma-separated list of classes) and <Name>_PATTERN.
+type Field struct {
+	Name      string
+	LengthErr error
+	FormatErr error
+	rule      Rule
+}
+
+var (
+	DLCode               = newField("DL_CODE", constants.ErrCodeEmptyOrTooLong, constants.ErrCodeFormatInvalid)
+	DLTitle              = newField("DL_TITLE", constants.ErrTitleEmptyOrTooLong, constants.ErrTitleFormatInvalid)
+	SLCode               = newField("SL_CODE", constants.ErrCodeEmptyOrTooLong, constants.ErrCodeFormatInvalid)
+	SLTitle              = newField("SL_TITLE", constants.ErrTitleEmptyOrTooLong, constants.ErrTitleFormatInvalid)
+	VoucherNumber        = newField("VOUCHER_NUMBER", constants.ErrNumberEmptyOrTooLong, constants.ErrNumberFormatInvalid)
+	VoucherTemplateCode  = newField("VOUCHER_TEMPLATE_CODE", constants.ErrCodeEmptyOrTooLong, constants.ErrCodeFormatInvalid)
+	VoucherTemplateTitle = newField("VOUCHER_TEMPLATE_TITLE", constants.ErrTitleEmptyOrTooLong, constants.ErrTitleFormatInvalid)
+	NumberSequenceCode   = newField("NUMBER_SEQUENCE_CODE", constants.ErrCodeEmptyOrTooLong, constants.ErrCodeFormatInvalid)
+	CurrencyTitle        = newField("CURRENCY_TITLE", constants.ErrTitleEmptyOrTooLong, constants.ErrTitleFormatInvalid)
+	TenantCode           = newField("TENANT_CODE", constants.ErrCodeEmptyOrTooLong, constants.ErrCodeFormatInvalid)
+	TenantTitle          = newField("TENANT_TITLE", constants.ErrTitleEmptyOrTooLong, constants.ErrTitleFormatInvalid)
+)
+
+func newField(name string, lengthErr error, formatErr error) *Field {
+	return &Field{Name: name, LengthErr: lengthErr, FormatErr: formatErr, rule: defaultRule()}
+}
+
+func defaultRule() Rule {
+	return Rule{MinLength: 1, MaxLength: columnLength}
+}
+
+func Fields() []*Field {
+	return []*Field{
+		DLCode, DLTitle, SLCode, SLTitle, VoucherNumber,
+		VoucherTemplateCode, VoucherTemplateTitle, NumberSequenceCode, CurrencyTitle, TenantCode, TenantTitle,
+	}
+}
+
+// Init reads the rule of every field from the environment, keeping the
+// default of 1 to 64 characters of any class for what is not set.
+func Init() error {
+	for _, field := range Fields() {
+		rule, err := readRule(field.Name)
+		if err != nil {
+			return err
+		}
+		field.rule = rule
+	}
+	return nil
+}
+
+func readRule(name string) (Rule, error) {
+	rule := defaultRule()
+	if value, err := configs.GetEnv(name + "_MIN_LENGTH"); err == nil {
+		if rule.MinLength, err = strconv.Atoi(value); err != nil {
+			return Rule{}, constants.ErrInvalidTextRule
+		}
+	}
+	if value, err := configs.GetEnv(name + "_MAX_LENGTH"); err == nil {
+		if rule.MaxLength, err = strconv.Atoi(value); err != nil {
+			return Rule{}, constants.ErrInvalidTextRule
+		}
+	}
+	if value, err := configs.GetEnv(name + "_CHARACTERS"); err == nil && value != "" {
+		for _, class := range strings.Split(value, ",") {
+			rule.Classes = append(rule.Classes, Class(strings.TrimSpace(class)))
+		}
+	}
+	if value, err := configs.GetEnv(name + "_PATTERN"); err == nil && value != "" {
+		if rule.Pattern, err = regexp.Compile(value); err != nil {
+			return Rule{}, constants.ErrInvalidTextRule
+		}
+	}
+	if err := rule.validate(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+func (r Rule) validate() error {
+	if r.MinLength < 1 || r.MaxLength < r.MinLength || r.MaxLength > columnLength {
+		return constants.ErrInvalidTextRule
+	}
+	for _, class := range r.Classes {
+		if !class.IsValid() {
+			return constants.ErrInvalidTextRule
+		}
+	}
+	return nil
+}
+
+func (f *Field) Rule() Rule {
+	return f.rule
+}
+
+// SetRule replaces the rule of the field.
+func (f *Field) SetRule(rule Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	f.rule = rule
+	return nil
+}
+
+// Validate returns the field's LengthErr when text is shorter or longer than
+// allowed and its FormatErr when it has a character outside the allowed
+// classes or does not match the pattern.
+func (f *Field) Validate(text string) error {
+	length := utf8.RuneCountInString(text)
+	if length < f.rule.MinLength || length > f.rule.MaxLength {
+		return f.LengthErr
+	}
+	if len(f.rule.Classes) > 0 && !f.rule.hasOnlyAllowedCharacters(text) {
+		return f.FormatErr
+	}
+	if f.rule.Pattern != nil && !f.rule.Pattern.MatchString(text) {
+		return f.FormatErr
+	}
+	return nil
+}
+
+func (r Rule) hasOnlyAllowedCharacters(text string) bool {
+	for _, character := range text {
+		allowed := false
+		for _, class := range r.Classes {
+			if class.contains(character) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Class) IsValid() bool {
+	switch c {
+	case Letters, Digits, Spaces, Punctuation, Symbols:
+		return true
+	}
+	return false
+}
+
+func (c Class) contains(character rune) bool {
+	switch c {
+	case Letters:
+		return unicode.IsLetter(character) || unicode.IsMark(character) || character == zeroWidthNonJoiner
+	case Digits:
+		return unicode.IsDigit(character)
+	case Spaces:
+		return character == ' '
+	case Punctuation:
+		return unicode.IsPunct(character)
+	case Symbols:
+		return unicode.IsSymbol(character)
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package txretry
+
+import (
+	"accountingsystem/configs"
+	"accountingsystem/internal/constants"
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// SQLSTATEs after which Postgres has aborted a transaction that may succeed
+// when it is run again.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+const maxDelay = time.Second
+
+var (
+	isolation  = sql.LevelDefault
+	maxRetries = 3
+	baseDelay  = 20 * time.Millisecond
+)
+
+var (
+	retries   atomic.Uint64
+	recovered atomic.Uint64
+	exhausted atomic.Uint64
+)
+
+// Stats counts what the runner did since the process started.
+type Stats struct {
+	// Retries is the number of times a transaction was run again.
+	Retries uint64
+	// Recovered is the number of transactions that committed after at least
+	// one retry.
+	Recovered uint64
+	// Exhausted is the number of transactions that still failed after the
+	// last retry.
+	Exhausted uint64
+}
+
+// Init reads TX_ISOLATION (read-committed, repeatable-read or serializable;
+// the database default when empty), TX_MAX_RETRIES (3 by default) and
+// TX_RETRY_BASE_DELAY (20ms by default).
+func Init() error {
+	isolation = sql.LevelDefault
+	if value, err := configs.GetEnv("TX_ISOLATION"); err == nil && value != "" {
+		level, ok := isolationLevels[strings.ToLower(value)]
+		if !ok {
+			return constants.ErrInvalidTxIsolation
+		}
+		isolation = level
+	}
+
+	maxRetries = 3
+	if value, err := configs.GetEnv("TX_MAX_RETRIES"); err == nil && value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 || count > 10 {
+			return constants.ErrInvalidTxRetry
+		}
+		maxRetries = count
+	}
+
+	baseDelay = 20 * time.Millisecond
+	if value, err := configs.GetEnv("TX_RETRY_BASE_DELAY"); err == nil && value != "" {
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay <= 0 || delay > maxDelay {
+			return constants.ErrInvalidTxRetry
+		}
+		baseDelay = delay
+	}
+	return nil
+}
+
+var isolationLevels = map[string]sql.IsolationLevel{
+	"read-committed":  sql.LevelReadCommitted,
+	"repeatable-read": sql.LevelRepeatableRead,
+	"serializable":    sql.LevelSerializable,
+}
+
+// Run runs fn in a transaction of db at the configured isolation level. When
+// Postgres aborts the transaction with a serialization failure or a
+// deadlock, fn is run again in a new transaction after a jittered backoff,
+// at most TX_MAX_RETRIES times; fn must therefore not keep state from a
+// failed attempt. Once the retries are used up, ErrTransactionConflict is
+// returned.
+//
+// When db is already in a transaction, fn runs in a savepoint of it and is
+// not retried, since the whole outer transaction has to be run again.
+func Run(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if inTransaction(db) {
+		return db.Transaction(fn)
+	}
+
+	var opts []*sql.TxOptions
+	if isolation != sql.LevelDefault {
+		opts = append(opts, &sql.TxOptions{Isolation: isolation})
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := db.Transaction(fn, opts...)
+		if err == nil {
+			if attempt > 0 {
+				recovered.Add(1)
+			}
+			return nil
+		}
+		if !IsRetryable(err) {
+			return err
+		}
+		if attempt == maxRetries {
+			exhausted.Add(1)
+			return constants.ErrTransactionConflict
+		}
+
+		retries.Add(1)
+		if err := sleep(db.Statement.Context, backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// IsRetryable reports whether err is a serialization failure or a deadlock.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+func Snapshot() Stats {
+	return Stats{
+		Retries:   retries.Load(),
+		Recovered: recovered.Load(),
+		Exhausted: exhausted.Load(),
+	}
+}
+
+func inTransaction(db *gorm.DB) bool {
+	committer, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok && committer != nil
+}
+
+// backoff picks a random delay up to baseDelay doubled per attempt, so that
+// transactions that collided do not collide again on the next attempt.
+func backoff(attempt int) time.Duration {
+	ceiling := baseDelay << attempt
+	if ceiling <= 0 || ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + 1
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}